@@ -10,11 +10,20 @@ import (
 	"github.com/justin/echome-be/config"
 	"github.com/justin/echome-be/internal/app"
 	character2 "github.com/justin/echome-be/internal/domain/character"
-	"github.com/justin/echome-be/internal/domain/conversation"
+	conversation2 "github.com/justin/echome-be/internal/domain/conversation"
+	job2 "github.com/justin/echome-be/internal/domain/job"
+	"github.com/justin/echome-be/internal/domain/speech"
+	transcription2 "github.com/justin/echome-be/internal/domain/transcription"
+	voice2 "github.com/justin/echome-be/internal/domain/voice"
 	"github.com/justin/echome-be/internal/handler"
 	"github.com/justin/echome-be/internal/infra/aliyun"
 	"github.com/justin/echome-be/internal/infra/character"
+	"github.com/justin/echome-be/internal/infra/conversation"
 	"github.com/justin/echome-be/internal/infra/db"
+	"github.com/justin/echome-be/internal/infra/job"
+	"github.com/justin/echome-be/internal/infra/storage"
+	"github.com/justin/echome-be/internal/infra/transcription"
+	"github.com/justin/echome-be/internal/infra/voice"
 )
 
 import (
@@ -38,10 +47,22 @@ func InitializeApplication(configPath2 string) (*app.Application, error) {
 	query := db.NewQuery(dbDB)
 	characterRepository := character.NewCharacterRepository(query)
 	aliClient := aliyun.ProvideAliClient(configConfig)
-	characterService := character2.NewCharacterService(characterRepository, aliClient)
+	storageConfig := config.GetStorageConfig(configConfig)
+	localStorage := storage.NewLocalStorage(storageConfig)
+	voiceRepository := voice.NewVoiceRepository(query)
+	sampleAnalyzer := voice.NewSampleAnalyzer()
+	jobRepository := job.NewJobRepository(query)
+	scheduler := job2.NewScheduler(jobRepository)
+	characterConfig := config.GetCharacterConfig(configConfig)
+	voiceService := voice2.NewVoiceService(voiceRepository, aliClient, sampleAnalyzer, localStorage, scheduler, characterConfig)
+	characterService := character2.NewCharacterService(characterRepository, aliClient, localStorage, voiceService, scheduler, characterConfig)
+	conversationRepository := conversation.NewConversationRepository(query)
 	tavilyConfig := config.GetTavilyConfig(configConfig)
-	conversationService := conversation.NewConversationService(aliClient, characterService, tavilyConfig)
-	handlers := handler.NewHandlers(characterService, aliClient, conversationService)
-	application := app.NewApplication(configConfig, handlers)
+	conversationService := conversation2.NewConversationService(aliClient, conversationRepository, characterService, tavilyConfig)
+	memoryJobRepository := transcription.NewMemoryJobRepository()
+	transcriptionService := transcription2.NewTranscriptionService(memoryJobRepository, aliClient, localStorage)
+	speechService := speech.NewSpeechService(aliClient, characterService, voiceService)
+	handlers := handler.NewHandlers(characterService, aliClient, conversationService, transcriptionService, speechService, voiceService)
+	application := app.NewApplication(configConfig, handlers, scheduler)
 	return application, nil
 }
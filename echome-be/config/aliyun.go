@@ -23,6 +23,23 @@ type TTSServiceConfig struct {
 	DefaultVoice   string `mapstructure:"default_voice"`
 	SampleRate     int    `mapstructure:"sample_rate"`
 	ResponseFormat string `mapstructure:"response_format"`
+	// Mode Qwen-TTS Realtime 提交模式: server_commit / commit
+	Mode string `mapstructure:"mode"`
+	// Cache CosyVoice 短句音频缓存
+	Cache TTSCacheConfig `mapstructure:"cache"`
+}
+
+// TTSCacheConfig defines TTS phrase cache configuration
+// 只缓存 pcm/wav 输出，mp3 和 opus 分句合成后无法直接拼接
+type TTSCacheConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	MaxMemoryMB int  `mapstructure:"max_memory_mb"`
+	// Dir 磁盘缓存目录，为空时只使用内存缓存
+	Dir string `mapstructure:"dir"`
+	// MaxDiskMB 磁盘缓存上限，超出后删除最久未使用的文件
+	MaxDiskMB int `mapstructure:"max_disk_mb"`
+	// MaxTextLength 可缓存句子的最大字数
+	MaxTextLength int `mapstructure:"max_text_length"`
 }
 
 // LLMServiceConfig defines LLM service configuration
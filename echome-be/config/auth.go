@@ -0,0 +1,16 @@
+package config
+
+// AuthConfig 鉴权相关配置
+type AuthConfig struct {
+	// JWTSecret 校验 HS256 访问令牌的密钥，为空时无法登录，所有请求都按匿名用户处理
+	JWTSecret string `mapstructure:"jwt_secret"`
+	// Disabled 关闭鉴权，所有请求都以本地管理员身份处理，只用于本地开发
+	Disabled bool `mapstructure:"disabled"`
+}
+
+func GetAuthConfig(cfg *Config) *AuthConfig {
+	if cfg == nil {
+		panic("config is nil")
+	}
+	return &cfg.Auth
+}
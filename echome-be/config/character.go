@@ -0,0 +1,17 @@
+package config
+
+// DefaultVoicePreviewText 默认的音色试听台词，{name} 会替换为角色名
+const DefaultVoicePreviewText = "你好，我是{name}，很高兴认识你。"
+
+// CharacterConfig 角色相关配置
+type CharacterConfig struct {
+	// VoicePreviewText 音色和没有开场白的角色合成的试听台词，{name} 会替换为音色名或角色名
+	VoicePreviewText string `mapstructure:"voice_preview_text"`
+}
+
+func GetCharacterConfig(cfg *Config) *CharacterConfig {
+	if cfg == nil {
+		panic("config is nil")
+	}
+	return &cfg.Character
+}
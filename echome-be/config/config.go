@@ -13,9 +13,12 @@ type Config struct {
 		Timeout     int    `mapstructure:"timeout"`
 		MaxRetries  int    `mapstructure:"max_retries"`
 	} `mapstructure:"ai"`
-	Aliyun   Aliyun         `mapstructure:"aliyun"`
-	Tavily   TavilyConfig   `mapstructure:"tavily"`
-	Database DatabaseConfig `mapstructure:"database"`
+	Aliyun    Aliyun          `mapstructure:"aliyun"`
+	Tavily    TavilyConfig    `mapstructure:"tavily"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Storage   StorageConfig   `mapstructure:"storage"`
+	Character CharacterConfig `mapstructure:"character"`
+	Auth      AuthConfig      `mapstructure:"auth"`
 }
 
 // TavilyConfig holds Tavily API configuration
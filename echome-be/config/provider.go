@@ -8,6 +8,9 @@ var ConfigProviderSet = wire.NewSet(
 	Load,
 	GetDatabaseConfig,
 	GetTavilyConfig,
+	GetStorageConfig,
+	GetCharacterConfig,
+	GetAuthConfig,
 )
 
 func GetTavilyConfig(cfg *Config) *TavilyConfig {
@@ -0,0 +1,17 @@
+package config
+
+// StorageConfig 本地文件存储配置
+type StorageConfig struct {
+	// Dir 文件保存目录
+	Dir string `mapstructure:"dir"`
+	// PublicBaseURL 服务对外可访问的地址，如 https://echome.example.com
+	// 配置后生成的文件URL可以被阿里云等外部服务下载
+	PublicBaseURL string `mapstructure:"public_base_url"`
+}
+
+func GetStorageConfig(cfg *Config) *StorageConfig {
+	if cfg == nil {
+		panic("config is nil")
+	}
+	return &cfg.Storage
+}
@@ -23,33 +23,60 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/character": {
-            "post": {
-                "description": "通过语音克隆创建角色",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/admin/characters/pending": {
+            "get": {
+                "description": "分页获取等待内容审核的角色，包括所有用户的角色，按创建时间倒序；只有管理员可以调用\n新建的角色和修改过内容的角色进入待审核，可见性和热词的修改不需要重新审核",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "characters"
+                    "reviews"
                 ],
-                "summary": "创建角色（语音克隆）",
+                "summary": "获取待审核角色",
                 "parameters": [
                     {
-                        "description": "创建角色的请求体参数",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateCharacterRequest"
-                        }
+                        "type": "string",
+                        "description": "检索词",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "所有者的用户ID",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否使用复刻音色",
+                        "name": "cloned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有该标签的角色",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量，默认20，最多100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上一页返回的 next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Page"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -60,6 +87,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -72,9 +117,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/characters": {
-            "get": {
-                "description": "获取所有可用角色的列表",
+        "/api/admin/characters/{id}/approve": {
+            "post": {
+                "description": "内容审核通过，音色也可用时角色变为可用；审核决定记录在角色的审核记录中，只有管理员可以调用",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,25 +127,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "characters"
+                    "reviews"
+                ],
+                "summary": "审核通过角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审核意见",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ApproveCharacterRequest"
+                        }
+                    }
                 ],
-                "summary": "获取所有角色",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/character.Character"
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色在审核期间被修改过",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/characters/{id}": {
-            "get": {
-                "description": "根据角色ID获取详细信息",
+        "/api/admin/characters/{id}/reject": {
+            "post": {
+                "description": "内容审核驳回，角色状态变为6，所有者修改内容后重新进入审核；审核决定记录在角色的审核记录中，只有管理员可以调用",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,9 +218,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "characters"
+                    "reviews"
                 ],
-                "summary": "获取角色详情",
+                "summary": "驳回角色",
                 "parameters": [
                     {
                         "type": "string",
@@ -118,6 +228,15 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "驳回原因",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RejectCharacterRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -126,45 +245,109 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/character.Character"
                         }
+                    },
+                    "400": {
+                        "description": "未填写原因时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色在审核期间被修改过",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/ws/asr": {
-            "get": {
-                "description": "建立语音识别的WebSocket连接，用于实时语音转文本",
-                "tags": [
-                    "websocket"
+        "/api/character": {
+            "post": {
+                "description": "创建归当前用户所有的角色，flag 为 true 时用 audio 复刻新音色并加入音色库，也可以通过 voice_id 使用音色库中已有的音色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "语音识别WebSocket连接",
-                "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
-                    }
-                }
-            }
-        },
-        "/ws/voice-conversation": {
-            "get": {
-                "description": "建立WebSocket连接，用户通过WebSocket消息发送语音或文本，返回AI生成的响应",
                 "tags": [
-                    "websocket"
+                    "characters"
                 ],
-                "summary": "语音对话WebSocket连接",
+                "summary": "创建角色",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "角色ID",
-                        "name": "characterId",
-                        "in": "query"
+                        "description": "创建角色的请求体参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateCharacterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "字段或音频样本不符合要求时 error.issues 列出具体问题",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -183,85 +366,4090 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "character.Character": {
-            "type": "object",
-            "properties": {
-                "audio_example": {
-                    "description": "AudioExample 音色示例音频URL",
-                    "type": "string"
-                },
-                "avatar": {
-                    "description": "角色头像URL",
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "description": "角色描述",
-                    "type": "string"
-                },
-                "flag": {
-                    "description": "是否克隆音色",
-                    "type": "boolean"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "description": "角色名",
-                    "type": "string"
-                },
-                "prompt": {
-                    "description": "角色提示词",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "音色状态，使用枚举值: 1-审核中, 2-可用, 3-禁用",
-                    "type": "integer"
+        },
+        "/api/characters": {
+            "get": {
+                "description": "分页获取当前用户可见的角色列表，包括公开角色和自己的角色，默认按创建时间倒序；q 检索角色名和描述，支持中文\n默认只返回可用的角色，owner=me 时默认返回自己所有状态的角色\n第一页的 facets 统计符合条件的所有角色中最常见的标签及角色数，可用于按标签筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "获取角色列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "检索词",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "所有者的用户ID，me 表示当前用户",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态: 1-审核中, 2-可用, 3-禁用, 4-复刻失败, 5-音色审核未通过, 6-内容审核驳回，all 表示不过滤，默认2",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否使用复刻音色",
+                        "name": "cloned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有该标签的角色",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序方式: newest 按创建时间, popular 按语音对话次数，默认newest",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量，默认20，最多100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上一页返回的 next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Page"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "owner=me 但未登录",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/import": {
+            "post": {
+                "description": "从 SillyTavern/TavernAI 角色卡创建角色，支持 V1/V2 JSON 和嵌入角色卡的 PNG，可以用 multipart 字段 file 上传或直接作为请求体发送\nsystem_prompt、description、personality、scenario 组成提示词，first_mes 和 alternate_greetings 作为开场白，mes_example 拆分为对话示例，tags 作为角色标签，creator_notes 作为角色描述，PNG 图片作为角色头像",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json",
+                    "image/png"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "导入角色卡",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "角色卡文件",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "可见性: private/unlisted/public，默认 private",
+                        "name": "visibility",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "角色卡无法解析，或字段不符合要求时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色名已被其他角色使用",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}": {
+            "get": {
+                "description": "根据角色ID获取详细信息，其他用户的私有角色按不存在处理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "获取角色详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "整体替换角色的可编辑字段，省略的可选字段会被清空；voice_id 为空时使用默认音色。热词和音色样本通过各自的接口修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "更新角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReplaceCharacterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "软删除角色，30 天内可通过 POST /api/characters/{id}/restore 恢复，之后角色连同热词表和试听音频一起清除；创建角色时复刻的音色在最后一个引用它的角色清除后删除，其他音色保留在音色库中",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "只修改请求中出现的字段，可选字段传 null 表示清空；voice_id 传 null 时使用默认音色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "部分更新角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要修改的字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PatchCharacterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/export": {
+            "get": {
+                "description": "把角色导出为 Character Card V2 JSON，或嵌入角色卡的 PNG 头像；提示词导出为 description，开场白导出为 first_mes 和 alternate_greetings，对话示例导出为 mes_example，标签导出为 tags，角色描述导出为 creator_notes\n只有保存在本服务的头像会嵌入 PNG，外部地址的头像使用占位图，原地址保存在 extensions.echome.avatar",
+                "produces": [
+                    "application/json",
+                    "image/png"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "导出角色卡",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "导出格式: json/png，默认 json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/favorite": {
+            "put": {
+                "description": "收藏当前用户可见的角色，重复收藏不报错",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "收藏角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "取消收藏，未收藏时不报错",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "取消收藏角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/fork": {
+            "post": {
+                "description": "以可见的角色为模板创建归当前用户所有的新角色，复制提示词、描述、头像、开场白、对话示例、热词、标签和音色，forked_from 记录来源角色\n新角色沿用来源角色的许可并重新进入审核；其他用户的角色须审核通过且 allow_fork 为 true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "复制角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "来源角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新角色的名称和可见性",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ForkCharacterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "来源角色不可用或不允许复制",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色名已被使用",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/hotwords": {
+            "put": {
+                "description": "覆盖角色的 ASR 热词列表并同步到阿里云热词表，空列表等同于删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "更新角色热词",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "热词列表，weight 取值1~5，默认4",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateHotwordsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "清空角色的 ASR 热词并删除阿里云热词表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "删除角色热词",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/restore": {
+            "post": {
+                "description": "恢复 30 天保留期内已删除的角色，已清除的角色返回 404；删除期间其音色被删除时改用默认音色",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "恢复角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "角色不存在或未被删除",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色名已被其他角色使用",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/reviews": {
+            "get": {
+                "description": "获取角色每次审核通过或驳回的记录，按时间倒序，只有所有者可以查看",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "获取角色审核记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/character.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/stats": {
+            "get": {
+                "description": "获取角色的语音对话次数、对话轮数、语音时长、用户数和收藏数，以及最近若干天每天的统计，只有所有者可以查看",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "获取角色使用统计",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每天统计的天数，包括今天，默认30，最多90",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Stats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/tags": {
+            "put": {
+                "description": "覆盖角色的标签，空列表表示清空；标签名保存时转换为小写，不存在的标签自动创建",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "设置角色标签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SetCharacterTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "标签不符合要求时 error.issues 列出具体问题",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/versions": {
+            "get": {
+                "description": "列出角色每次修改后保存的版本，按版本号倒序，只有角色所有者可以查看",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "获取角色历史版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/character.Version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/versions/diff": {
+            "get": {
+                "description": "返回两个版本之间有变化的字段，提示词和描述附带逐行差异",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "比较角色的两个版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "旧版本号",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "新版本号",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.VersionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "获取角色的指定版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Version"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/versions/{version}/rollback": {
+            "post": {
+                "description": "把角色恢复为指定版本的内容，包括热词和音色；回滚会产生一个新版本，历史版本保持不变",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "回滚角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "要恢复的版本号",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "版本引用的音色已被删除或不可用",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "角色名已被其他角色使用",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/voice": {
+            "put": {
+                "description": "通过 voice_id 换成音色库中的音色，无需重新复刻；或通过 audio 用新样本重新复刻角色当前使用的音色，使用该音色的角色都会回到审核中",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "更换角色音色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "音色ID或音频样本URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateCharacterVoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "音频样本不符合要求时 error.issues 列出具体问题",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/voice/preview": {
+            "post": {
+                "description": "用尚未保存的语速、语调、音量和模型合成角色的试听音频，返回 mp3，调好后通过更新角色接口保存到 speech 字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "audio/mpeg"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "试听语音合成参数",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "试听参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PreviewSpeechRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/characters/{id}/voice/recheck": {
+            "post": {
+                "description": "立即查询角色所用复刻音色的审核状态，复刻失败的音色回到审核中并重新开始检查",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "characters"
+                ],
+                "summary": "重新检查音色审核状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是角色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/favorites": {
+            "get": {
+                "description": "分页获取当前用户收藏的角色，包括所有状态，已对当前用户不可见的角色不返回",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "获取我收藏的角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "检索词",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有该标签的角色",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序方式: newest, popular，默认newest",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量，默认20，最多100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上一页返回的 next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Page"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "description": "获取所有标签，精选分类按展示顺序排在前面，其余按名称排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "获取标签",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "为 true 时只返回精选分类",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/character.Tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建标签或精选分类，只有管理员可以调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "创建标签",
+                "parameters": [
+                    {
+                        "description": "标签",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/character.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "同名标签已存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/{id}": {
+            "delete": {
+                "description": "删除标签并从所有角色上移除，只有管理员可以调用",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "删除标签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "标签ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "修改标签名、是否为精选分类及展示顺序，只有管理员可以调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "修改标签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "标签ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要修改的字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/character.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "字段校验失败时 error.issues 列出具体字段",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是管理员",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "同名标签已存在",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transcriptions": {
+            "post": {
+                "description": "上传音频文件（multipart 字段 file，支持 wav/mp3/m4a）或提交 JSON {\"url\": \"...\"}，返回异步任务，通过 GET /api/transcriptions/{id} 轮询结果",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcriptions"
+                ],
+                "summary": "创建文件转写任务",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "音频文件",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "音频格式，默认取文件扩展名",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "音频URL",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTranscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/transcription.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transcriptions/{id}": {
+            "get": {
+                "description": "查询转写任务状态，完成后返回全文和句子时间戳",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcriptions"
+                ],
+                "summary": "查询转写任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transcription.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transcriptions/{id}/subtitles": {
+            "get": {
+                "description": "以 SRT 或 WebVTT 格式导出已完成任务的字幕",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "transcriptions"
+                ],
+                "summary": "导出字幕",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "字幕格式: srt/vtt，默认srt",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tts": {
+            "post": {
+                "description": "合成一段文本，默认返回完整音频文件；stream 为 true 时以分块传输边合成边返回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "audio/mpeg",
+                    "audio/wav",
+                    "audio/ogg",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "tts"
+                ],
+                "summary": "文本转语音",
+                "parameters": [
+                    {
+                        "description": "合成参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SynthesizeSpeechRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "角色尚未审核通过",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/voices": {
+            "get": {
+                "description": "列出音色库中的模型自带音色和当前用户自己的复刻音色，管理员可以看到所有音色",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "获取音色库",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/voice.Voice"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "登记模型自带音色，或用音频样本复刻新音色；复刻音色审核通过前处于 pending 状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "创建音色",
+                "parameters": [
+                    {
+                        "description": "音色参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateVoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/voice.Voice"
+                        }
+                    },
+                    "400": {
+                        "description": "音频样本不符合要求时 error.issues 列出具体问题",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/voices/{id}": {
+            "get": {
+                "description": "其他用户的复刻音色按不存在处理",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "获取音色详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "音色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/voice.Voice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除未被角色使用的音色，复刻音色同时从上游删除以释放配额",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "删除音色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "音色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是音色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "修改音色名，或为复刻音色更换音频样本；更换样本后音色及使用它的角色回到审核中。只有所有者可以修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "更新音色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "音色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要修改的字段",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateVoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/voice.Voice"
+                        }
+                    },
+                    "400": {
+                        "description": "音频样本不符合要求时 error.issues 列出具体问题",
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是音色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/voices/{id}/recheck": {
+            "post": {
+                "description": "立即查询复刻音色的审核状态，复刻失败的音色回到审核中并重新开始检查",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "voices"
+                ],
+                "summary": "重新检查音色审核状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "音色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/voice.Voice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "不是音色的所有者",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/asr": {
+            "get": {
+                "description": "建立语音识别的WebSocket连接，用于实时语音转文本。\n音频格式可以通过查询参数声明，也可以在首条消息中发送 {\"type\":\"start\",\"format\":\"webm\",\"sample_rate\":48000,\"channels\":1}。\n支持 pcm（任意采样率、多声道）、wav、ogg/opus、webm/opus。pcm/wav 下混为单声道并重采样后送入识别；\nOpus 不在服务端解码，只接受单声道：ogg/opus 原样转发，webm/opus 转封装为 ogg/opus，由识别服务按 48kHz 解码。\n首条文本消息不是合法的 start 消息时返回 asr_error 并关闭识别。",
+                "tags": [
+                    "websocket"
+                ],
+                "summary": "语音识别WebSocket连接",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "音频格式: pcm/wav/ogg/opus/webm，默认pcm",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "PCM采样率，支持 8000/11025/12000/16000/22050/24000/32000/44100/48000，默认16000",
+                        "name": "sample_rate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "PCM声道数，默认1",
+                        "name": "channels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色ID，使用该角色的热词表",
+                        "name": "characterId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        },
+        "/ws/interpreter": {
+            "get": {
+                "description": "建立同声传译的WebSocket连接，客户端发送语音，服务端返回原文、译文事件和译文语音",
+                "tags": [
+                    "websocket"
+                ],
+                "summary": "同声传译WebSocket连接",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "源语言，默认zh",
+                        "name": "source_lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "目标语言，默认en",
+                        "name": "target_lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否根据识别语言自动切换翻译方向，默认true",
+                        "name": "auto_switch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "译文播报音色",
+                        "name": "voice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "TTS音频格式: pcm/wav/mp3/opus",
+                        "name": "audio_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "TTS采样率",
+                        "name": "sample_rate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/voice-conversation": {
+            "get": {
+                "description": "建立WebSocket连接，用户通过WebSocket消息发送语音或文本，返回AI生成的响应\n指定角色时，服务端用角色提示词代替消息开头的系统消息，并把对话示例作为之前的轮次插入上下文；\n角色有开场白时，连接后先发送 {\"type\":\"greeting\",\"content\":\"...\"} 和开场白语音，再发送 {\"type\":\"greeting_end\"}，客户端无需把开场白放进消息历史",
+                "tags": [
+                    "websocket"
+                ],
+                "summary": "语音对话WebSocket连接",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "角色ID，其他用户的角色须可见且审核通过",
+                        "name": "characterId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "访问令牌，浏览器无法为 WebSocket 设置 Authorization 头时使用",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "TTS音频格式: pcm/wav/mp3/opus",
+                        "name": "audio_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "TTS采样率",
+                        "name": "sample_rate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "ai.Hotword": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "description": "Lang 语言代码，如 zh、en，为空时由服务端判断",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight 权重，取值 1~5，越大越容易被识别",
+                    "type": "integer"
+                }
+            }
+        },
+        "ai.Transcript": {
+            "type": "object",
+            "properties": {
+                "sentences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.TranscriptSentence"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "ai.TranscriptSentence": {
+            "type": "object",
+            "properties": {
+                "begin_time": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "character.Card": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/character.CardData"
+                },
+                "spec": {
+                    "type": "string"
+                },
+                "spec_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "character.CardData": {
+            "type": "object",
+            "properties": {
+                "alternate_greetings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "character_version": {
+                    "type": "string"
+                },
+                "creator": {
+                    "type": "string"
+                },
+                "creator_notes": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "extensions": {
+                    "description": "Extensions 各应用的扩展字段，本服务的字段在 echome 键下",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "first_mes": {
+                    "description": "FirstMes 角色的开场白",
+                    "type": "string"
+                },
+                "mes_example": {
+                    "description": "MesExample 对话示例，每段以 \u003cSTART\u003e 开头",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "personality": {
+                    "type": "string"
+                },
+                "post_history_instructions": {
+                    "type": "string"
+                },
+                "scenario": {
+                    "type": "string"
+                },
+                "system_prompt": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "character.Character": {
+            "type": "object",
+            "properties": {
+                "allow_fork": {
+                    "description": "AllowFork 是否允许其他用户复制该角色，所有者始终可以复制自己的角色",
+                    "type": "boolean"
+                },
+                "alternate_greetings": {
+                    "description": "AlternateGreetings 备选开场白，连接时从开场白和备选开场白中随机选择一条",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "audio_example": {
+                    "description": "AudioExample 音色示例音频URL",
+                    "type": "string"
+                },
+                "avatar": {
+                    "description": "角色头像URL",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "角色描述",
+                    "type": "string"
+                },
+                "example_dialogues": {
+                    "description": "ExampleDialogues 对话示例，对话时作为之前的轮次放在上下文开头",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.ExampleDialogue"
+                    }
+                },
+                "favorite_count": {
+                    "description": "FavoriteCount 收藏数",
+                    "type": "integer"
+                },
+                "flag": {
+                    "description": "是否克隆音色",
+                    "type": "boolean"
+                },
+                "forked_from": {
+                    "description": "ForkedFrom 复制来源角色的ID，来源角色被删除后保留",
+                    "type": "string"
+                },
+                "greeting": {
+                    "description": "Greeting 开场白，语音对话连接后由角色用自己的音色说出",
+                    "type": "string"
+                },
+                "hotwords": {
+                    "description": "Hotwords ASR 热词，提高角色名等专有名词的识别率",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.Hotword"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "license": {
+                    "description": "License 所有者声明的使用许可，复制的角色沿用来源角色的许可",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "角色名",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID 角色所有者的用户ID，为空的是引入所有权之前创建的角色，只有管理员可以修改",
+                    "type": "string"
+                },
+                "prompt": {
+                    "description": "角色提示词",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "description": "ReviewReason 内容审核驳回的原因",
+                    "type": "string"
+                },
+                "review_status": {
+                    "description": "ReviewStatus 内容审核状态: pending / approved / rejected",
+                    "type": "string"
+                },
+                "session_count": {
+                    "description": "SessionCount 语音对话次数，用于按热度排序",
+                    "type": "integer"
+                },
+                "speech": {
+                    "description": "Speech 语音合成参数，对话和合成时应用在角色音色上",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/character.SpeechSettings"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "角色状态，由 VoiceStatus 和 ReviewStatus 决定: 1-审核中, 2-可用, 3-禁用, 4-复刻失败, 5-音色审核未通过, 6-内容审核驳回",
+                    "type": "integer"
+                },
+                "status_reason": {
+                    "description": "StatusReason 复刻失败、音色审核未通过或内容审核驳回的原因",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags 角色的标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version 当前版本号，每次修改可编辑字段后递增",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "Visibility 可见性: private, unlisted, public",
+                    "type": "string"
+                },
+                "vocabulary_id": {
+                    "description": "VocabularyID 热词同步到阿里云后得到的热词表ID",
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "角色音色，与 VoiceID 对应音色的上游音色保持一致",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "VoiceID 音色库中的音色ID，为空时使用默认音色",
+                    "type": "string"
+                },
+                "voice_preview_url": {
+                    "description": "VoicePreviewURL 音色可用后用该音色合成的角色试听音频URL",
+                    "type": "string"
+                },
+                "voice_status": {
+                    "description": "VoiceStatus 音色状态: pending / ready / failed / rejected，使用默认音色时为 ready",
+                    "type": "string"
+                }
+            }
+        },
+        "character.DailyStats": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "sessions": {
+                    "type": "integer"
+                },
+                "turns": {
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "type": "integer"
+                },
+                "voice_minutes": {
+                    "type": "number"
+                }
+            }
+        },
+        "character.DialogueTurn": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role 发言方: user 为用户，assistant 为角色",
+                    "type": "string"
+                }
+            }
+        },
+        "character.ExampleDialogue": {
+            "type": "object",
+            "properties": {
+                "turns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.DialogueTurn"
+                    }
+                }
+            }
+        },
+        "character.FieldChange": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "description": "Diff 多行文本字段的逐行差异，删除的行以 \"-\" 开头，新增的行以 \"+\" 开头，未变的行以空格开头",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "character.Page": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "description": "Facets 符合查询条件的所有角色中最常见的标签及角色数，只在第一页返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.TagCount"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.Character"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor 下一页的游标，没有更多数据时为空",
+                    "type": "string"
+                }
+            }
+        },
+        "character.Review": {
+            "type": "object",
+            "properties": {
+                "character_id": {
+                    "type": "string"
+                },
+                "character_version": {
+                    "description": "CharacterVersion 审核时角色的版本号",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decision": {
+                    "description": "Decision 审核结果: approved, rejected",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason 审核意见，驳回时必填",
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "description": "ReviewerID 审核的管理员用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "character.SpeechSettings": {
+            "type": "object",
+            "properties": {
+                "pitch": {
+                    "description": "Pitch 语调，取值 0.5~2.0，0 表示默认语调",
+                    "type": "number"
+                },
+                "rate": {
+                    "description": "Rate 语速，取值 0.5~2.0，0 表示默认语速",
+                    "type": "number"
+                },
+                "tts_model": {
+                    "description": "TTSModel 覆盖音色默认模型的 TTS 模型，须与音色库音色同属 CosyVoice 或 Qwen-TTS，复刻音色不能覆盖",
+                    "type": "string"
+                },
+                "volume": {
+                    "description": "Volume 音量，取值 1~100，0 表示默认音量",
+                    "type": "integer"
+                }
+            }
+        },
+        "character.Stats": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "Daily 最近若干天每天的统计，按日期升序，没有对话的日期不返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.DailyStats"
+                    }
+                },
+                "favorites": {
+                    "description": "Favorites 收藏数",
+                    "type": "integer"
+                },
+                "sessions": {
+                    "description": "Sessions 语音对话次数",
+                    "type": "integer"
+                },
+                "turns": {
+                    "description": "Turns 对话轮数，用户每发送一条消息并得到回复算一轮",
+                    "type": "integer"
+                },
+                "unique_users": {
+                    "description": "UniqueUsers 发起过对话的登录用户数",
+                    "type": "integer"
+                },
+                "voice_minutes": {
+                    "description": "VoiceMinutes 已结束对话的总时长，单位分钟",
+                    "type": "number"
+                }
+            }
+        },
+        "character.Tag": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category 是否为精选分类",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "SortOrder 分类的展示顺序，从小到大",
+                    "type": "integer"
+                }
+            }
+        },
+        "character.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "character.Version": {
+            "type": "object",
+            "properties": {
+                "allow_fork": {
+                    "type": "boolean"
+                },
+                "alternate_greetings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "audio_example": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "description": "AuthorID 修改者的用户ID",
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "change": {
+                    "description": "Change 产生该版本的操作",
+                    "type": "string"
+                },
+                "character_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "example_dialogues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.ExampleDialogue"
+                    }
+                },
+                "greeting": {
+                    "type": "string"
+                },
+                "hotwords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.Hotword"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "license": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "rolled_back_from": {
+                    "description": "RolledBackFrom 回滚产生的版本记录恢复的版本号",
+                    "type": "integer"
+                },
+                "speech": {
+                    "$ref": "#/definitions/character.SpeechSettings"
+                },
+                "tags": {
+                    "description": "Tags 标签，为 nil 的是记录标签之前保存的版本，回滚时保持标签不变",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "description": "Version 版本号，从1开始递增",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "voice_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "character.VersionDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.FieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "issues": {
+                    "description": "Issues 校验失败的具体问题，供客户端逐条提示"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/domain.APIError"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ApproveCharacterRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "可选，审核意见，最多500字",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "可选，审核的角色版本号，与当前版本不一致时返回409",
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateCharacterRequest": {
+            "type": "object",
+            "properties": {
+                "allow_fork": {
+                    "description": "可选，是否允许其他用户复制，默认 true",
+                    "type": "boolean"
+                },
+                "alternate_greetings": {
+                    "description": "可选，备选开场白，最多20条",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "audio": {
+                    "description": "可选，音频文件",
+                    "type": "string"
+                },
+                "audio_example": {
+                    "description": "可选，音频示例",
+                    "type": "string"
+                },
+                "avatar": {
+                    "description": "可选，角色头像",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "可选，角色描述",
+                    "type": "string"
+                },
+                "example_dialogues": {
+                    "description": "可选，对话示例，最多20段，每段最多20轮",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.ExampleDialogue"
+                    }
+                },
+                "flag": {
+                    "description": "必须，为 true 时用 audio 复刻新音色",
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "greeting": {
+                    "description": "可选，开场白，最多2000字",
+                    "type": "string"
+                },
+                "hotwords": {
+                    "description": "可选，ASR 热词",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.Hotword"
+                    }
+                },
+                "license": {
+                    "description": "可选，使用许可，最多100字",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "必须，角色名称",
+                    "type": "string"
+                },
+                "prompt": {
+                    "description": "必须，角色提示词",
+                    "type": "string"
+                },
+                "speech": {
+                    "description": "可选，语音合成参数，省略的字段使用默认值",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/character.SpeechSettings"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "可选，标签，最多10个，不存在的标签自动创建",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "visibility": {
+                    "description": "可选，可见性: private/unlisted/public，默认 private",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "可选，使用音色库中的音色，与 flag 互斥",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateTagRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "可选，是否为精选分类",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "必须，标签名，最多20字，保存时转换为小写",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "可选，分类的展示顺序，从小到大",
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateTranscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "description": "必须，可公开访问的音频地址",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateVoiceRequest": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "description": "可选，合成使用的模型，默认 cosyvoice-v2",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "必须，音色名",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "可选，builtin/clone，默认按是否提供 sample_url 推断",
+                    "type": "string"
+                },
+                "sample_url": {
+                    "description": "clone 必须，复刻使用的音频样本URL",
                     "type": "string"
                 },
                 "voice": {
-                    "description": "角色音色",
+                    "description": "builtin 必须，模型自带的音色名",
                     "type": "string"
                 }
             }
         },
-        "handler.CreateCharacterRequest": {
+        "handler.ForkCharacterRequest": {
             "type": "object",
             "properties": {
-                "audio": {
-                    "description": "可选，音频文件",
+                "name": {
+                    "description": "可选，新角色名，默认为“来源角色名的副本”",
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "可选，可见性: private/unlisted/public，默认 private",
                     "type": "string"
+                }
+            }
+        },
+        "handler.PatchCharacterRequest": {
+            "type": "object",
+            "properties": {
+                "allow_fork": {
+                    "description": "AllowFork 是否允许其他用户复制，传 null 表示恢复为允许",
+                    "type": "boolean"
+                },
+                "alternate_greetings": {
+                    "description": "AlternateGreetings 备选开场白，传 null 或空列表表示清空",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "audio_example": {
-                    "description": "可选，音频示例",
                     "type": "string"
                 },
                 "avatar": {
-                    "description": "可选，角色头像",
                     "type": "string"
                 },
                 "description": {
-                    "description": "可选，角色描述",
                     "type": "string"
                 },
-                "flag": {
-                    "description": "必须，标志位",
+                "example_dialogues": {
+                    "description": "ExampleDialogues 对话示例，传 null 或空列表表示清空",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "greeting": {
+                    "type": "string"
+                },
+                "license": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "speech": {
+                    "description": "Speech 语音合成参数，整体替换，传 null 表示恢复默认",
+                    "type": "object"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "voice_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PreviewSpeechRequest": {
+            "type": "object",
+            "properties": {
+                "speech": {
+                    "description": "必须，要试听的语音合成参数，不会保存",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/character.SpeechSettings"
+                        }
+                    ]
+                },
+                "text": {
+                    "description": "可选，试听文本，最多200字，默认为角色的开场白或试听台词",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RejectCharacterRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "必须，驳回原因，最多500字，所有者可以看到",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "可选，审核的角色版本号，与当前版本不一致时返回409",
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ReplaceCharacterRequest": {
+            "type": "object",
+            "properties": {
+                "allow_fork": {
+                    "description": "可选，是否允许其他用户复制，省略时为 true",
                     "type": "boolean"
                 },
+                "alternate_greetings": {
+                    "description": "可选，备选开场白，最多20条",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "audio_example": {
+                    "description": "可选，示例音频URL",
+                    "type": "string"
+                },
+                "avatar": {
+                    "description": "可选，角色头像URL",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "可选，角色描述，最多500字",
+                    "type": "string"
+                },
+                "example_dialogues": {
+                    "description": "可选，对话示例，最多20段，每段最多20轮",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/character.ExampleDialogue"
+                    }
+                },
+                "greeting": {
+                    "description": "可选，开场白，最多2000字",
+                    "type": "string"
+                },
+                "license": {
+                    "description": "可选，使用许可，最多100字",
+                    "type": "string"
+                },
                 "name": {
-                    "description": "必须，角色名称",
+                    "description": "必须，角色名称，最多50字",
                     "type": "string"
                 },
                 "prompt": {
                     "description": "必须，角色提示词",
                     "type": "string"
+                },
+                "speech": {
+                    "description": "可选，语音合成参数，省略的字段使用默认值",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/character.SpeechSettings"
+                        }
+                    ]
+                },
+                "visibility": {
+                    "description": "必须，可见性: private/unlisted/public",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "可选，音色库中的音色，为空时使用默认音色",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SetCharacterTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "description": "最多10个，不存在的标签自动创建",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.SynthesizeSpeechRequest": {
+            "type": "object",
+            "properties": {
+                "character_id": {
+                    "description": "可选，使用角色的音色，与 voice_id、voice 三选一",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "可选，pcm/wav/mp3/opus，默认mp3",
+                    "type": "string"
+                },
+                "sample_rate": {
+                    "description": "可选，输出采样率",
+                    "type": "integer"
+                },
+                "speed": {
+                    "description": "可选，语速 0.5~2.0，默认1.0",
+                    "type": "number"
+                },
+                "stream": {
+                    "description": "可选，为 true 时以分块传输边合成边返回",
+                    "type": "boolean"
+                },
+                "text": {
+                    "description": "必须，最多2000字",
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "可选，模型自带的音色名",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "可选，使用音色库中的音色",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateCharacterVoiceRequest": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "description": "可选，新的音频样本URL，重新复刻角色当前使用的音色",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "可选，换成音色库中的音色",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateHotwordsRequest": {
+            "type": "object",
+            "properties": {
+                "hotwords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ai.Hotword"
+                    }
+                }
+            }
+        },
+        "handler.UpdateTagRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.UpdateVoiceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "可选，新的音色名",
+                    "type": "string"
+                },
+                "sample_url": {
+                    "description": "可选，新的音频样本URL，会重新复刻音色",
+                    "type": "string"
+                }
+            }
+        },
+        "transcription.Job": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error 任务失败原因",
+                    "type": "string"
+                },
+                "file_name": {
+                    "description": "FileName 来源为 upload 时的原始文件名",
+                    "type": "string"
+                },
+                "file_url": {
+                    "description": "FileURL 来源为 url 时的音频地址",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/ai.Transcript"
+                },
+                "source": {
+                    "description": "Source 音频来源: url / upload",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/transcription.Status"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "transcription.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "StatusFailed": "失败",
+                "StatusPending": "排队中",
+                "StatusRunning": "转写中",
+                "StatusSucceeded": "已完成"
+            },
+            "x-enum-descriptions": [
+                "排队中",
+                "转写中",
+                "已完成",
+                "失败"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusRunning",
+                "StatusSucceeded",
+                "StatusFailed"
+            ]
+        },
+        "voice.Voice": {
+            "type": "object",
+            "properties": {
+                "character_owned": {
+                    "description": "CharacterOwned 创建角色时随角色复刻的音色，最后一个引用它的角色清除后自动删除",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Model 合成使用的模型，复刻音色只能由复刻时指定的模型合成",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name 音色名",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID 音色所有者",
+                    "type": "string"
+                },
+                "preview_url": {
+                    "description": "PreviewURL 音色可用后合成的试听音频",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Provider 音色来源: builtin / clone",
+                    "type": "string"
+                },
+                "sample_url": {
+                    "description": "SampleURL 复刻使用的音频样本",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status 音色状态: pending / ready / failed / rejected",
+                    "type": "string"
+                },
+                "status_reason": {
+                    "description": "StatusReason 复刻失败或审核未通过的原因",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "Voice 上游音色：模型自带的音色名或复刻得到的音色ID",
+                    "type": "string"
                 }
             }
         }
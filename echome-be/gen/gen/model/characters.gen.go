@@ -6,23 +6,50 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNameCharacter = "characters"
 
 // Character mapped from table <characters>
 type Character struct {
-	ID           string    `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid();comment:角色ID" json:"id"`                                                  // 角色ID
-	Name         string    `gorm:"column:name;type:text;not null;comment:角色名" json:"name"`                                                                           // 角色名
-	Prompt       string    `gorm:"column:prompt;type:text;not null;comment:角色提示词" json:"prompt"`                                                                     // 角色提示词
-	Avatar       *string   `gorm:"column:avatar;type:text;comment:角色头像地址" json:"avatar"`                                                                             // 角色头像地址
-	AudioExample *string   `gorm:"column:audio_example;type:text;comment:示例音频" json:"audio_example"`                                                                 // 示例音频
-	CreatedAt    time.Time `gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP;autoCreateTime;comment:创建时间" json:"created_at"` // 创建时间
-	UpdatedAt    time.Time `gorm:"column:updated_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP;autoUpdateTime;comment:更新时间" json:"updated_at"` // 更新时间
-	Voice        *string   `gorm:"column:voice;type:text;comment:自定义音色" json:"voice"`                                                                                // 自定义音色
-	Description  *string   `gorm:"column:description;type:text;comment:角色描述" json:"description"`                                                                     // 角色描述
-	Flag         bool      `gorm:"column:flag;type:boolean;not null;comment:是否克隆" json:"flag"`                                                                       // 是否克隆
-	Status       int32     `gorm:"column:status;type:integer;not null;default:3;comment:1.审核中2.可用3.禁用" json:"status"`                                                // 1.审核中2.可用3.禁用
+	ID                 string         `gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid();comment:角色ID" json:"id"`                                                      // 角色ID
+	Name               string         `gorm:"column:name;type:text;not null;comment:角色名" json:"name"`                                                                               // 角色名
+	Prompt             string         `gorm:"column:prompt;type:text;not null;comment:角色提示词" json:"prompt"`                                                                         // 角色提示词
+	Avatar             *string        `gorm:"column:avatar;type:text;comment:角色头像地址" json:"avatar"`                                                                                 // 角色头像地址
+	AudioExample       *string        `gorm:"column:audio_example;type:text;comment:示例音频" json:"audio_example"`                                                                     // 示例音频
+	CreatedAt          time.Time      `gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP;autoCreateTime;comment:创建时间" json:"created_at"`     // 创建时间
+	UpdatedAt          time.Time      `gorm:"column:updated_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP;autoUpdateTime;comment:更新时间" json:"updated_at"`     // 更新时间
+	Voice              *string        `gorm:"column:voice;type:text;comment:自定义音色" json:"voice"`                                                                                    // 自定义音色
+	Description        *string        `gorm:"column:description;type:text;comment:角色描述" json:"description"`                                                                         // 角色描述
+	Flag               bool           `gorm:"column:flag;type:boolean;not null;comment:是否克隆" json:"flag"`                                                                           // 是否克隆
+	Status             int32          `gorm:"column:status;type:integer;not null;default:3;comment:1.审核中2.可用3.禁用4.复刻失败5.审核未通过" json:"status"`                                       // 1.审核中2.可用3.禁用4.复刻失败5.审核未通过
+	Hotwords           *string        `gorm:"column:hotwords;type:jsonb;comment:ASR热词" json:"hotwords"`                                                                             // ASR热词
+	VocabularyID       *string        `gorm:"column:vocabulary_id;type:text;comment:ASR热词表ID" json:"vocabulary_id"`                                                                 // ASR热词表ID
+	StatusReason       *string        `gorm:"column:status_reason;type:text;comment:复刻失败或审核未通过的原因" json:"status_reason"`                                                            // 复刻失败或审核未通过的原因
+	VoicePreviewURL    *string        `gorm:"column:voice_preview_url;type:text;comment:克隆音色试听音频" json:"voice_preview_url"`                                                         // 克隆音色试听音频
+	DeletedAt          gorm.DeletedAt `gorm:"column:deleted_at;type:timestamp with time zone;index;comment:删除时间" json:"deleted_at"`                                                 // 删除时间
+	SearchText         *string        `gorm:"column:search_text;type:text;comment:全文检索词，由角色名和描述切词得到" json:"search_text"`                                                            // 全文检索词，由角色名和描述切词得到
+	VoiceID            *string        `gorm:"column:voice_id;type:uuid;index;comment:音色库中的音色ID" json:"voice_id"`                                                                    // 音色库中的音色ID
+	OwnerID            *string        `gorm:"column:owner_id;type:text;index;comment:角色所有者的用户ID" json:"owner_id"`                                                                   // 角色所有者的用户ID
+	Visibility         string         `gorm:"column:visibility;type:text;not null;default:private;comment:可见性:private.仅所有者unlisted.知道ID即可访问public.公开" json:"visibility"`            // 可见性:private.仅所有者unlisted.知道ID即可访问public.公开
+	Version            int32          `gorm:"column:version;type:integer;not null;default:0;comment:当前版本号" json:"version"`                                                          // 当前版本号
+	Greeting           *string        `gorm:"column:greeting;type:text;comment:开场白" json:"greeting"`                                                                                // 开场白
+	AlternateGreetings *string        `gorm:"column:alternate_greetings;type:jsonb;comment:备选开场白" json:"alternate_greetings"`                                                       // 备选开场白
+	ExampleDialogues   *string        `gorm:"column:example_dialogues;type:jsonb;comment:对话示例" json:"example_dialogues"`                                                            // 对话示例
+	VoiceStatus        string         `gorm:"column:voice_status;type:text;not null;default:ready;comment:音色状态:pending.复刻中ready.可用failed.复刻失败rejected.音色审核未通过" json:"voice_status"` // 音色状态:pending.复刻中ready.可用failed.复刻失败rejected.音色审核未通过
+	ReviewStatus       string         `gorm:"column:review_status;type:text;not null;default:pending;index;comment:内容审核状态:pending.待审核approved.通过rejected.驳回" json:"review_status"`  // 内容审核状态:pending.待审核approved.通过rejected.驳回
+	ReviewReason       *string        `gorm:"column:review_reason;type:text;comment:驳回原因" json:"review_reason"`                                                                     // 驳回原因
+	SessionCount       int64          `gorm:"column:session_count;type:bigint;not null;default:0;comment:语音对话次数" json:"session_count"`                                              // 语音对话次数
+	FavoriteCount      int64          `gorm:"column:favorite_count;type:bigint;not null;default:0;comment:收藏数" json:"favorite_count"`                                               // 收藏数
+	ForkedFrom         *string        `gorm:"column:forked_from;type:uuid;index;comment:复制来源角色ID" json:"forked_from"`                                                               // 复制来源角色ID
+	AllowFork          bool           `gorm:"column:allow_fork;type:boolean;not null;default:true;comment:是否允许其他用户复制" json:"allow_fork"`                                            // 是否允许其他用户复制
+	License            *string        `gorm:"column:license;type:text;comment:角色的使用许可" json:"license"`                                                                              // 角色的使用许可
+	TTSModel           *string        `gorm:"column:tts_model;type:text;comment:覆盖默认模型的TTS模型" json:"tts_model"`                                                                     // 覆盖默认模型的TTS模型
+	SpeechRate         float64        `gorm:"column:speech_rate;type:double precision;not null;default:0;comment:语速，0表示默认" json:"speech_rate"`                                      // 语速，0表示默认
+	SpeechPitch        float64        `gorm:"column:speech_pitch;type:double precision;not null;default:0;comment:语调，0表示默认" json:"speech_pitch"`                                    // 语调，0表示默认
+	SpeechVolume       int32          `gorm:"column:speech_volume;type:integer;not null;default:0;comment:音量，0表示默认" json:"speech_volume"`                                           // 音量，0表示默认
 }
 
 // TableName Character's table name
@@ -39,6 +39,31 @@ func newCharacter(db *gorm.DB, opts ...gen.DOOption) character {
 	_character.Description = field.NewString(tableName, "description")
 	_character.Flag = field.NewBool(tableName, "flag")
 	_character.Status = field.NewInt32(tableName, "status")
+	_character.Hotwords = field.NewString(tableName, "hotwords")
+	_character.VocabularyID = field.NewString(tableName, "vocabulary_id")
+	_character.StatusReason = field.NewString(tableName, "status_reason")
+	_character.VoicePreviewURL = field.NewString(tableName, "voice_preview_url")
+	_character.DeletedAt = field.NewField(tableName, "deleted_at")
+	_character.SearchText = field.NewString(tableName, "search_text")
+	_character.VoiceID = field.NewString(tableName, "voice_id")
+	_character.OwnerID = field.NewString(tableName, "owner_id")
+	_character.Visibility = field.NewString(tableName, "visibility")
+	_character.Version = field.NewInt32(tableName, "version")
+	_character.Greeting = field.NewString(tableName, "greeting")
+	_character.AlternateGreetings = field.NewString(tableName, "alternate_greetings")
+	_character.ExampleDialogues = field.NewString(tableName, "example_dialogues")
+	_character.VoiceStatus = field.NewString(tableName, "voice_status")
+	_character.ReviewStatus = field.NewString(tableName, "review_status")
+	_character.ReviewReason = field.NewString(tableName, "review_reason")
+	_character.SessionCount = field.NewInt64(tableName, "session_count")
+	_character.FavoriteCount = field.NewInt64(tableName, "favorite_count")
+	_character.ForkedFrom = field.NewString(tableName, "forked_from")
+	_character.AllowFork = field.NewBool(tableName, "allow_fork")
+	_character.License = field.NewString(tableName, "license")
+	_character.TTSModel = field.NewString(tableName, "tts_model")
+	_character.SpeechRate = field.NewFloat64(tableName, "speech_rate")
+	_character.SpeechPitch = field.NewFloat64(tableName, "speech_pitch")
+	_character.SpeechVolume = field.NewInt32(tableName, "speech_volume")
 
 	_character.fillFieldMap()
 
@@ -48,18 +73,43 @@ func newCharacter(db *gorm.DB, opts ...gen.DOOption) character {
 type character struct {
 	characterDo characterDo
 
-	ALL          field.Asterisk
-	ID           field.String // 角色ID
-	Name         field.String // 角色名
-	Prompt       field.String // 角色提示词
-	Avatar       field.String // 角色头像地址
-	AudioExample field.String // 示例音频
-	CreatedAt    field.Time   // 创建时间
-	UpdatedAt    field.Time   // 更新时间
-	Voice        field.String // 自定义音色
-	Description  field.String // 角色描述
-	Flag         field.Bool   // 是否克隆
-	Status       field.Int32  // 1.审核中2.可用3.禁用
+	ALL                field.Asterisk
+	ID                 field.String  // 角色ID
+	Name               field.String  // 角色名
+	Prompt             field.String  // 角色提示词
+	Avatar             field.String  // 角色头像地址
+	AudioExample       field.String  // 示例音频
+	CreatedAt          field.Time    // 创建时间
+	UpdatedAt          field.Time    // 更新时间
+	Voice              field.String  // 自定义音色
+	Description        field.String  // 角色描述
+	Flag               field.Bool    // 是否克隆
+	Status             field.Int32   // 1.审核中2.可用3.禁用4.复刻失败5.审核未通过
+	Hotwords           field.String  // ASR热词
+	VocabularyID       field.String  // ASR热词表ID
+	StatusReason       field.String  // 复刻失败或审核未通过的原因
+	VoicePreviewURL    field.String  // 克隆音色试听音频
+	DeletedAt          field.Field   // 删除时间
+	SearchText         field.String  // 全文检索词，由角色名和描述切词得到
+	VoiceID            field.String  // 音色库中的音色ID
+	OwnerID            field.String  // 角色所有者的用户ID
+	Visibility         field.String  // 可见性:private.仅所有者unlisted.知道ID即可访问public.公开
+	Version            field.Int32   // 当前版本号
+	Greeting           field.String  // 开场白
+	AlternateGreetings field.String  // 备选开场白
+	ExampleDialogues   field.String  // 对话示例
+	VoiceStatus        field.String  // 音色状态:pending.复刻中ready.可用failed.复刻失败rejected.音色审核未通过
+	ReviewStatus       field.String  // 内容审核状态:pending.待审核approved.通过rejected.驳回
+	ReviewReason       field.String  // 驳回原因
+	SessionCount       field.Int64   // 语音对话次数
+	FavoriteCount      field.Int64   // 收藏数
+	ForkedFrom         field.String  // 复制来源角色ID
+	AllowFork          field.Bool    // 是否允许其他用户复制
+	License            field.String  // 角色的使用许可
+	TTSModel           field.String  // 覆盖默认模型的TTS模型
+	SpeechRate         field.Float64 // 语速，0表示默认
+	SpeechPitch        field.Float64 // 语调，0表示默认
+	SpeechVolume       field.Int32   // 音量，0表示默认
 
 	fieldMap map[string]field.Expr
 }
@@ -87,6 +137,31 @@ func (c *character) updateTableName(table string) *character {
 	c.Description = field.NewString(table, "description")
 	c.Flag = field.NewBool(table, "flag")
 	c.Status = field.NewInt32(table, "status")
+	c.Hotwords = field.NewString(table, "hotwords")
+	c.VocabularyID = field.NewString(table, "vocabulary_id")
+	c.StatusReason = field.NewString(table, "status_reason")
+	c.VoicePreviewURL = field.NewString(table, "voice_preview_url")
+	c.DeletedAt = field.NewField(table, "deleted_at")
+	c.SearchText = field.NewString(table, "search_text")
+	c.VoiceID = field.NewString(table, "voice_id")
+	c.OwnerID = field.NewString(table, "owner_id")
+	c.Visibility = field.NewString(table, "visibility")
+	c.Version = field.NewInt32(table, "version")
+	c.Greeting = field.NewString(table, "greeting")
+	c.AlternateGreetings = field.NewString(table, "alternate_greetings")
+	c.ExampleDialogues = field.NewString(table, "example_dialogues")
+	c.VoiceStatus = field.NewString(table, "voice_status")
+	c.ReviewStatus = field.NewString(table, "review_status")
+	c.ReviewReason = field.NewString(table, "review_reason")
+	c.SessionCount = field.NewInt64(table, "session_count")
+	c.FavoriteCount = field.NewInt64(table, "favorite_count")
+	c.ForkedFrom = field.NewString(table, "forked_from")
+	c.AllowFork = field.NewBool(table, "allow_fork")
+	c.License = field.NewString(table, "license")
+	c.TTSModel = field.NewString(table, "tts_model")
+	c.SpeechRate = field.NewFloat64(table, "speech_rate")
+	c.SpeechPitch = field.NewFloat64(table, "speech_pitch")
+	c.SpeechVolume = field.NewInt32(table, "speech_volume")
 
 	c.fillFieldMap()
 
@@ -113,7 +188,7 @@ func (c *character) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *character) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 11)
+	c.fieldMap = make(map[string]field.Expr, 36)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["name"] = c.Name
 	c.fieldMap["prompt"] = c.Prompt
@@ -125,6 +200,31 @@ func (c *character) fillFieldMap() {
 	c.fieldMap["description"] = c.Description
 	c.fieldMap["flag"] = c.Flag
 	c.fieldMap["status"] = c.Status
+	c.fieldMap["hotwords"] = c.Hotwords
+	c.fieldMap["vocabulary_id"] = c.VocabularyID
+	c.fieldMap["status_reason"] = c.StatusReason
+	c.fieldMap["voice_preview_url"] = c.VoicePreviewURL
+	c.fieldMap["deleted_at"] = c.DeletedAt
+	c.fieldMap["search_text"] = c.SearchText
+	c.fieldMap["voice_id"] = c.VoiceID
+	c.fieldMap["owner_id"] = c.OwnerID
+	c.fieldMap["visibility"] = c.Visibility
+	c.fieldMap["version"] = c.Version
+	c.fieldMap["greeting"] = c.Greeting
+	c.fieldMap["alternate_greetings"] = c.AlternateGreetings
+	c.fieldMap["example_dialogues"] = c.ExampleDialogues
+	c.fieldMap["voice_status"] = c.VoiceStatus
+	c.fieldMap["review_status"] = c.ReviewStatus
+	c.fieldMap["review_reason"] = c.ReviewReason
+	c.fieldMap["session_count"] = c.SessionCount
+	c.fieldMap["favorite_count"] = c.FavoriteCount
+	c.fieldMap["forked_from"] = c.ForkedFrom
+	c.fieldMap["allow_fork"] = c.AllowFork
+	c.fieldMap["license"] = c.License
+	c.fieldMap["tts_model"] = c.TTSModel
+	c.fieldMap["speech_rate"] = c.SpeechRate
+	c.fieldMap["speech_pitch"] = c.SpeechPitch
+	c.fieldMap["speech_volume"] = c.SpeechVolume
 }
 
 func (c character) clone(db *gorm.DB) character {
@@ -16,34 +16,74 @@ import (
 )
 
 var (
-	Q         = new(Query)
-	Character *character
+	Q                 = new(Query)
+	Character         *character
+	CharacterFavorite *characterFavorite
+	CharacterReview   *characterReview
+	CharacterTag      *characterTag
+	CharacterVersion  *characterVersion
+	Conversation      *conversation
+	Job               *job
+	Tag               *tag
+	Voice             *voice
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	Character = &Q.Character
+	CharacterFavorite = &Q.CharacterFavorite
+	CharacterReview = &Q.CharacterReview
+	CharacterTag = &Q.CharacterTag
+	CharacterVersion = &Q.CharacterVersion
+	Conversation = &Q.Conversation
+	Job = &Q.Job
+	Tag = &Q.Tag
+	Voice = &Q.Voice
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:        db,
-		Character: newCharacter(db, opts...),
+		db:                db,
+		Character:         newCharacter(db, opts...),
+		CharacterFavorite: newCharacterFavorite(db, opts...),
+		CharacterReview:   newCharacterReview(db, opts...),
+		CharacterTag:      newCharacterTag(db, opts...),
+		CharacterVersion:  newCharacterVersion(db, opts...),
+		Conversation:      newConversation(db, opts...),
+		Job:               newJob(db, opts...),
+		Tag:               newTag(db, opts...),
+		Voice:             newVoice(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Character character
+	Character         character
+	CharacterFavorite characterFavorite
+	CharacterReview   characterReview
+	CharacterTag      characterTag
+	CharacterVersion  characterVersion
+	Conversation      conversation
+	Job               job
+	Tag               tag
+	Voice             voice
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:        db,
-		Character: q.Character.clone(db),
+		db:                db,
+		Character:         q.Character.clone(db),
+		CharacterFavorite: q.CharacterFavorite.clone(db),
+		CharacterReview:   q.CharacterReview.clone(db),
+		CharacterTag:      q.CharacterTag.clone(db),
+		CharacterVersion:  q.CharacterVersion.clone(db),
+		Conversation:      q.Conversation.clone(db),
+		Job:               q.Job.clone(db),
+		Tag:               q.Tag.clone(db),
+		Voice:             q.Voice.clone(db),
 	}
 }
 
@@ -57,18 +97,42 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:        db,
-		Character: q.Character.replaceDB(db),
+		db:                db,
+		Character:         q.Character.replaceDB(db),
+		CharacterFavorite: q.CharacterFavorite.replaceDB(db),
+		CharacterReview:   q.CharacterReview.replaceDB(db),
+		CharacterTag:      q.CharacterTag.replaceDB(db),
+		CharacterVersion:  q.CharacterVersion.replaceDB(db),
+		Conversation:      q.Conversation.replaceDB(db),
+		Job:               q.Job.replaceDB(db),
+		Tag:               q.Tag.replaceDB(db),
+		Voice:             q.Voice.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Character ICharacterDo
+	Character         ICharacterDo
+	CharacterFavorite ICharacterFavoriteDo
+	CharacterReview   ICharacterReviewDo
+	CharacterTag      ICharacterTagDo
+	CharacterVersion  ICharacterVersionDo
+	Conversation      IConversationDo
+	Job               IJobDo
+	Tag               ITagDo
+	Voice             IVoiceDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Character: q.Character.WithContext(ctx),
+		Character:         q.Character.WithContext(ctx),
+		CharacterFavorite: q.CharacterFavorite.WithContext(ctx),
+		CharacterReview:   q.CharacterReview.WithContext(ctx),
+		CharacterTag:      q.CharacterTag.WithContext(ctx),
+		CharacterVersion:  q.CharacterVersion.WithContext(ctx),
+		Conversation:      q.Conversation.WithContext(ctx),
+		Job:               q.Job.WithContext(ctx),
+		Tag:               q.Tag.WithContext(ctx),
+		Voice:             q.Voice.WithContext(ctx),
 	}
 }
 
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os/signal"
@@ -11,7 +12,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/job"
 	"github.com/justin/echome-be/internal/handler"
+	"github.com/justin/echome-be/internal/infra/storage"
+	"github.com/justin/echome-be/internal/infra/ttscache"
 	"github.com/justin/echome-be/internal/middleware"
 	"github.com/justin/echome-be/internal/validation"
 	"github.com/labstack/echo/v4"
@@ -29,10 +35,11 @@ type Application struct {
 	handler   *handler.Handlers
 	echo      *echo.Echo
 	validator *validation.ConfigValidator
+	scheduler *job.Scheduler
 }
 
 // NewApplication 初始化应用
-func NewApplication(cfg *config.Config, h *handler.Handlers) *Application {
+func NewApplication(cfg *config.Config, h *handler.Handlers, scheduler *job.Scheduler) *Application {
 	e := echo.New()
 
 	// 注册中间件
@@ -41,6 +48,7 @@ func NewApplication(cfg *config.Config, h *handler.Handlers) *Application {
 		echomiddleware.Recover(),
 		echomiddleware.CORS(),
 		middleware.MetricsMiddleware(),
+		middleware.AuthMiddleware(&cfg.Auth),
 		echomiddleware.RateLimiter(echomiddleware.NewRateLimiterMemoryStore(20)), // 简单的内存限流
 	)
 
@@ -50,12 +58,34 @@ func NewApplication(cfg *config.Config, h *handler.Handlers) *Application {
 	// swagger 文档路由
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// 本地存储的文件
+	storageDir := cfg.Storage.Dir
+	if storageDir == "" {
+		storageDir = storage.DefaultDir
+	}
+	e.Static(storage.URLPrefix, storageDir)
+
+	// TTS 缓存命中统计，仅管理员可见
+	e.GET("/debug/vars", ttsCacheMetrics)
+
 	return &Application{
 		config:    cfg,
 		handler:   h,
 		echo:      e,
 		validator: validation.NewConfigValidator(),
+		scheduler: scheduler,
+	}
+}
+
+// ttsCacheMetrics 返回 TTS 缓存命中统计，只公开缓存指标，不包含 cmdline、memstats 等运行信息
+func ttsCacheMetrics(c echo.Context) error {
+	switch err := auth.RequireAdmin(c.Request().Context()); {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return domain.Unauthorized(c, "Authentication required", err.Error())
+	case err != nil:
+		return domain.Forbidden(c, "Permission denied", err.Error())
 	}
+	return c.JSONBlob(http.StatusOK, []byte(`{"tts_cache":`+ttscache.Metrics().String()+`}`))
 }
 
 // GetEcho 获取 Echo 实例（用于测试）
@@ -108,30 +138,44 @@ func (a *Application) Run() error {
 	})
 
 	// ---------------------------
-	// 2. 定时任务：检查角色语音状态
+	// 2. 后台任务：音色审核检查等持久化任务
 	// ---------------------------
 	g.Go(func() error {
-		ticker := time.NewTicker(10 * time.Minute) // 每 10 分钟检查一次
+		// 为升级前已在审核中的音色补建检查任务
+		if err := a.handler.GetRouter().GetVoiceService().SchedulePendingChecks(gCtx); err != nil {
+			zap.L().Error("创建音色检查任务失败", zap.Error(err))
+		}
+		return a.scheduler.Run(gCtx)
+	})
+
+	// ---------------------------
+	// 3. 定时任务：清理孤儿音色
+	// ---------------------------
+	g.Go(func() error {
+		ticker := time.NewTicker(24 * time.Hour) // 每天清理一次
 		defer ticker.Stop()
 
-		timer := time.NewTimer(5 * time.Second) // 延迟 5 秒后进行首次检查
+		timer := time.NewTimer(time.Minute) // 延迟 1 分钟后进行首次清理
 		defer timer.Stop()
 
+		reconcile := func() {
+			deleted, err := a.handler.GetRouter().GetVoiceService().Reconcile(gCtx)
+			if err != nil {
+				zap.L().Error("清理孤儿音色失败", zap.Error(err))
+				return
+			}
+			zap.L().Info("孤儿音色清理完成", zap.Int("deleted", deleted))
+		}
+
 		for {
 			select {
 			case <-gCtx.Done():
-				zap.L().Info("语音状态检查任务已停止")
+				zap.L().Info("音色清理任务已停止")
 				return nil
-			case <-timer.C: // 首次检查
-				zap.L().Info("启动时检查角色语音状态")
-				if err := a.handler.GetRouter().GetCharacterService().CheckAndUpdatePendingCharacters(gCtx); err != nil {
-					zap.L().Error("启动检查角色语音状态失败", zap.Error(err))
-				}
-			case <-ticker.C: // 定时检查
-				zap.L().Info("定时检查角色语音状态")
-				if err := a.handler.GetRouter().GetCharacterService().CheckAndUpdatePendingCharacters(gCtx); err != nil {
-					zap.L().Error("定时检查角色语音状态失败", zap.Error(err))
-				}
+			case <-timer.C:
+				reconcile()
+			case <-ticker.C:
+				reconcile()
 			}
 		}
 	})
@@ -1,20 +1,161 @@
 package ai
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
 // ASRConfig 定义ASR配置参数
 type ASRConfig struct {
 	Model         string   `json:"model"`
 	Format        string   `json:"format"`
 	SampleRate    int      `json:"sample_rate"`
 	LanguageHints []string `json:"language_hints,omitempty"`
+	// VocabularyID 热词表ID，为空时不使用热词
+	VocabularyID string `json:"vocabulary_id,omitempty"`
+}
+
+// Hotword ASR 热词
+type Hotword struct {
+	Text string `json:"text"`
+	// Weight 权重，取值 1~5，越大越容易被识别
+	Weight int `json:"weight"`
+	// Lang 语言代码，如 zh、en，为空时由服务端判断
+	Lang string `json:"lang,omitempty"`
+}
+
+// 复刻音色状态
+const (
+	VoiceStatusOK         = "OK"         // 可用
+	VoiceStatusDeploying  = "DEPLOYING"  // 审核中
+	VoiceStatusUndeployed = "UNDEPLOYED" // 审核未通过
+)
+
+// VoiceInfo 上游的复刻音色
+type VoiceInfo struct {
+	VoiceID   string    `json:"voice_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AudioInput 描述客户端上传给 ASR 的音频格式
+// 客户端可以通过查询参数或首条 start 消息声明，未声明的字段使用 16kHz 单声道 PCM
+type AudioInput struct {
+	Format     string `json:"format"` // pcm / wav / ogg / opus / webm
+	SampleRate int    `json:"sample_rate"`
+	Channels   int    `json:"channels"`
 }
 
-// TTSConfig 定义TTS配置参数
+// ASRResult 语音识别结果
+type ASRResult struct {
+	Text        string `json:"text"`
+	SentenceEnd bool   `json:"sentence_end"`
+	// BeginTime/EndTime 句子在音频中的起止时间（毫秒），句子未结束时 EndTime 为 0
+	BeginTime int `json:"begin_time"`
+	EndTime   int `json:"end_time"`
+}
+
+// Transcript 音频文件转写结果
+type Transcript struct {
+	Text      string               `json:"text"`
+	Sentences []TranscriptSentence `json:"sentences"`
+}
+
+// TranscriptSentence 带时间戳的句子，时间单位为毫秒
+type TranscriptSentence struct {
+	BeginTime int    `json:"begin_time"`
+	EndTime   int    `json:"end_time"`
+	Text      string `json:"text"`
+}
+
+// TTS 输出音频格式
+const (
+	AudioFormatPCM  = "pcm"
+	AudioFormatWAV  = "wav"
+	AudioFormatMP3  = "mp3"
+	AudioFormatOpus = "opus"
+)
+
+// supportedTTSSampleRates TTS 支持的输出采样率
+var supportedTTSSampleRates = []int{8000, 16000, 22050, 24000, 44100, 48000}
+
+// TTSConfig 定义TTS配置参数，零值字段使用服务配置中的默认值
 type TTSConfig struct {
-	Model  string // qwen3-tts-flash-realtime / cosyvoice-v2
-	Voice  string // 克隆音色的voice_id/	非克隆时选择模型自带角色名
-	Format string // pcm / mp3
-	Mode   string // server_commit / commit
-	Lang   string // 语言类型，如"zh"、"en"等
+	Model      string  // qwen-tts-realtime / qwen3-tts-flash-realtime / cosyvoice-v2，qwen 开头的模型使用 Qwen-TTS Realtime 协议
+	Voice      string  // 克隆音色的voice_id/	非克隆时选择模型自带角色名
+	Format     string  // pcm / wav / mp3 / opus
+	SampleRate int     // 输出采样率，如 16000、22050、24000
+	Mode       string  // server_commit / commit，仅 Qwen-TTS Realtime 使用
+	Lang       string  // 语言类型，如"zh"、"en"等
+	Rate       float64 // 语速，取值 0.5~2.0，0 表示默认语速
+	Pitch      float64 // 语调，取值 0.5~2.0，0 表示默认语调
+	Volume     int     // 音量，取值 1~100，0 表示默认音量
+}
+
+// IsQwenTTSModel 判断模型是否使用 Qwen-TTS Realtime 协议，其余模型使用 CosyVoice 协议
+func IsQwenTTSModel(model string) bool {
+	return strings.HasPrefix(model, "qwen")
+}
+
+// IsCosyVoiceModel 判断是否为 CosyVoice 模型
+func IsCosyVoiceModel(model string) bool {
+	return strings.HasPrefix(model, "cosyvoice")
+}
+
+// TTS 语速、语调和音量范围
+const (
+	MinTTSRate   = 0.5
+	MaxTTSRate   = 2.0
+	MinTTSPitch  = 0.5
+	MaxTTSPitch  = 2.0
+	MinTTSVolume = 1
+	MaxTTSVolume = 100
+)
+
+// EventWriter TTS 输出可选实现的接口
+// 实现该接口的输出会在音频之外收到 audio_format、tts_timing 等 JSON 事件，未实现时只写出音频
+type EventWriter interface {
+	WriteEvent(event any) error
+}
+
+// ValidateTTSOutput 校验客户端请求的输出格式和采样率，空值表示使用默认值
+func ValidateTTSOutput(format string, sampleRate int) error {
+	switch format {
+	case "", AudioFormatPCM, AudioFormatWAV, AudioFormatMP3, AudioFormatOpus:
+	default:
+		return fmt.Errorf("unsupported audio format: %s, supported: pcm, wav, mp3, opus", format)
+	}
+	if sampleRate != 0 && !slices.Contains(supportedTTSSampleRates, sampleRate) {
+		return fmt.Errorf("unsupported sample rate: %d, supported: %v", sampleRate, supportedTTSSampleRates)
+	}
+	return nil
+}
+
+// ValidateTTSRate 校验语速，0 表示使用默认语速
+func ValidateTTSRate(rate float64) error {
+	if rate != 0 && (rate < MinTTSRate || rate > MaxTTSRate) {
+		return fmt.Errorf("unsupported rate: %g, supported: %g~%g", rate, MinTTSRate, MaxTTSRate)
+	}
+	return nil
+}
+
+// ValidateTTSPitch 校验语调，0 表示使用默认语调
+func ValidateTTSPitch(pitch float64) error {
+	if pitch != 0 && (pitch < MinTTSPitch || pitch > MaxTTSPitch) {
+		return fmt.Errorf("unsupported pitch: %g, supported: %g~%g", pitch, MinTTSPitch, MaxTTSPitch)
+	}
+	return nil
+}
+
+// ValidateTTSVolume 校验音量，0 表示使用默认音量
+func ValidateTTSVolume(volume int) error {
+	if volume != 0 && (volume < MinTTSVolume || volume > MaxTTSVolume) {
+		return fmt.Errorf("unsupported volume: %d, supported: %d~%d", volume, MinTTSVolume, MaxTTSVolume)
+	}
+	return nil
 }
 
 // ToolCall 工具调用结构
@@ -2,15 +2,40 @@ package ai
 
 import (
 	"context"
+	"io"
 
 	"github.com/justin/echome-be/internal/domain/ws"
 )
 
 type Repo interface {
-	GetVoiceStatus(ctx context.Context, voiceID string) (bool, error)
+	// GetVoiceStatus 查询复刻音色的状态
+	GetVoiceStatus(ctx context.Context, voiceID string) (*VoiceInfo, error)
 	VoiceClone(ctx context.Context, url string) (*string, error)
-	HandleCosyVoiceTTS(ctx context.Context, clientWS ws.WebSocketConn, textStream <-chan string, config TTSConfig) error
+	// ListVoices 列出本服务创建的全部复刻音色
+	ListVoices(ctx context.Context) ([]VoiceInfo, error)
+	// UpdateVoice 用新的音频样本重新复刻音色
+	UpdateVoice(ctx context.Context, voiceID, url string) error
+	DeleteVoice(ctx context.Context, voiceID string) error
+	HandleCosyVoiceTTS(ctx context.Context, w io.Writer, textStream <-chan string, config TTSConfig) error
+	// HandleStreamTTS 根据 config.Model 选择 CosyVoice 或 Qwen-TTS Realtime 合成语音
+	// 音频写入 w，w 实现 EventWriter 时还会收到格式和时间戳事件
+	HandleStreamTTS(ctx context.Context, w io.Writer, textStream <-chan string, config TTSConfig) error
+	// HandleTTS 合成一段完整文本
+	HandleTTS(ctx context.Context, w io.Writer, text string, config TTSConfig) error
 	GenerateResponse(ctx context.Context, msg DashScopeChatRequest, onChunk func(string) error) error
 	PerformSearch(ctx context.Context, query string, apiKey string) (string, error)
-	HandleASR(ctx context.Context, clientWS ws.WebSocketConn) error
+	// HandleASR 识别客户端音频并把结果写回客户端，config 中未指定的参数使用默认值
+	HandleASR(ctx context.Context, clientWS ws.WebSocketConn, input AudioInput, config ASRConfig) error
+	// RecognizeSpeech 识别客户端音频，每个识别结果通过 onResult 回调返回
+	// input 为客户端声明的音频格式，客户端也可以用首条 start 消息重新声明
+	RecognizeSpeech(ctx context.Context, clientWS ws.WebSocketConn, input AudioInput, config ASRConfig, onResult func(ASRResult) error) error
+	// CreateVocabulary 创建 ASR 热词表，返回热词表ID
+	CreateVocabulary(ctx context.Context, hotwords []Hotword) (string, error)
+	// UpdateVocabulary 用新的热词列表覆盖热词表
+	UpdateVocabulary(ctx context.Context, vocabularyID string, hotwords []Hotword) error
+	DeleteVocabulary(ctx context.Context, vocabularyID string) error
+	// TranscribeURL 通过录音文件识别转写可公开访问的音频文件，阻塞直到转写完成
+	TranscribeURL(ctx context.Context, fileURL string) (*Transcript, error)
+	// TranscribeAudio 把音频文件分块送入实时识别完成转写，format 支持 wav、mp3
+	TranscribeAudio(ctx context.Context, r io.Reader, format string) (*Transcript, error)
 }
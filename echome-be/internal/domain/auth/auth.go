@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnauthenticated 操作需要登录
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrForbidden 当前用户无权执行该操作
+	ErrForbidden = errors.New("permission denied")
+)
+
+// LocalUserID 关闭鉴权时所有请求使用的用户ID
+const LocalUserID = "local"
+
+// User 发起请求的用户
+type User struct {
+	// ID 用户ID，取自访问令牌的 sub
+	ID string
+	// Admin 管理员可以查看和修改所有资源
+	Admin bool
+}
+
+// LocalUser 关闭鉴权时使用的本地用户，拥有管理员权限
+var LocalUser = &User{ID: LocalUserID, Admin: true}
+
+type userKey struct{}
+
+// WithUser 返回携带当前用户的 context
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom 返回 context 中的当前用户，匿名请求返回 nil
+func UserFrom(ctx context.Context) *User {
+	user, _ := ctx.Value(userKey{}).(*User)
+	return user
+}
+
+// IsOwner 判断用户是否为资源的所有者，管理员视为所有资源的所有者
+func (u *User) IsOwner(ownerID *string) bool {
+	if u == nil {
+		return false
+	}
+	return u.Admin || (ownerID != nil && *ownerID == u.ID)
+}
+
+// RequireAdmin 检查当前用户是否为管理员，未登录返回 ErrUnauthenticated，不是管理员返回 ErrForbidden
+func RequireAdmin(ctx context.Context) error {
+	user := UserFrom(ctx)
+	switch {
+	case user == nil:
+		return ErrUnauthenticated
+	case !user.Admin:
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package character
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/samber/lo"
+)
+
+// ErrCharacterUnavailable 角色尚未审核通过或已禁用，只有所有者可以使用
+var ErrCharacterUnavailable = errors.New("character is not available")
+
+// IsVisibility 判断是否为合法的可见性
+func IsVisibility(visibility string) bool {
+	return lo.Contains([]string{VisibilityPrivate, VisibilityUnlisted, VisibilityPublic}, visibility)
+}
+
+// CanView 判断用户能否查看角色，私有角色只有所有者可见
+func CanView(user *auth.User, character *Character) bool {
+	return character.Visibility != VisibilityPrivate || user.IsOwner(character.OwnerID)
+}
+
+// CanEdit 判断用户能否修改或删除角色
+func CanEdit(user *auth.User, character *Character) bool {
+	return user.IsOwner(character.OwnerID)
+}
+
+// viewableCharacter 获取当前用户可见的角色，不可见的角色按不存在处理，避免泄露私有角色是否存在
+func (s *CharacterService) viewableCharacter(ctx context.Context, id uuid.UUID) (*Character, error) {
+	character, err := s.characterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CanView(auth.UserFrom(ctx), character) {
+		return nil, ErrCharacterNotFound
+	}
+	return character, nil
+}
+
+// editableCharacter 获取当前用户可以修改的角色
+func (s *CharacterService) editableCharacter(ctx context.Context, id uuid.UUID) (*Character, error) {
+	character, err := s.viewableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEdit(ctx, character); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+// checkEdit 检查当前用户能否修改角色，匿名用户返回 auth.ErrUnauthenticated
+func checkEdit(ctx context.Context, character *Character) error {
+	user := auth.UserFrom(ctx)
+	switch {
+	case user == nil:
+		return auth.ErrUnauthenticated
+	case !CanEdit(user, character):
+		return auth.ErrForbidden
+	}
+	return nil
+}
+
+// UsableCharacter 获取当前用户可以对话的角色
+// 其他用户只能使用审核通过的角色，所有者可以在审核期间试用自己的角色
+func (s *CharacterService) UsableCharacter(ctx context.Context, id uuid.UUID) (*Character, error) {
+	character, err := s.viewableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if character.Status != CharacterStatusApproved && !CanEdit(auth.UserFrom(ctx), character) {
+		return nil, ErrCharacterUnavailable
+	}
+	return character, nil
+}
@@ -0,0 +1,249 @@
+package character
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/samber/lo"
+)
+
+// Character Card V2 是 SillyTavern、TavernAI 等角色扮演前端通用的角色卡格式
+// 规范见 https://github.com/malfoyslastname/character-card-spec-v2
+
+const (
+	// CardSpecV2 角色卡 V2 的 spec 字段
+	CardSpecV2 = "chara_card_v2"
+	// CardSpecVersionV2 角色卡 V2 的 spec_version 字段
+	CardSpecVersionV2 = "2.0"
+	// cardSpecV3 V3 角色卡在 V2 的基础上增加字段，导入时按 V2 读取
+	cardSpecV3 = "chara_card_v3"
+	// cardExtension 角色卡 extensions 中保存本服务专有字段的键
+	cardExtension = "echome"
+	// cardUserName 导入时替换 {{user}} 的称呼
+	cardUserName = "用户"
+)
+
+// ErrInvalidCard 角色卡无法解析或缺少角色名
+var ErrInvalidCard = errors.New("invalid character card")
+
+// Card 角色卡 V2
+type Card struct {
+	Spec        string   `json:"spec"`
+	SpecVersion string   `json:"spec_version"`
+	Data        CardData `json:"data"`
+}
+
+// CardData 角色卡内容，V1 角色卡没有外层的 spec 和 data，字段直接位于顶层
+type CardData struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Personality string `json:"personality"`
+	Scenario    string `json:"scenario"`
+	// FirstMes 角色的开场白
+	FirstMes string `json:"first_mes"`
+	// MesExample 对话示例，每段以 <START> 开头
+	MesExample              string   `json:"mes_example"`
+	CreatorNotes            string   `json:"creator_notes"`
+	SystemPrompt            string   `json:"system_prompt"`
+	PostHistoryInstructions string   `json:"post_history_instructions"`
+	AlternateGreetings      []string `json:"alternate_greetings"`
+	Tags                    []string `json:"tags"`
+	Creator                 string   `json:"creator"`
+	CharacterVersion        string   `json:"character_version"`
+	// Extensions 各应用的扩展字段，本服务的字段在 echome 键下
+	Extensions map[string]any `json:"extensions"`
+}
+
+// cardExtensionData 导出时写入 extensions.echome 的字段
+type cardExtensionData struct {
+	Hotwords []ai.Hotword `json:"hotwords,omitempty"`
+	// Avatar 头像不在本服务存储时，PNG 中嵌入的是占位图，原头像地址保存在这里
+	Avatar *string `json:"avatar,omitempty"`
+}
+
+// ParseCard 解析 V1、V2 或 V3 角色卡 JSON
+func ParseCard(data []byte) (*Card, error) {
+	var raw struct {
+		Spec string          `json:"spec"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Join(ErrInvalidCard, err)
+	}
+
+	card := &Card{Spec: CardSpecV2, SpecVersion: CardSpecVersionV2}
+	switch raw.Spec {
+	case CardSpecV2, cardSpecV3:
+		if err := json.Unmarshal(raw.Data, &card.Data); err != nil {
+			return nil, errors.Join(ErrInvalidCard, err)
+		}
+	case "":
+		// V1 角色卡
+		if err := json.Unmarshal(data, &card.Data); err != nil {
+			return nil, errors.Join(ErrInvalidCard, err)
+		}
+	default:
+		return nil, errors.Join(ErrInvalidCard, errors.New("unsupported spec: "+raw.Spec))
+	}
+
+	if strings.TrimSpace(card.Data.Name) == "" {
+		return nil, errors.Join(ErrInvalidCard, errors.New("name is required"))
+	}
+	return card, nil
+}
+
+// cardMacro 角色卡中的占位符，{{char}} 和 <BOT> 表示角色名，{{user}} 和 <USER> 表示用户
+var cardMacro = regexp.MustCompile(`(?i)\{\{char\}\}|<bot>|\{\{user\}\}|<user>|\{\{original\}\}`)
+
+// cardExampleStart mes_example 中每段对话示例开头的标记
+var cardExampleStart = regexp.MustCompile(`(?i)<start>`)
+
+// cardSpeaker mes_example 中行首的发言者，如 "{{user}}: 你好"
+var cardSpeaker = regexp.MustCompile(`(?i)^\s*(\{\{user\}\}|<user>|\{\{char\}\}|<bot>)\s*[:：]\s*`)
+
+// ToCharacter 把角色卡转换为待创建的角色
+// description 是角色设定的主体，和 system_prompt、personality、scenario 一起组成提示词；
+// first_mes 和 alternate_greetings 作为开场白，mes_example 拆分为对话示例，tags 作为角色标签；
+// 角色描述取 creator_notes，没有时截取 description 开头
+func (c *Card) ToCharacter() *Character {
+	data := c.Data
+	name := strings.TrimSpace(data.Name)
+	expand := func(text string) string {
+		text = cardMacro.ReplaceAllStringFunc(text, func(macro string) string {
+			switch strings.ToLower(macro) {
+			case "{{char}}", "<bot>":
+				return name
+			case "{{user}}", "<user>":
+				return cardUserName
+			}
+			// {{original}} 表示前端默认的系统提示词，这里没有对应内容
+			return ""
+		})
+		return strings.TrimSpace(text)
+	}
+
+	var sections []string
+	addSection := func(title, text string) {
+		if text = expand(text); text != "" {
+			sections = append(sections, title+text)
+		}
+	}
+	addSection("", data.SystemPrompt)
+	addSection("", data.Description)
+	addSection("性格：", data.Personality)
+	addSection("场景：", data.Scenario)
+
+	character := &Character{
+		Name:             name,
+		Prompt:           strings.Join(sections, "\n\n"),
+		Greeting:         lo.EmptyableToPtr(expand(data.FirstMes)),
+		ExampleDialogues: parseCardExamples(data.MesExample, expand),
+		AllowFork:        true,
+	}
+	for _, tag := range data.Tags {
+		// 丢弃不符合要求的标签，不因此拒绝导入
+		tag = NormalizeTagName(tag)
+		if tag != "" && utf8.RuneCountInString(tag) <= MaxTagLength && len(character.Tags) < MaxCharacterTags &&
+			!lo.Contains(character.Tags, tag) {
+			character.Tags = append(character.Tags, tag)
+		}
+	}
+	for _, greeting := range data.AlternateGreetings {
+		if greeting = expand(greeting); greeting != "" && len(character.AlternateGreetings) < MaxAlternateGreetings {
+			character.AlternateGreetings = append(character.AlternateGreetings, greeting)
+		}
+	}
+	summary := lo.CoalesceOrEmpty(expand(data.CreatorNotes), expand(data.Description))
+	if summary != "" {
+		if utf8.RuneCountInString(summary) > MaxDescriptionLength {
+			summary = string([]rune(summary)[:MaxDescriptionLength])
+		}
+		character.Description = &summary
+	}
+
+	if ext, ok := data.Extensions[cardExtension]; ok {
+		var extData cardExtensionData
+		if raw, err := json.Marshal(ext); err == nil && json.Unmarshal(raw, &extData) == nil {
+			character.Hotwords = extData.Hotwords
+		}
+	}
+	return character
+}
+
+// parseCardExamples 把 mes_example 拆分为对话示例，每段以 <START> 开头，每次发言以 {{user}}: 或 {{char}}: 开头，
+// 没有发言者的行接在上一次发言后面；超出数量限制的部分丢弃
+func parseCardExamples(text string, expand func(string) string) []ExampleDialogue {
+	var dialogues []ExampleDialogue
+	for _, block := range cardExampleStart.Split(text, -1) {
+		var turns []DialogueTurn
+		for _, line := range strings.Split(block, "\n") {
+			speaker := cardSpeaker.FindStringSubmatch(line)
+			if speaker == nil {
+				if len(turns) > 0 {
+					turns[len(turns)-1].Content += "\n" + line
+				}
+				continue
+			}
+			role := RoleAssistant
+			if strings.EqualFold(speaker[1], "{{user}}") || strings.EqualFold(speaker[1], "<user>") {
+				role = RoleUser
+			}
+			turns = append(turns, DialogueTurn{Role: role, Content: line[len(speaker[0]):]})
+		}
+
+		dialogue := ExampleDialogue{}
+		for _, turn := range turns {
+			if turn.Content = expand(turn.Content); turn.Content != "" && len(dialogue.Turns) < MaxExampleTurns {
+				dialogue.Turns = append(dialogue.Turns, turn)
+			}
+		}
+		if len(dialogue.Turns) > 0 && len(dialogues) < MaxExampleDialogues {
+			dialogues = append(dialogues, dialogue)
+		}
+	}
+	return dialogues
+}
+
+// formatCardExamples 把对话示例格式化为 mes_example
+func formatCardExamples(dialogues []ExampleDialogue) string {
+	var b strings.Builder
+	for _, dialogue := range dialogues {
+		b.WriteString("<START>\n")
+		for _, turn := range dialogue.Turns {
+			b.WriteString(lo.Ternary(turn.Role == RoleUser, "{{user}}: ", "{{char}}: "))
+			b.WriteString(turn.Content)
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// NewCard 把角色导出为角色卡，提示词作为 description，角色描述作为 creator_notes
+// embeddedAvatar 表示 PNG 中嵌入的是角色头像，否则把头像地址写入扩展字段
+func NewCard(character *Character, embeddedAvatar bool) *Card {
+	ext := cardExtensionData{Hotwords: character.Hotwords}
+	if !embeddedAvatar {
+		ext.Avatar = character.Avatar
+	}
+
+	return &Card{
+		Spec:        CardSpecV2,
+		SpecVersion: CardSpecVersionV2,
+		Data: CardData{
+			Name:               character.Name,
+			Description:        character.Prompt,
+			FirstMes:           lo.FromPtr(character.Greeting),
+			MesExample:         formatCardExamples(character.ExampleDialogues),
+			CreatorNotes:       lo.FromPtr(character.Description),
+			AlternateGreetings: append([]string{}, character.AlternateGreetings...),
+			Tags:               append([]string{}, character.Tags...),
+			CharacterVersion:   strconv.Itoa(int(character.Version)),
+			Extensions:         map[string]any{cardExtension: ext},
+		},
+	}
+}
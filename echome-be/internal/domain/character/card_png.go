@@ -0,0 +1,118 @@
+package character
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// 角色卡 PNG 把 base64 编码的角色卡 JSON 保存在关键字为 chara 的 tEXt 块中
+
+// pngSignature PNG 文件头
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// cardChunkKeyword 保存角色卡的 tEXt 块关键字
+const cardChunkKeyword = "chara"
+
+// IsPNG 判断数据是否为 PNG 文件
+func IsPNG(data []byte) bool {
+	return bytes.HasPrefix(data, pngSignature)
+}
+
+// ReadCardPNG 从角色卡 PNG 中读取角色卡 JSON
+func ReadCardPNG(data []byte) ([]byte, error) {
+	if !IsPNG(data) {
+		return nil, errors.Join(ErrInvalidCard, errors.New("not a PNG file"))
+	}
+
+	r := bytes.NewReader(data[len(pngSignature):])
+	for {
+		chunkType, chunkData, err := readPNGChunk(r)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCard, err)
+		}
+		switch chunkType {
+		case "tEXt":
+			keyword, text, ok := bytes.Cut(chunkData, []byte{0})
+			if !ok || string(keyword) != cardChunkKeyword {
+				continue
+			}
+			decoded, err := base64.StdEncoding.DecodeString(string(text))
+			if err != nil {
+				return nil, errors.Join(ErrInvalidCard, err)
+			}
+			return decoded, nil
+		case "IEND":
+			return nil, errors.Join(ErrInvalidCard, errors.New("PNG has no character card"))
+		}
+	}
+}
+
+// WriteCardPNG 把角色卡 JSON 写入 PNG 的 tEXt 块，原有的角色卡块会被替换
+func WriteCardPNG(image []byte, card []byte) ([]byte, error) {
+	if !IsPNG(image) {
+		return nil, errors.New("not a PNG file")
+	}
+
+	var out bytes.Buffer
+	out.Write(pngSignature)
+	r := bytes.NewReader(image[len(pngSignature):])
+	for {
+		chunkType, chunkData, err := readPNGChunk(r)
+		if err != nil {
+			return nil, err
+		}
+		if chunkType == "tEXt" {
+			if keyword, _, ok := bytes.Cut(chunkData, []byte{0}); ok && string(keyword) == cardChunkKeyword {
+				continue
+			}
+		}
+		if chunkType == "IEND" {
+			text := append([]byte(cardChunkKeyword+"\x00"), base64.StdEncoding.EncodeToString(card)...)
+			writePNGChunk(&out, "tEXt", text)
+		}
+		writePNGChunk(&out, chunkType, chunkData)
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+}
+
+// readPNGChunk 读取一个 PNG 块，校验 CRC
+// 块长度来自文件本身，分配内存前先和剩余数据比较，避免很小的文件声明超大的块
+func readPNGChunk(r *bytes.Reader) (string, []byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return "", nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if int64(length)+4 > int64(r.Len()) {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	data := make([]byte, length+4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return "", nil, err
+	}
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data[:length])
+	if crc.Sum32() != binary.BigEndian.Uint32(data[length:]) {
+		return "", nil, errors.New("PNG chunk CRC mismatch")
+	}
+	return string(header[4:]), data[:length], nil
+}
+
+// writePNGChunk 写入一个 PNG 块
+func writePNGChunk(w *bytes.Buffer, chunkType string, data []byte) {
+	_ = binary.Write(w, binary.BigEndian, uint32(len(data)))
+	w.WriteString(chunkType)
+	w.Write(data)
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(chunkType))
+	crc.Write(data)
+	_ = binary.Write(w, binary.BigEndian, crc.Sum32())
+}
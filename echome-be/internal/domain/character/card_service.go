@@ -0,0 +1,156 @@
+package character
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+// MaxCardSize 导入的角色卡文件大小上限
+const MaxCardSize = 10 << 20
+
+// 没有可嵌入的头像时导出的占位图尺寸，与常见角色卡的宽高比一致
+const (
+	placeholderWidth  = 400
+	placeholderHeight = 600
+)
+
+// ImportCharacter 从角色卡 JSON 或 PNG 创建归当前用户所有的角色，PNG 图片作为角色头像
+// 角色卡无法解析时返回 ErrInvalidCard，其余错误与 CreateCharacter 相同
+func (s *CharacterService) ImportCharacter(ctx context.Context, data []byte, visibility string) (*Character, error) {
+	if auth.UserFrom(ctx) == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	cardJSON := data
+	if IsPNG(data) {
+		var err error
+		if cardJSON, err = ReadCardPNG(data); err != nil {
+			return nil, err
+		}
+	}
+	card, err := ParseCard(cardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	info := card.ToCharacter()
+	info.Visibility = visibility
+	if info.Hotwords, err = NormalizeHotwords(info.Hotwords); err != nil {
+		return nil, errors.Join(ErrInvalidCard, err)
+	}
+
+	var avatarKey string
+	if IsPNG(data) {
+		avatarKey = "avatars/" + uuid.NewString() + ".png"
+		url, err := s.storage.Save(ctx, avatarKey, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		info.Avatar = &url
+	}
+
+	created, err := s.CreateCharacter(ctx, nil, info)
+	if err != nil {
+		if avatarKey != "" {
+			if err := s.storage.Delete(ctx, avatarKey); err != nil {
+				zap.L().Warn("删除导入的头像失败", zap.String("key", avatarKey), zap.Error(err))
+			}
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// ExportCard 把当前用户可见的角色导出为角色卡
+func (s *CharacterService) ExportCard(ctx context.Context, id uuid.UUID) (*Card, error) {
+	character, err := s.viewableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewCard(character, false), nil
+}
+
+// ExportCardPNG 把当前用户可见的角色导出为嵌入角色卡的 PNG 头像
+// 只嵌入保存在本服务的头像，外部地址的头像不在服务端下载，改用占位图并在角色卡中保留原地址
+func (s *CharacterService) ExportCardPNG(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	character, err := s.viewableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	avatar, err := s.avatarPNG(ctx, character)
+	embedded := err == nil
+	if !embedded {
+		if !errors.Is(err, storage.ErrNotFound) {
+			zap.L().Warn("读取角色头像失败，使用占位图", zap.String("characterID", id.String()), zap.Error(err))
+		}
+		if avatar, err = placeholderPNG(); err != nil {
+			return nil, err
+		}
+	}
+
+	cardJSON, err := json.Marshal(NewCard(character, embedded))
+	if err != nil {
+		return nil, err
+	}
+	return WriteCardPNG(avatar, cardJSON)
+}
+
+// avatarPNG 读取保存在本服务的角色头像，非 PNG 格式的头像转换为 PNG
+func (s *CharacterService) avatarPNG(ctx context.Context, character *Character) ([]byte, error) {
+	if character.Avatar == nil {
+		return nil, storage.ErrNotFound
+	}
+	r, err := s.storage.Open(ctx, *character.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxCardSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxCardSize {
+		return nil, errors.New("avatar is too large")
+	}
+	if IsPNG(data) {
+		return data, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// placeholderPNG 生成纯色占位图
+func placeholderPNG() ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, placeholderWidth, placeholderHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 0xe5, G: 0xe7, B: 0xeb, A: 0xff}}, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package character
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+// 对话示例中发言的一方，与 LLM 消息的 role 一致
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// 开场白和对话示例的限制
+const (
+	MaxGreetingLength     = 2000
+	MaxAlternateGreetings = 20
+	MaxExampleDialogues   = 20
+	MaxExampleTurns       = 20
+)
+
+// DialogueTurn 对话示例中的一次发言
+type DialogueTurn struct {
+	// Role 发言方: user 为用户，assistant 为角色
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ExampleDialogue 一段对话示例，对话时作为之前的轮次放在上下文开头，示范角色的说话风格
+type ExampleDialogue struct {
+	Turns []DialogueTurn `json:"turns"`
+}
+
+// PickGreeting 从开场白和备选开场白中随机选择一条，没有开场白时返回空字符串
+func (c *Character) PickGreeting() string {
+	greetings := c.AlternateGreetings
+	if c.Greeting != nil {
+		greetings = append([]string{*c.Greeting}, greetings...)
+	}
+	if len(greetings) == 0 {
+		return ""
+	}
+	return greetings[rand.IntN(len(greetings))]
+}
+
+// normalizeDialogue 去除开场白和对话示例的首尾空白，空开场白置空，空列表置为 nil
+func normalizeDialogue(character *Character) {
+	if character.Greeting != nil {
+		character.Greeting = lo.EmptyableToPtr(strings.TrimSpace(*character.Greeting))
+	}
+
+	greetings := make([]string, 0, len(character.AlternateGreetings))
+	for _, greeting := range character.AlternateGreetings {
+		greetings = append(greetings, strings.TrimSpace(greeting))
+	}
+	character.AlternateGreetings = lo.Ternary(len(greetings) > 0, greetings, nil)
+
+	dialogues := make([]ExampleDialogue, 0, len(character.ExampleDialogues))
+	for _, dialogue := range character.ExampleDialogues {
+		turns := make([]DialogueTurn, 0, len(dialogue.Turns))
+		for _, turn := range dialogue.Turns {
+			turns = append(turns, DialogueTurn{
+				Role:    strings.ToLower(strings.TrimSpace(turn.Role)),
+				Content: strings.TrimSpace(turn.Content),
+			})
+		}
+		dialogues = append(dialogues, ExampleDialogue{Turns: turns})
+	}
+	character.ExampleDialogues = lo.Ternary(len(dialogues) > 0, dialogues, nil)
+}
+
+// validateDialogue 校验开场白和对话示例
+func validateDialogue(character *Character, add func(field, format string, args ...any)) {
+	if character.Greeting != nil && utf8.RuneCountInString(*character.Greeting) > MaxGreetingLength {
+		add("greeting", "不能超过%d个字", MaxGreetingLength)
+	}
+
+	if len(character.AlternateGreetings) > MaxAlternateGreetings {
+		add("alternate_greetings", "不能超过%d条", MaxAlternateGreetings)
+	}
+	for i, greeting := range character.AlternateGreetings {
+		switch n := utf8.RuneCountInString(greeting); {
+		case n == 0:
+			add(fieldIndex("alternate_greetings", i), "不能为空")
+		case n > MaxGreetingLength:
+			add(fieldIndex("alternate_greetings", i), "不能超过%d个字", MaxGreetingLength)
+		}
+	}
+
+	if len(character.ExampleDialogues) > MaxExampleDialogues {
+		add("example_dialogues", "不能超过%d段", MaxExampleDialogues)
+	}
+	for i, dialogue := range character.ExampleDialogues {
+		field := fieldIndex("example_dialogues", i)
+		switch n := len(dialogue.Turns); {
+		case n == 0:
+			add(field+".turns", "不能为空")
+		case n > MaxExampleTurns:
+			add(field+".turns", "不能超过%d轮", MaxExampleTurns)
+		}
+		for j, turn := range dialogue.Turns {
+			turnField := fieldIndex(field+".turns", j)
+			if turn.Role != RoleUser && turn.Role != RoleAssistant {
+				add(turnField+".role", "必须是 %s 或 %s", RoleUser, RoleAssistant)
+			}
+			switch n := utf8.RuneCountInString(turn.Content); {
+			case n == 0:
+				add(turnField+".content", "不能为空")
+			case n > MaxGreetingLength:
+				add(turnField+".content", "不能超过%d个字", MaxGreetingLength)
+			}
+		}
+	}
+}
+
+// fieldIndex 返回列表字段中指定元素的字段名，如 alternate_greetings[0]
+func fieldIndex(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
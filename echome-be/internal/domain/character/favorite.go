@@ -0,0 +1,43 @@
+package character
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// FavoriteCharacter 收藏当前用户可见的角色，重复收藏不报错，返回收藏后的角色
+func (s *CharacterService) FavoriteCharacter(ctx context.Context, id uuid.UUID) (*Character, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	if _, err := s.viewableCharacter(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.characterRepo.AddFavorite(ctx, user.ID, id); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.GetByID(ctx, id)
+}
+
+// UnfavoriteCharacter 取消收藏，未收藏时不报错；角色已不可见时也可以取消
+func (s *CharacterService) UnfavoriteCharacter(ctx context.Context, id uuid.UUID) error {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return auth.ErrUnauthenticated
+	}
+	return s.characterRepo.RemoveFavorite(ctx, user.ID, id)
+}
+
+// ListFavorites 获取当前用户收藏的角色，包括所有状态，仍然只返回当前用户可见的角色
+func (s *CharacterService) ListFavorites(ctx context.Context, query ListQuery) (*Page, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	query.FavoritedBy = &user.ID
+	query.Status = nil
+	return s.ListCharacters(ctx, query)
+}
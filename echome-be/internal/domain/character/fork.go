@@ -0,0 +1,91 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// maxForkNameAttempts 未指定角色名时，默认角色名被占用后依次加序号重试的次数
+const maxForkNameAttempts = 5
+
+// ErrForkNotAllowed 角色的所有者不允许其他用户复制
+var ErrForkNotAllowed = errors.New("character does not allow forking")
+
+// ForkRequest 复制角色的参数
+type ForkRequest struct {
+	// Name 新角色名，为空时使用“来源角色名的副本”
+	Name string
+	// Visibility 新角色的可见性，为空时为私有
+	Visibility string
+}
+
+// ForkCharacter 以当前用户可见的角色为模板创建一个归当前用户所有的新角色，并记录来源角色
+// 复制提示词、描述、头像、开场白、对话示例、热词、标签、音色和语音合成参数，新角色沿用来源角色的许可，重新进入审核
+// 其他用户的角色须审核通过且允许复制，否则分别返回 ErrCharacterUnavailable 和 ErrForkNotAllowed
+func (s *CharacterService) ForkCharacter(ctx context.Context, id uuid.UUID, req ForkRequest) (*Character, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	source, err := s.viewableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CanEdit(user, source) {
+		if source.Status != CharacterStatusApproved {
+			return nil, ErrCharacterUnavailable
+		}
+		if !source.AllowFork {
+			return nil, ErrForkNotAllowed
+		}
+	}
+
+	info := &Character{
+		Name:               req.Name,
+		Visibility:         req.Visibility,
+		Description:        source.Description,
+		Prompt:             source.Prompt,
+		Greeting:           source.Greeting,
+		AlternateGreetings: slices.Clone(source.AlternateGreetings),
+		ExampleDialogues:   slices.Clone(source.ExampleDialogues),
+		Avatar:             source.Avatar,
+		AudioExample:       source.AudioExample,
+		Hotwords:           slices.Clone(source.Hotwords),
+		VoiceID:            source.VoiceID,
+		Tags:               slices.Clone(source.Tags),
+		ForkedFrom:         &source.ID,
+		AllowFork:          true,
+		License:            source.License,
+		Speech:             source.Speech,
+	}
+	if req.Name != "" {
+		return s.CreateCharacter(ctx, nil, info)
+	}
+
+	// 默认角色名可能已被之前的副本占用
+	for attempt := 1; ; attempt++ {
+		info.Name = forkName(source.Name, attempt)
+		forked, err := s.CreateCharacter(ctx, nil, info)
+		if !errors.Is(err, ErrNameTaken) || attempt == maxForkNameAttempts {
+			return forked, err
+		}
+	}
+}
+
+// forkName 返回副本的默认角色名，第 attempt 次尝试时加上序号，超长时截断来源角色名
+func forkName(name string, attempt int) string {
+	suffix := "的副本"
+	if attempt > 1 {
+		suffix = fmt.Sprintf("的副本%d", attempt)
+	}
+	if n := MaxNameLength - utf8.RuneCountInString(suffix); utf8.RuneCountInString(name) > n {
+		name = string([]rune(name)[:n])
+	}
+	return name + suffix
+}
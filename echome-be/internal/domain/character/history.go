@@ -0,0 +1,104 @@
+package character
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/samber/lo"
+)
+
+// ListVersions 获取角色的历史版本，按版本号倒序，只有所有者可以查看
+func (s *CharacterService) ListVersions(ctx context.Context, id uuid.UUID) ([]*Version, error) {
+	if _, err := s.editableCharacter(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.ListVersions(ctx, id)
+}
+
+// GetVersion 获取角色的指定版本
+func (s *CharacterService) GetVersion(ctx context.Context, id uuid.UUID, version int32) (*Version, error) {
+	if _, err := s.editableCharacter(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.GetVersion(ctx, id, version)
+}
+
+// DiffVersions 比较角色的两个版本
+func (s *CharacterService) DiffVersions(ctx context.Context, id uuid.UUID, from, to int32) (*VersionDiff, error) {
+	if _, err := s.editableCharacter(ctx, id); err != nil {
+		return nil, err
+	}
+	fromVersion, err := s.characterRepo.GetVersion(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.characterRepo.GetVersion(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(fromVersion, toVersion), nil
+}
+
+// RollbackCharacter 把角色恢复为指定版本的内容，回滚本身记录为一个新版本，历史版本保持不变
+// 版本引用的音色已被删除或不可用时回滚失败
+func (s *CharacterService) RollbackCharacter(ctx context.Context, id uuid.UUID, version int32) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.characterRepo.GetVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateCharacter(ctx, character, target.patch()); err != nil {
+		return nil, err
+	}
+	if !sameHotwords(character.Hotwords, target.Hotwords) {
+		if err := s.syncHotwords(ctx, character, target.Hotwords); err != nil {
+			return nil, err
+		}
+	}
+	if target.Tags != nil && !slices.Equal(character.Tags, target.Tags) {
+		if err := s.characterRepo.SetCharacterTags(ctx, id, target.Tags); err != nil {
+			return nil, err
+		}
+		character.Tags = target.Tags
+	}
+	if err := s.recordVersion(ctx, character, ChangeRollback, &version); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+// recordVersion 把角色当前内容保存为新版本，内容与当前版本相同时不保存
+// 与当前版本比较而不是与修改前比较，上次保存版本失败后重试同样的修改可以补上版本
+func (s *CharacterService) recordVersion(ctx context.Context, character *Character, change string, rolledBackFrom *int32) error {
+	snapshot := SnapshotOf(character)
+	if character.Version > 0 {
+		current, err := s.characterRepo.GetVersion(ctx, character.ID, character.Version)
+		if err != nil {
+			return err
+		}
+		if current.Snapshot.Equal(snapshot) {
+			return nil
+		}
+	}
+
+	version := &Version{
+		CharacterID:    character.ID,
+		Change:         change,
+		RolledBackFrom: rolledBackFrom,
+		Snapshot:       snapshot,
+	}
+	if user := auth.UserFrom(ctx); user != nil {
+		version.AuthorID = lo.ToPtr(user.ID)
+	}
+	if err := s.characterRepo.SaveVersion(ctx, version); err != nil {
+		return err
+	}
+	character.Version = version.Version
+	return nil
+}
@@ -0,0 +1,94 @@
+package character
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// 分页大小
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// 排序方式
+const (
+	// SortNewest 按创建时间倒序
+	SortNewest = "newest"
+	// SortPopular 按语音对话次数倒序，次数相同时按创建时间倒序
+	SortPopular = "popular"
+)
+
+var (
+	// ErrInvalidCursor 翻页游标无法解析
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidSort 不支持的排序方式
+	ErrInvalidSort = errors.New("unsupported sort order")
+)
+
+// ListQuery 角色列表的查询条件
+type ListQuery struct {
+	// Viewer 当前用户，列表只包含公开角色和当前用户自己的角色，管理员可以看到所有角色
+	Viewer *auth.User
+	// OwnerID 按所有者过滤，为空时不过滤
+	OwnerID *string
+	// Status 按状态过滤，为空时不过滤
+	Status *int32
+	// FavoritedBy 只返回该用户收藏的角色，为空时不过滤
+	FavoritedBy *string
+	// ReviewStatus 按内容审核状态过滤，为空时不过滤
+	ReviewStatus string
+	// Cloned 按是否使用复刻音色过滤，为空时不过滤
+	Cloned *bool
+	// Tag 只返回带有该标签的角色，为空时不过滤
+	Tag string
+	// Search 检索角色名和描述
+	Search string
+	// Sort 排序方式，默认 SortNewest
+	Sort string
+	// Cursor 上一页返回的游标，为空时从第一页开始
+	Cursor *Cursor
+	// Limit 每页数量
+	Limit int
+}
+
+// Cursor 翻页游标，记录上一页最后一个角色的排序键
+type Cursor struct {
+	// SessionCount 按热度排序时上一页最后一个角色的对话次数
+	SessionCount int64     `json:"n,omitempty"`
+	CreatedAt    time.Time `json:"t"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// Encode 把游标编码为不透明的字符串
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解析 Encode 生成的游标
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Page 一页角色
+type Page struct {
+	Items []*Character `json:"items"`
+	// NextCursor 下一页的游标，没有更多数据时为空
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Facets 符合查询条件的所有角色中最常见的标签及角色数，只在第一页返回
+	Facets []TagCount `json:"facets,omitempty"`
+}
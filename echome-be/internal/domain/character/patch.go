@@ -0,0 +1,195 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// 字段长度限制
+const (
+	MaxNameLength        = 50
+	MaxDescriptionLength = 500
+	MaxPromptLength      = 8000
+	MaxLicenseLength     = 100
+)
+
+// Optional 可以区分“未提供”和“显式置空”的 JSON 字段，用于部分更新
+type Optional[T any] struct {
+	// Set 请求中是否包含该字段
+	Set bool
+	// Value 字段值，显式传 null 时为空
+	Value *T
+}
+
+// Some 返回已设置的字段
+func Some[T any](value *T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// UnmarshalJSON 字段出现在 JSON 中时才会被调用，因此可以据此标记为已设置
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+	return nil
+}
+
+// Patch 角色的部分更新，未设置的字段保持不变
+type Patch struct {
+	Name         Optional[string]
+	Prompt       Optional[string]
+	Description  Optional[string]
+	Avatar       Optional[string]
+	AudioExample Optional[string]
+	Greeting     Optional[string]
+	// AlternateGreetings 备选开场白，置空时清空
+	AlternateGreetings Optional[[]string]
+	// ExampleDialogues 对话示例，置空时清空
+	ExampleDialogues Optional[[]ExampleDialogue]
+	// Visibility 可见性，不能置空
+	Visibility Optional[string]
+	// VoiceID 音色库中的音色，置空时使用默认音色
+	VoiceID Optional[uuid.UUID]
+	// AllowFork 是否允许其他用户复制，置空时恢复为允许
+	AllowFork Optional[bool]
+	License   Optional[string]
+	// Speech 语音合成参数，整体替换，置空时恢复默认
+	Speech Optional[SpeechSettings]
+}
+
+// FieldIssue 字段未通过校验的原因
+type FieldIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError 角色字段未通过校验
+type ValidationError struct {
+	Issues []FieldIssue `json:"issues"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		messages = append(messages, issue.Field+": "+issue.Message)
+	}
+	return "角色字段校验失败: " + strings.Join(messages, "; ")
+}
+
+// apply 把部分更新应用到角色上，不处理音色
+func (p *Patch) apply(character *Character) {
+	if p.Name.Set {
+		character.Name = strings.TrimSpace(lo.FromPtr(p.Name.Value))
+	}
+	if p.Prompt.Set {
+		character.Prompt = strings.TrimSpace(lo.FromPtr(p.Prompt.Value))
+	}
+	if p.Description.Set {
+		character.Description = p.Description.Value
+	}
+	if p.Avatar.Set {
+		character.Avatar = p.Avatar.Value
+	}
+	if p.AudioExample.Set {
+		character.AudioExample = p.AudioExample.Value
+	}
+	if p.Greeting.Set {
+		character.Greeting = p.Greeting.Value
+	}
+	if p.AlternateGreetings.Set {
+		character.AlternateGreetings = lo.FromPtr(p.AlternateGreetings.Value)
+	}
+	if p.ExampleDialogues.Set {
+		character.ExampleDialogues = lo.FromPtr(p.ExampleDialogues.Value)
+	}
+	if p.Visibility.Set {
+		character.Visibility = lo.FromPtr(p.Visibility.Value)
+	}
+	if p.AllowFork.Set {
+		character.AllowFork = lo.FromPtrOr(p.AllowFork.Value, true)
+	}
+	if p.License.Set {
+		character.License = normalizeLicense(p.License.Value)
+	}
+	if p.Speech.Set {
+		character.Speech = lo.FromPtr(p.Speech.Value)
+		character.Speech.TTSModel = normalizeTTSModel(character.Speech.TTSModel)
+	}
+	normalizeDialogue(character)
+}
+
+// Validate 校验角色的可编辑字段，不通过时返回 *ValidationError
+func Validate(character *Character) error {
+	var issues []FieldIssue
+	add := func(field, format string, args ...any) {
+		issues = append(issues, FieldIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch n := utf8.RuneCountInString(strings.TrimSpace(character.Name)); {
+	case n == 0:
+		add("name", "不能为空")
+	case n > MaxNameLength:
+		add("name", "不能超过%d个字", MaxNameLength)
+	}
+	switch n := utf8.RuneCountInString(strings.TrimSpace(character.Prompt)); {
+	case n == 0:
+		add("prompt", "不能为空")
+	case n > MaxPromptLength:
+		add("prompt", "不能超过%d个字", MaxPromptLength)
+	}
+	if character.Description != nil && utf8.RuneCountInString(*character.Description) > MaxDescriptionLength {
+		add("description", "不能超过%d个字", MaxDescriptionLength)
+	}
+	if character.Avatar != nil && !isHTTPURL(*character.Avatar) && !isSitePath(*character.Avatar) {
+		add("avatar", "必须是 http 或 https 地址，或以 / 开头的本站路径")
+	}
+	if character.AudioExample != nil && !isHTTPURL(*character.AudioExample) {
+		add("audio_example", "必须是 http 或 https 地址")
+	}
+	if !IsVisibility(character.Visibility) {
+		add("visibility", "必须是 %s、%s 或 %s", VisibilityPrivate, VisibilityUnlisted, VisibilityPublic)
+	}
+	if character.License != nil && utf8.RuneCountInString(*character.License) > MaxLicenseLength {
+		add("license", "不能超过%d个字", MaxLicenseLength)
+	}
+	validateDialogue(character, add)
+	validateSpeech(character.Speech, add)
+
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// normalizeLicense 去除许可首尾空白，空白的许可视为未声明
+func normalizeLicense(license *string) *string {
+	if license == nil {
+		return nil
+	}
+	return lo.EmptyableToPtr(strings.TrimSpace(*license))
+}
+
+// isSitePath 判断是否为本站的绝对路径，未配置对外地址时文件存储返回这种URL
+func isSitePath(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "" && u.Host == "" && strings.HasPrefix(u.Path, "/")
+}
+
+// isHTTPURL 判断是否为绝对的 http/https 地址
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,64 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/job"
+	"go.uber.org/zap"
+)
+
+// JobTypeCharacterPurge 清除保留期已过的已删除角色的任务
+const JobTypeCharacterPurge = "character_purge"
+
+// DeletedRetention 已删除角色的保留期，保留期内可以恢复，之后角色连同热词表和试听音频一起清除
+const DeletedRetention = 30 * 24 * time.Hour
+
+// characterPurgePolicy 清除失败时稍后重试，保留期到期后一周内仍未成功视为失败
+var characterPurgePolicy = job.Policy{
+	BaseDelay: 10 * time.Minute,
+	MaxDelay:  6 * time.Hour,
+	MaxAge:    DeletedRetention + 7*24*time.Hour,
+}
+
+// schedulePurge 安排在保留期结束后清除已删除的角色，重复删除时重新计算保留期
+func (s *CharacterService) schedulePurge(ctx context.Context, id uuid.UUID) error {
+	_, err := s.scheduler.Schedule(ctx, JobTypeCharacterPurge, id.String(), time.Now().Add(DeletedRetention))
+	return err
+}
+
+// runCharacterPurge 清除已删除的角色：删除热词表和试听音频，再删除角色及其标签、版本、收藏和审核记录
+// 最后释放随角色复刻且不再被引用的音色；角色已恢复或已清除时任务直接结束
+func (s *CharacterService) runCharacterPurge(ctx context.Context, j *job.Job) (bool, error) {
+	id, err := uuid.Parse(j.RefID)
+	if err != nil {
+		return true, nil
+	}
+	character, err := s.characterRepo.GetDeletedByID(ctx, id)
+	if errors.Is(err, ErrCharacterNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	s.deleteVocabulary(ctx, character.VocabularyID)
+	if character.VoicePreviewURL != nil {
+		if err := s.storage.Delete(ctx, voicePreviewKey(character.ID)); err != nil {
+			zap.L().Warn("删除音色试听失败", zap.String("characterID", character.ID.String()), zap.Error(err))
+		}
+	}
+	if err := s.characterRepo.Purge(ctx, id); err != nil && !errors.Is(err, ErrCharacterNotFound) {
+		return false, err
+	}
+	// 角色已清除，任务不会再执行，释放失败只记录日志，上游音色由 Reconcile 回收
+	if character.VoiceID != nil {
+		if err := s.voiceService.ReleaseCharacterVoice(ctx, *character.VoiceID); err != nil {
+			zap.L().Warn("释放音色失败", zap.String("voiceID", character.VoiceID.String()), zap.Error(err))
+		}
+	}
+	zap.L().Info("已清除删除的角色", zap.String("characterID", id.String()))
+	return true, nil
+}
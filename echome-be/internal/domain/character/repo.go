@@ -2,16 +2,73 @@ package character
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+var (
+	// ErrCharacterNotFound 角色不存在
+	ErrCharacterNotFound = errors.New("character not found")
+	// ErrNameTaken 角色名已被同一所有者的其他角色使用
+	ErrNameTaken = errors.New("character name is already taken")
+	// ErrNoVoice 角色未使用音色库中的音色
+	ErrNoVoice = errors.New("character does not use a voice from the voice library")
 )
 
 // Repo 角色仓库接口
 type Repo interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Character, error)
-	GetAll(ctx context.Context) ([]*Character, error)
+	// List 按查询条件获取角色，最多返回 query.Limit 个
+	List(ctx context.Context, query ListQuery) ([]*Character, error)
+	// Save 保存新角色并回填ID，角色名重复时返回 ErrNameTaken
 	Save(ctx context.Context, character *Character) error
+	// Update 更新角色的可编辑字段和状态，角色名重复时返回 ErrNameTaken
 	Update(ctx context.Context, character *Character) error
-	// GetCharactersByStatus 根据状态获取角色列表
-	GetCharactersByStatus(ctx context.Context, status int32) ([]*Character, error)
+	// Delete 软删除角色
+	Delete(ctx context.Context, id uuid.UUID) error
+	// GetDeletedByID 获取已软删除的角色，角色不存在或未删除时返回 ErrCharacterNotFound
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*Character, error)
+	// Restore 恢复已软删除的角色，角色不存在或未删除时返回 ErrCharacterNotFound
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge 彻底删除已软删除的角色及其标签、版本、收藏和审核记录，角色不存在或未删除时返回 ErrCharacterNotFound
+	Purge(ctx context.Context, id uuid.UUID) error
+	// GetByVoiceID 获取使用指定音色的角色
+	GetByVoiceID(ctx context.Context, voiceID uuid.UUID) ([]*Character, error)
+	// UpdateHotwords 更新角色热词及热词表ID
+	UpdateHotwords(ctx context.Context, id uuid.UUID, hotwords []ai.Hotword, vocabularyID *string) error
+	// SaveVersion 保存角色的新版本，分配下一个版本号并更新角色的当前版本号，回填版本ID、版本号和创建时间
+	SaveVersion(ctx context.Context, version *Version) error
+	// ListVersions 获取角色的所有版本，按版本号倒序
+	ListVersions(ctx context.Context, characterID uuid.UUID) ([]*Version, error)
+	// GetVersion 获取角色的指定版本，不存在时返回 ErrVersionNotFound
+	GetVersion(ctx context.Context, characterID uuid.UUID, version int32) (*Version, error)
+	// TagFacets 统计符合查询条件的角色中最常见的标签，忽略游标，最多返回 limit 个
+	TagFacets(ctx context.Context, query ListQuery, limit int) ([]TagCount, error)
+	// ListTags 获取标签，精选分类按展示顺序排在前面，其余按名称排序
+	ListTags(ctx context.Context, categoriesOnly bool) ([]*Tag, error)
+	// GetTag 获取标签，不存在时返回 ErrTagNotFound
+	GetTag(ctx context.Context, id uuid.UUID) (*Tag, error)
+	// SaveTag 保存新标签并回填ID和创建时间，同名标签已存在时返回 ErrTagExists
+	SaveTag(ctx context.Context, tag *Tag) error
+	// UpdateTag 更新标签，同名标签已存在时返回 ErrTagExists
+	UpdateTag(ctx context.Context, tag *Tag) error
+	// DeleteTag 删除标签及其与角色的关联，不存在时返回 ErrTagNotFound
+	DeleteTag(ctx context.Context, id uuid.UUID) error
+	// SetCharacterTags 覆盖角色的标签，不存在的标签自动创建
+	SetCharacterTags(ctx context.Context, characterID uuid.UUID, tags []string) error
+	// SaveReview 在一个事务中保存审核记录并更新角色的审核状态和角色状态，回填记录ID和创建时间
+	SaveReview(ctx context.Context, review *Review, character *Character) error
+	// ListReviews 获取角色的审核记录，按时间倒序
+	ListReviews(ctx context.Context, characterID uuid.UUID) ([]*Review, error)
+	// Stats 汇总角色所有语音对话的统计，及 since 之后每天的统计，不包括收藏数
+	Stats(ctx context.Context, characterID uuid.UUID, since time.Time) (*Stats, error)
+	// AddFavorite 收藏角色并累加收藏数，已收藏时不做修改
+	AddFavorite(ctx context.Context, userID string, characterID uuid.UUID) error
+	// RemoveFavorite 取消收藏并减少收藏数，未收藏时不做修改
+	RemoveFavorite(ctx context.Context, userID string, characterID uuid.UUID) error
+	// UpdateVoicePreview 更新音色试听音频URL
+	UpdateVoicePreview(ctx context.Context, id uuid.UUID, url *string) error
 }
@@ -0,0 +1,132 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/samber/lo"
+)
+
+// MaxReviewReasonLength 审核意见的最大长度
+const MaxReviewReasonLength = 500
+
+// ErrReviewStale 审核的版本不是角色的当前版本，角色在审核期间被修改过
+var ErrReviewStale = errors.New("character has changed since the reviewed version")
+
+// Review 一次内容审核决定，每次通过或驳回都会记录
+type Review struct {
+	ID          uuid.UUID `json:"id"`
+	CharacterID uuid.UUID `json:"character_id"`
+	// CharacterVersion 审核时角色的版本号
+	CharacterVersion int32 `json:"character_version"`
+	// Decision 审核结果: approved, rejected
+	Decision string `json:"decision"`
+	// Reason 审核意见，驳回时必填
+	Reason *string `json:"reason"`
+	// ReviewerID 审核的管理员用户ID
+	ReviewerID string    `json:"reviewer_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListPendingReviews 获取等待审核的角色，按创建时间倒序，只有管理员可以查看
+func (s *CharacterService) ListPendingReviews(ctx context.Context, query ListQuery) (*Page, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	query.ReviewStatus = ReviewPending
+	query.Status = nil
+	return s.ListCharacters(ctx, query)
+}
+
+// ApproveCharacter 审核通过角色，音色也可用时角色变为可用
+// version 不为空时须与角色当前版本一致，否则返回 ErrReviewStale
+func (s *CharacterService) ApproveCharacter(ctx context.Context, id uuid.UUID, version *int32, reason *string) (*Character, error) {
+	return s.reviewCharacter(ctx, id, version, ReviewApproved, reason)
+}
+
+// RejectCharacter 驳回角色，必须填写原因，所有者修改内容后重新进入审核
+// version 不为空时须与角色当前版本一致，否则返回 ErrReviewStale
+func (s *CharacterService) RejectCharacter(ctx context.Context, id uuid.UUID, version *int32, reason string) (*Character, error) {
+	return s.reviewCharacter(ctx, id, version, ReviewRejected, &reason)
+}
+
+// ListReviews 获取角色的审核记录，按时间倒序，只有所有者可以查看
+func (s *CharacterService) ListReviews(ctx context.Context, id uuid.UUID) ([]*Review, error) {
+	if _, err := s.editableCharacter(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.ListReviews(ctx, id)
+}
+
+// reviewCharacter 记录审核决定并更新角色的审核状态
+func (s *CharacterService) reviewCharacter(ctx context.Context, id uuid.UUID, version *int32, decision string, reason *string) (*Character, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if reason != nil {
+		reason = lo.EmptyableToPtr(strings.TrimSpace(*reason))
+	}
+	if err := validateReview(decision, reason); err != nil {
+		return nil, err
+	}
+
+	character, err := s.characterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != nil && *version != character.Version {
+		return nil, ErrReviewStale
+	}
+
+	character.ReviewStatus = decision
+	character.ReviewReason = lo.Ternary(decision == ReviewRejected, reason, nil)
+	refreshStatus(character, voiceStatusReason(character))
+	character.UpdatedAt = time.Now()
+	review := &Review{
+		CharacterID:      id,
+		CharacterVersion: character.Version,
+		Decision:         decision,
+		Reason:           reason,
+		ReviewerID:       auth.UserFrom(ctx).ID,
+	}
+	if err := s.characterRepo.SaveReview(ctx, review, character); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+// requestReview 角色内容变化后重新进入审核，可见性、热词、标签、复制许可和语音合成参数不影响审核
+func requestReview(character *Character, before Snapshot) {
+	after := SnapshotOf(character)
+	for _, s := range []*Snapshot{&before, &after} {
+		s.Visibility, s.Hotwords, s.Tags = "", nil, nil
+		s.AllowFork, s.License, s.Speech = false, nil, SpeechSettings{}
+	}
+	if before.Equal(after) || character.ReviewStatus == ReviewPending {
+		return
+	}
+	character.ReviewStatus = ReviewPending
+	character.ReviewReason = nil
+	refreshStatus(character, voiceStatusReason(character))
+}
+
+// validateReview 校验审核意见，不通过时返回 *ValidationError
+func validateReview(decision string, reason *string) error {
+	var issues []FieldIssue
+	switch {
+	case decision == ReviewRejected && reason == nil:
+		issues = append(issues, FieldIssue{Field: "reason", Message: "驳回时必须填写原因"})
+	case reason != nil && utf8.RuneCountInString(*reason) > MaxReviewReasonLength:
+		issues = append(issues, FieldIssue{Field: "reason", Message: fmt.Sprintf("不能超过%d个字", MaxReviewReasonLength)})
+	}
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package character
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 中文没有空格分词，Postgres 自带的解析器无法切分，这里在写入和查询时统一切词：
+// 连续的汉字、假名、谚文同时按单字和相邻两字切分，其他字母数字按单词切分并转为小写。
+// 写入的词以空格分隔保存在 search_text 列，数据库用 array_to_tsvector 生成 search_vector，不再经过解析器。
+
+// SearchText 返回角色用于全文检索的词，覆盖角色名和描述
+func SearchText(character *Character) string {
+	text := character.Name
+	if character.Description != nil {
+		text += " " + *character.Description
+	}
+
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(token string) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	for _, run := range splitRuns(text) {
+		if !run.cjk {
+			add(string(run.runes))
+			continue
+		}
+		for i := range run.runes {
+			add(string(run.runes[i]))
+			if i+1 < len(run.runes) {
+				add(string(run.runes[i : i+2]))
+			}
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// SearchQuery 把用户输入转换为 tsquery，所有词都需要命中；没有可检索的词时返回空字符串
+// 单个汉字按单字匹配，多个汉字按相邻两字匹配，最后一个单词按前缀匹配以支持边输入边搜索
+func SearchQuery(input string) string {
+	runs := splitRuns(input)
+	var terms []string
+	for i, run := range runs {
+		switch {
+		case !run.cjk && i == len(runs)-1:
+			terms = append(terms, quoteLexeme(string(run.runes))+":*")
+		case !run.cjk:
+			terms = append(terms, quoteLexeme(string(run.runes)))
+		case len(run.runes) == 1:
+			terms = append(terms, quoteLexeme(string(run.runes)))
+		default:
+			for j := 0; j+1 < len(run.runes); j++ {
+				terms = append(terms, quoteLexeme(string(run.runes[j:j+2])))
+			}
+		}
+	}
+	return strings.Join(terms, " & ")
+}
+
+type textRun struct {
+	runes []rune
+	cjk   bool
+}
+
+// splitRuns 把文本切分为连续的 CJK 字符段和字母数字段，其余字符作为分隔符
+func splitRuns(text string) []textRun {
+	var runs []textRun
+	var current *textRun
+	for _, r := range strings.ToLower(text) {
+		cjk := isCJK(r)
+		if !cjk && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			current = nil
+			continue
+		}
+		if current == nil || current.cjk != cjk {
+			runs = append(runs, textRun{cjk: cjk})
+			current = &runs[len(runs)-1]
+		}
+		current.runes = append(current.runes, r)
+	}
+	return runs
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// quoteLexeme 按 tsquery 语法引用词，词中只有字母数字，无需转义
+func quoteLexeme(lexeme string) string {
+	return "'" + lexeme + "'"
+}
@@ -2,98 +2,410 @@ package character
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/justin/echome-be/config"
 	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/job"
+	"github.com/justin/echome-be/internal/domain/storage"
+	"github.com/justin/echome-be/internal/domain/voice"
 	"github.com/samber/lo"
+	"go.uber.org/zap"
 )
 
 // CharacterService 角色服务
 type CharacterService struct {
-	characterRepo Repo
-	aiClient      ai.Repo
+	characterRepo   Repo
+	aiClient        ai.Repo
+	storage         storage.Repo
+	voiceService    *voice.VoiceService
+	scheduler       *job.Scheduler
+	characterConfig *config.CharacterConfig
 }
 
-// NewCharacterService 创建角色服务
-func NewCharacterService(repo Repo, aiClient ai.Repo) *CharacterService {
-	return &CharacterService{
-		characterRepo: repo,
-		aiClient:      aiClient,
+// NewCharacterService 创建角色服务，订阅音色状态变化以同步角色状态，并注册已删除角色的清除任务
+func NewCharacterService(repo Repo, aiClient ai.Repo, storage storage.Repo, voiceService *voice.VoiceService, scheduler *job.Scheduler, characterConfig *config.CharacterConfig) *CharacterService {
+	s := &CharacterService{
+		characterRepo:   repo,
+		aiClient:        aiClient,
+		storage:         storage,
+		voiceService:    voiceService,
+		scheduler:       scheduler,
+		characterConfig: characterConfig,
 	}
+	voiceService.OnStatusChange(s.syncVoiceStatus)
+	scheduler.Register(JobTypeCharacterPurge, job.Handler{
+		Policy: characterPurgePolicy,
+		Run:    s.runCharacterPurge,
+	})
+	return s
 }
 
-// GetCharacterByID 获取角色信息
+// GetCharacterByID 获取当前用户可见的角色，私有角色对其他用户返回 ErrCharacterNotFound
 func (s *CharacterService) GetCharacterByID(ctx context.Context, id uuid.UUID) (*Character, error) {
-	return s.characterRepo.GetByID(ctx, id)
+	return s.viewableCharacter(ctx, id)
 }
 
-// GetAllCharacters 获取所有角色
-func (s *CharacterService) GetAllCharacters(ctx context.Context) ([]*Character, error) {
-	return s.characterRepo.GetAll(ctx)
+// ListCharacters 分页获取当前用户可见的角色列表，不包含其他用户的私有和不公开列出的角色
+func (s *CharacterService) ListCharacters(ctx context.Context, query ListQuery) (*Page, error) {
+	query.Viewer = auth.UserFrom(ctx)
+	query.Tag = NormalizeTagName(query.Tag)
+	if query.Sort == "" {
+		query.Sort = SortNewest
+	}
+	if query.Sort != SortNewest && query.Sort != SortPopular {
+		return nil, ErrInvalidSort
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+	query.Limit = min(query.Limit, MaxPageSize)
+
+	// 多取一个用于判断是否还有下一页
+	pageSize := query.Limit
+	query.Limit++
+	characters, err := s.characterRepo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Items: characters}
+	if query.Cursor == nil {
+		if page.Facets, err = s.characterRepo.TagFacets(ctx, query, MaxFacets); err != nil {
+			return nil, err
+		}
+	}
+	if len(characters) > pageSize {
+		page.Items = characters[:pageSize]
+		last := page.Items[pageSize-1]
+		page.NextCursor = (&Cursor{SessionCount: last.SessionCount, CreatedAt: last.CreatedAt, ID: last.ID}).Encode()
+	}
+	return page, nil
 }
 
-// CreateCharacter 创建角色
-func (s *CharacterService) CreateCharacter(ctx context.Context, audio *string, characterInfo *Character) error {
+// CreateCharacter 创建角色，返回保存后的角色
+// 指定 Flag 时用音频样本复刻新音色并加入音色库，否则使用 VoiceID 指定的音色库音色
+// 角色归当前用户所有，未指定可见性时为私有，是否允许复制取 characterInfo.AllowFork
+// 字段校验失败返回 *ValidationError，角色名重复返回 ErrNameTaken
+func (s *CharacterService) CreateCharacter(ctx context.Context, audio *string, characterInfo *Character) (*Character, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
 	// 1. 角色初始化
 	character := &Character{
-		Name:         characterInfo.Name,
-		Description:  characterInfo.Description,
-		Prompt:       characterInfo.Prompt,
-		Avatar:       characterInfo.Avatar,
-		Flag:         characterInfo.Flag,
-		AudioExample: characterInfo.AudioExample,
-		Status:       CharacterStatusPending, // 使用枚举值设置初始状态为审核中
-	}
-
-	// 2. 判断是否需要创建音色
-	if characterInfo.Flag {
-		//  调用AI服务创建音色
-		voiceProfile, err := s.aiClient.VoiceClone(ctx, lo.FromPtr(audio))
+		OwnerID:            &user.ID,
+		Visibility:         lo.CoalesceOrEmpty(characterInfo.Visibility, VisibilityPrivate),
+		Name:               strings.TrimSpace(characterInfo.Name),
+		Description:        characterInfo.Description,
+		Prompt:             strings.TrimSpace(characterInfo.Prompt),
+		Greeting:           characterInfo.Greeting,
+		AlternateGreetings: characterInfo.AlternateGreetings,
+		ExampleDialogues:   characterInfo.ExampleDialogues,
+		Avatar:             characterInfo.Avatar,
+		AudioExample:       characterInfo.AudioExample,
+		Hotwords:           characterInfo.Hotwords,
+		ForkedFrom:         characterInfo.ForkedFrom,
+		AllowFork:          characterInfo.AllowFork,
+		License:            normalizeLicense(characterInfo.License),
+		Speech:             characterInfo.Speech,
+		Status:             CharacterStatusPending, // 使用枚举值设置初始状态为审核中
+		VoiceStatus:        voice.StatusReady,
+		ReviewStatus:       ReviewPending,
+	}
+	character.Speech.TTSModel = normalizeTTSModel(character.Speech.TTSModel)
+	normalizeDialogue(character)
+	if err := Validate(character); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(characterInfo.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// 复刻音色不能覆盖模型，在复刻前检查以免浪费复刻配额
+	if characterInfo.Flag && character.Speech.TTSModel != nil {
+		return nil, speechModelError("复刻音色只能由复刻时的模型合成")
+	}
+
+	// 2. 确定角色音色，需要复刻时创建新音色
+	var v, cloned *voice.Voice
+	switch {
+	case characterInfo.Flag:
+		cloned, err = s.voiceService.CreateVoice(ctx, voice.CreateRequest{
+			Name:      characterInfo.Name,
+			OwnerID:   &user.ID,
+			Provider:  voice.ProviderClone,
+			SampleURL: lo.FromPtr(audio),
+			// 随角色复刻的音色在最后一个引用它的角色清除后释放
+			CharacterOwned: true,
+		})
+		v = cloned
+	case characterInfo.VoiceID != nil:
+		// 复制的角色沿用来源角色的音色，ForkCharacter 已检查过来源角色
+		v, err = s.usableVoice(ctx, *characterInfo.VoiceID, characterInfo.ForkedFrom != nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		applyVoice(character, v)
+	}
+	if err := s.validateSpeechModel(ctx, character); err != nil {
+		s.discardVoice(ctx, cloned)
+		return nil, err
+	}
+
+	// 3. 同步热词表
+	if len(character.Hotwords) > 0 {
+		vocabularyID, err := s.aiClient.CreateVocabulary(ctx, character.Hotwords)
 		if err != nil {
-			return err
+			s.discardVoice(ctx, cloned)
+			return nil, err
 		}
-		character.Voice = voiceProfile
+		character.VocabularyID = &vocabularyID
 	}
-	err := s.characterRepo.Save(ctx, character)
+
+	if err := s.characterRepo.Save(ctx, character); err != nil {
+		s.deleteVocabulary(ctx, character.VocabularyID)
+		s.discardVoice(ctx, cloned)
+		return nil, err
+	}
+	// 保存之后的步骤失败时删除刚保存的角色，避免留下没有标签或版本记录的角色
+	if len(tags) > 0 {
+		if err := s.characterRepo.SetCharacterTags(ctx, character.ID, tags); err != nil {
+			s.discardCharacter(ctx, character)
+			return nil, err
+		}
+	}
+	character.Tags = tags
+	if err := s.recordVersion(ctx, character, ChangeCreate, nil); err != nil {
+		s.discardCharacter(ctx, character)
+		return nil, err
+	}
+
+	// 4. 音色已可用时直接生成试听，复刻音色审核通过后由状态同步生成
+	if character.VoiceStatus == voice.StatusReady && character.VoiceID != nil {
+		s.generateVoicePreviewAsync(character)
+	}
+	return character, nil
+}
+
+// UpdateCharacter 部分更新角色，未设置的字段保持不变，整体替换时调用方需设置所有字段
+// 更换音色或角色名后重新生成试听音频；字段校验失败返回 *ValidationError，角色名重复返回 ErrNameTaken
+func (s *CharacterService) UpdateCharacter(ctx context.Context, id uuid.UUID, patch Patch) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+	if err := s.updateCharacter(ctx, character, patch); err != nil {
+		return nil, err
+	}
+	if err := s.recordVersion(ctx, character, ChangeUpdate, nil); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+// updateCharacter 把部分更新应用到角色并保存，不记录版本
+func (s *CharacterService) updateCharacter(ctx context.Context, character *Character, patch Patch) error {
+	oldPreviewText := s.voicePreviewText(character)
+	before := SnapshotOf(character)
+	patch.apply(character)
+	if err := Validate(character); err != nil {
+		return err
+	}
+
+	voiceChanged := patch.VoiceID.Set && lo.FromPtr(patch.VoiceID.Value) != lo.FromPtr(character.VoiceID)
+	if voiceChanged {
+		if patch.VoiceID.Value == nil {
+			clearVoice(character)
+		} else {
+			v, err := s.usableVoice(ctx, *patch.VoiceID.Value, false)
+			if err != nil {
+				return err
+			}
+			applyVoice(character, v)
+		}
+	}
+	if err := s.validateSpeechModel(ctx, character); err != nil {
+		return err
+	}
+
+	requestReview(character, before)
+
+	character.UpdatedAt = time.Now()
+	if err := s.characterRepo.Update(ctx, character); err != nil {
 		return err
 	}
+	// 试听台词取自角色名和开场白，变化后重新生成
+	if voiceChanged || s.voicePreviewText(character) != oldPreviewText {
+		if err := s.clearVoicePreview(ctx, character); err != nil {
+			return err
+		}
+		if character.VoiceStatus == voice.StatusReady && character.VoiceID != nil {
+			s.generateVoicePreviewAsync(character)
+		}
+	}
 	return nil
 }
 
-// UpdateCharacterStatus 更新角色状态
-func (s *CharacterService) UpdateCharacterStatus(ctx context.Context, character *Character, status int32) error {
-	character.Status = status
+// DeleteCharacter 软删除角色，热词表和试听音频保留以便在 DeletedRetention 内恢复，保留期过后由清除任务回收
+// 音色属于音色库，随角色复刻的音色在最后一个引用它的角色清除时释放
+func (s *CharacterService) DeleteCharacter(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.editableCharacter(ctx, id); err != nil {
+		return err
+	}
+	if err := s.characterRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.schedulePurge(ctx, id)
+}
+
+// RestoreCharacter 恢复保留期内已删除的角色，已清除的角色返回 ErrCharacterNotFound
+// 删除期间音色被删除时改用默认音色，音色状态有变化时同步到角色
+func (s *CharacterService) RestoreCharacter(ctx context.Context, id uuid.UUID) (*Character, error) {
+	deleted, err := s.characterRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CanView(auth.UserFrom(ctx), deleted) {
+		return nil, ErrCharacterNotFound
+	}
+	if err := checkEdit(ctx, deleted); err != nil {
+		return nil, err
+	}
+
+	if err := s.characterRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	character, err := s.characterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if character.VoiceID == nil {
+		return character, nil
+	}
+
+	v, err := s.voiceService.GetVoice(ctx, *character.VoiceID)
+	switch {
+	case errors.Is(err, voice.ErrVoiceNotFound):
+		clearVoice(character)
+		if err := s.clearVoicePreview(ctx, character); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		applyVoice(character, v)
+	}
 	character.UpdatedAt = time.Now()
-	return s.characterRepo.Update(ctx, character)
+	if err := s.characterRepo.Update(ctx, character); err != nil {
+		return nil, err
+	}
+	// 音色被删除时角色内容发生了变化
+	if err := s.recordVersion(ctx, character, ChangeRestore, nil); err != nil {
+		return nil, err
+	}
+	return character, nil
 }
 
-// CheckAndUpdatePendingCharacters 检查并更新审核中角色的状态
-func (s *CharacterService) CheckAndUpdatePendingCharacters(ctx context.Context) error {
-	// 获取所有审核中的角色
-	pendingCharacters, err := s.characterRepo.GetCharactersByStatus(ctx, CharacterStatusPending)
+// UpdateHotwords 更新角色热词并同步到热词表，热词为空时删除热词表
+// hotwords 须先经过 NormalizeHotwords 校验
+func (s *CharacterService) UpdateHotwords(ctx context.Context, id uuid.UUID, hotwords []ai.Hotword) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.syncHotwords(ctx, character, hotwords); err != nil {
+		return nil, err
 	}
+	if err := s.recordVersion(ctx, character, ChangeHotwords, nil); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
 
-	// 遍历角色，检查音色状态
-	for _, character := range pendingCharacters {
-		if character.Flag && character.Voice != nil {
-			// 查询音色状态
-			status, err := s.aiClient.GetVoiceStatus(ctx, *character.Voice)
-			if err != nil {
-				// 如果查询失败，继续处理下一个角色
-				continue
-			}
+// DeleteHotwords 清空角色热词并删除热词表
+func (s *CharacterService) DeleteHotwords(ctx context.Context, id uuid.UUID) (*Character, error) {
+	return s.UpdateHotwords(ctx, id, nil)
+}
 
-			// 更新角色状态
-			if status {
-				// 音色审核通过
-				_ = s.UpdateCharacterStatus(ctx, character, CharacterStatusApproved)
+// syncHotwords 把热词同步到热词表并保存到角色，热词为空时删除热词表，不记录版本
+func (s *CharacterService) syncHotwords(ctx context.Context, character *Character, hotwords []ai.Hotword) error {
+	if len(hotwords) == 0 {
+		if character.VocabularyID != nil {
+			if err := s.aiClient.DeleteVocabulary(ctx, *character.VocabularyID); err != nil {
+				return err
 			}
 		}
+		if err := s.characterRepo.UpdateHotwords(ctx, character.ID, nil, nil); err != nil {
+			return err
+		}
+		character.Hotwords = nil
+		character.VocabularyID = nil
+		return nil
+	}
+
+	vocabularyID := character.VocabularyID
+	created := vocabularyID == nil
+	if created {
+		newID, err := s.aiClient.CreateVocabulary(ctx, hotwords)
+		if err != nil {
+			return err
+		}
+		vocabularyID = &newID
+	} else if err := s.aiClient.UpdateVocabulary(ctx, *vocabularyID, hotwords); err != nil {
+		return err
 	}
 
+	if err := s.characterRepo.UpdateHotwords(ctx, character.ID, hotwords, vocabularyID); err != nil {
+		if created {
+			s.deleteVocabulary(ctx, vocabularyID)
+		}
+		return err
+	}
+
+	character.Hotwords = hotwords
+	character.VocabularyID = vocabularyID
 	return nil
 }
+
+// deleteVocabulary 尽力删除热词表，失败时只记录日志
+func (s *CharacterService) deleteVocabulary(ctx context.Context, vocabularyID *string) {
+	if vocabularyID == nil {
+		return
+	}
+	if err := s.aiClient.DeleteVocabulary(ctx, *vocabularyID); err != nil {
+		zap.L().Warn("删除热词表失败", zap.String("vocabularyID", *vocabularyID), zap.Error(err))
+	}
+}
+
+// discardCharacter 删除创建失败的角色，并立即清除角色及其热词表和随角色复刻的音色，失败只记录日志
+func (s *CharacterService) discardCharacter(ctx context.Context, character *Character) {
+	if err := s.characterRepo.Delete(ctx, character.ID); err != nil {
+		zap.L().Warn("删除角色失败", zap.String("characterID", character.ID.String()), zap.Error(err))
+		return
+	}
+	if _, err := s.scheduler.Enqueue(ctx, JobTypeCharacterPurge, character.ID.String()); err != nil {
+		zap.L().Warn("创建角色清除任务失败", zap.String("characterID", character.ID.String()), zap.Error(err))
+	}
+}
+
+// UpdateCharacterStatus 更新角色状态，并清空状态原因
+func (s *CharacterService) UpdateCharacterStatus(ctx context.Context, character *Character, status int32) error {
+	return s.updateStatus(ctx, character, status, nil)
+}
+
+// updateStatus 更新角色状态及原因
+func (s *CharacterService) updateStatus(ctx context.Context, character *Character, status int32, reason *string) error {
+	character.Status = status
+	character.StatusReason = reason
+	character.UpdatedAt = time.Now()
+	return s.characterRepo.Update(ctx, character)
+}
@@ -0,0 +1,160 @@
+package character
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/samber/lo"
+)
+
+// 语音合成参数限制
+const (
+	// MaxTTSModelLength TTS 模型名的最大长度
+	MaxTTSModelLength = 64
+	// MaxSpeechPreviewTextLength 试听文本的最大长度
+	MaxSpeechPreviewTextLength = 200
+)
+
+// SpeechSettings 角色的语音合成参数，零值字段使用音色或服务配置中的默认值
+type SpeechSettings struct {
+	// TTSModel 覆盖音色默认模型的 TTS 模型，须与音色库音色同属 CosyVoice 或 Qwen-TTS，复刻音色不能覆盖
+	TTSModel *string `json:"tts_model"`
+	// Rate 语速，取值 0.5~2.0，0 表示默认语速
+	Rate float64 `json:"rate"`
+	// Pitch 语调，取值 0.5~2.0，0 表示默认语调
+	Pitch float64 `json:"pitch"`
+	// Volume 音量，取值 1~100，0 表示默认音量
+	Volume int `json:"volume"`
+}
+
+// SpeechPreviewRequest 试听语音合成参数的请求，参数不会保存
+type SpeechPreviewRequest struct {
+	Speech SpeechSettings
+	// Text 试听文本，为空时使用角色的试听台词
+	Text string
+}
+
+// PreviewSpeech 用尚未保存的语音合成参数合成 mp3 格式的角色试听音频，只有所有者可以试听
+// 参数校验失败返回 *ValidationError
+func (s *CharacterService) PreviewSpeech(ctx context.Context, id uuid.UUID, req SpeechPreviewRequest) ([]byte, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	req.Speech.TTSModel = normalizeTTSModel(req.Speech.TTSModel)
+	if err := validateSpeechPreview(req); err != nil {
+		return nil, err
+	}
+
+	character.Speech = req.Speech
+	if err := s.validateSpeechModel(ctx, character); err != nil {
+		return nil, err
+	}
+	ttsConfig, err := s.VoiceTTSConfig(ctx, character)
+	if err != nil {
+		return nil, err
+	}
+	ttsConfig.Format = ai.AudioFormatMP3
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		text = s.voicePreviewText(character)
+	}
+	var buf bytes.Buffer
+	if err := s.aiClient.HandleTTS(ctx, &buf, text, ttsConfig); err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 {
+		return nil, fmt.Errorf("TTS 未返回音频")
+	}
+	return buf.Bytes(), nil
+}
+
+// apply 把语音合成参数应用到音色的合成参数上，cloned 为复刻音色时不覆盖模型
+func (p SpeechSettings) apply(config ai.TTSConfig, cloned bool) ai.TTSConfig {
+	if p.TTSModel != nil && !cloned {
+		config.Model = *p.TTSModel
+	}
+	config.Rate = p.Rate
+	config.Pitch = p.Pitch
+	config.Volume = p.Volume
+	return config
+}
+
+// validateSpeechModel 校验模型覆盖能否用于角色的音色，不兼容时返回 *ValidationError
+// 模型自带音色只属于同一类模型，默认音色由服务配置决定，复刻音色只能由复刻时的模型合成
+func (s *CharacterService) validateSpeechModel(ctx context.Context, character *Character) error {
+	model := character.Speech.TTSModel
+	if model == nil {
+		return nil
+	}
+	if character.VoiceID == nil {
+		return speechModelError("使用默认音色时不能指定模型")
+	}
+	v, err := s.voiceService.GetVoice(ctx, *character.VoiceID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case v.Provider == voice.ProviderClone:
+		return speechModelError("复刻音色只能由模型 %s 合成", v.Model)
+	case ai.IsQwenTTSModel(*model) != ai.IsQwenTTSModel(v.Model):
+		return speechModelError("音色 %s 属于模型 %s，不能由 %s 合成", v.Name, v.Model, *model)
+	}
+	return nil
+}
+
+// speechModelError 返回模型覆盖未通过校验的 *ValidationError
+func speechModelError(format string, args ...any) error {
+	return &ValidationError{Issues: []FieldIssue{{Field: "speech.tts_model", Message: fmt.Sprintf(format, args...)}}}
+}
+
+// validateSpeech 校验语音合成参数，字段名以 speech. 开头
+func validateSpeech(p SpeechSettings, add func(field, format string, args ...any)) {
+	switch {
+	case p.TTSModel == nil:
+	case utf8.RuneCountInString(*p.TTSModel) > MaxTTSModelLength:
+		add("speech.tts_model", "不能超过%d个字符", MaxTTSModelLength)
+	case !ai.IsQwenTTSModel(*p.TTSModel) && !ai.IsCosyVoiceModel(*p.TTSModel):
+		add("speech.tts_model", "必须是 CosyVoice 或 Qwen-TTS 模型")
+	}
+	if err := ai.ValidateTTSRate(p.Rate); err != nil {
+		add("speech.rate", "必须在%g到%g之间，0 表示默认", ai.MinTTSRate, ai.MaxTTSRate)
+	}
+	if err := ai.ValidateTTSPitch(p.Pitch); err != nil {
+		add("speech.pitch", "必须在%g到%g之间，0 表示默认", ai.MinTTSPitch, ai.MaxTTSPitch)
+	}
+	if err := ai.ValidateTTSVolume(p.Volume); err != nil {
+		add("speech.volume", "必须在%d到%d之间，0 表示默认", ai.MinTTSVolume, ai.MaxTTSVolume)
+	}
+}
+
+// validateSpeechPreview 校验试听请求，不通过时返回 *ValidationError
+func validateSpeechPreview(req SpeechPreviewRequest) error {
+	var issues []FieldIssue
+	add := func(field, format string, args ...any) {
+		issues = append(issues, FieldIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	validateSpeech(req.Speech, add)
+	if utf8.RuneCountInString(req.Text) > MaxSpeechPreviewTextLength {
+		add("text", "不能超过%d个字", MaxSpeechPreviewTextLength)
+	}
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// normalizeTTSModel 去除模型名首尾空白，空白的模型名视为使用默认模型
+func normalizeTTSModel(model *string) *string {
+	if model == nil {
+		return nil
+	}
+	return lo.EmptyableToPtr(strings.TrimSpace(*model))
+}
@@ -0,0 +1,60 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 统计的天数
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 90
+)
+
+// Stats 角色的使用统计，由语音对话记录汇总得到
+type Stats struct {
+	// Sessions 语音对话次数
+	Sessions int64 `json:"sessions"`
+	// Turns 对话轮数，用户每发送一条消息并得到回复算一轮
+	Turns int64 `json:"turns"`
+	// VoiceMinutes 已结束对话的总时长，单位分钟
+	VoiceMinutes float64 `json:"voice_minutes"`
+	// UniqueUsers 发起过对话的登录用户数
+	UniqueUsers int64 `json:"unique_users"`
+	// Favorites 收藏数
+	Favorites int64 `json:"favorites"`
+	// Daily 最近若干天每天的统计，按日期升序，没有对话的日期不返回
+	Daily []DailyStats `json:"daily"`
+}
+
+// DailyStats 一天的使用统计，日期按 UTC 计算
+type DailyStats struct {
+	Day          time.Time `json:"day"`
+	Sessions     int64     `json:"sessions"`
+	Turns        int64     `json:"turns"`
+	VoiceMinutes float64   `json:"voice_minutes"`
+	UniqueUsers  int64     `json:"unique_users"`
+}
+
+// GetStats 获取角色的使用统计及最近 days 天每天的统计，只有所有者可以查看
+func (s *CharacterService) GetStats(ctx context.Context, id uuid.UUID, days int) (*Stats, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if days <= 0 {
+		days = DefaultStatsDays
+	}
+	days = min(days, MaxStatsDays)
+
+	// 从今天起往前 days 天，包括今天
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	stats, err := s.characterRepo.Stats(ctx, id, since)
+	if err != nil {
+		return nil, err
+	}
+	stats.Favorites = character.FavoriteCount
+	return stats, nil
+}
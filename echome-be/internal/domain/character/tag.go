@@ -0,0 +1,184 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// 标签限制
+const (
+	MaxTagLength     = 20
+	MaxCharacterTags = 10
+	// MaxFacets 角色列表返回的标签统计数量
+	MaxFacets = 20
+)
+
+var (
+	// ErrTagNotFound 标签不存在
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagExists 同名标签已存在
+	ErrTagExists = errors.New("tag already exists")
+)
+
+// Tag 角色标签，管理员可以把标签设为精选分类，分类按 SortOrder 排序展示
+type Tag struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Category 是否为精选分类
+	Category bool `json:"category"`
+	// SortOrder 分类的展示顺序，从小到大
+	SortOrder int32     `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TagPatch 标签的部分更新
+type TagPatch struct {
+	Name      Optional[string]
+	Category  Optional[bool]
+	SortOrder Optional[int32]
+}
+
+// TagCount 标签及列表中带有该标签的角色数
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// NormalizeTagName 去除标签名首尾空白并转换为小写，使同一标签的不同写法合并
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// validateTagName 校验已规范化的标签名
+func validateTagName(field, name string, add func(field, format string, args ...any)) {
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		add(field, "不能为空")
+	case n > MaxTagLength:
+		add(field, "不能超过%d个字", MaxTagLength)
+	}
+}
+
+// normalizeTags 规范化角色的标签列表并去重，不通过时返回 *ValidationError
+func normalizeTags(names []string) ([]string, error) {
+	var issues []FieldIssue
+	add := func(field, format string, args ...any) {
+		issues = append(issues, FieldIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	tags := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = NormalizeTagName(name)
+		validateTagName(fieldIndex("tags", i), name, add)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	if len(tags) > MaxCharacterTags {
+		add("tags", "不能超过%d个", MaxCharacterTags)
+	}
+
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+	return tags, nil
+}
+
+// ListTags 获取标签，精选分类按展示顺序排在前面；categoriesOnly 为 true 时只返回精选分类
+func (s *CharacterService) ListTags(ctx context.Context, categoriesOnly bool) ([]*Tag, error) {
+	return s.characterRepo.ListTags(ctx, categoriesOnly)
+}
+
+// CreateTag 创建标签，只有管理员可以直接创建标签，普通标签也会在设置角色标签时自动创建
+func (s *CharacterService) CreateTag(ctx context.Context, tag *Tag) (*Tag, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tag.Name = NormalizeTagName(tag.Name)
+	if err := validateTag(tag); err != nil {
+		return nil, err
+	}
+	if err := s.characterRepo.SaveTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// UpdateTag 修改标签名、是否为精选分类及展示顺序，只有管理员可以修改
+func (s *CharacterService) UpdateTag(ctx context.Context, id uuid.UUID, patch TagPatch) (*Tag, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.characterRepo.GetTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Name.Set && patch.Name.Value != nil {
+		tag.Name = NormalizeTagName(*patch.Name.Value)
+	}
+	if patch.Category.Set && patch.Category.Value != nil {
+		tag.Category = *patch.Category.Value
+	}
+	if patch.SortOrder.Set && patch.SortOrder.Value != nil {
+		tag.SortOrder = *patch.SortOrder.Value
+	}
+	if err := validateTag(tag); err != nil {
+		return nil, err
+	}
+	if err := s.characterRepo.UpdateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag 删除标签并从所有角色上移除，只有管理员可以删除
+func (s *CharacterService) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return err
+	}
+	return s.characterRepo.DeleteTag(ctx, id)
+}
+
+// SetCharacterTags 覆盖角色的标签，不存在的标签自动创建为普通标签
+func (s *CharacterService) SetCharacterTags(ctx context.Context, id uuid.UUID, names []string) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.characterRepo.SetCharacterTags(ctx, id, tags); err != nil {
+		return nil, err
+	}
+	character.Tags = tags
+	if err := s.recordVersion(ctx, character, ChangeTags, nil); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+// validateTag 校验标签字段，不通过时返回 *ValidationError
+func validateTag(tag *Tag) error {
+	var issues []FieldIssue
+	validateTagName("name", tag.Name, func(field, format string, args ...any) {
+		issues = append(issues, FieldIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	})
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
@@ -1,16 +1,45 @@
 package character
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
 )
 
-// 角色状态枚举定义
+// 角色状态枚举定义，由音色状态和内容审核状态共同决定
 const (
-	CharacterStatusPending  = 1 // 审核中
-	CharacterStatusApproved = 2 // 可用
+	CharacterStatusPending  = 1 // 审核中，音色复刻中或等待管理员审核
+	CharacterStatusApproved = 2 // 可用，音色可用且审核通过
 	CharacterStatusDisabled = 3 // 禁用
+	// CharacterStatusVoiceFailed 音色复刻失败，如审核超时或上游持续出错
+	CharacterStatusVoiceFailed = 4
+	// CharacterStatusVoiceRejected 音色审核未通过，需要更换样本
+	CharacterStatusVoiceRejected = 5
+	// CharacterStatusReviewRejected 内容审核被管理员驳回，修改后重新审核
+	CharacterStatusReviewRejected = 6
+)
+
+// 内容审核状态
+const (
+	// ReviewPending 等待管理员审核，新建和修改内容后进入该状态
+	ReviewPending = "pending"
+	// ReviewApproved 审核通过
+	ReviewApproved = "approved"
+	// ReviewRejected 审核驳回
+	ReviewRejected = "rejected"
+)
+
+// 角色可见性
+const (
+	// VisibilityPrivate 仅所有者可见
+	VisibilityPrivate = "private"
+	// VisibilityUnlisted 不出现在角色列表中，知道角色ID即可访问
+	VisibilityUnlisted = "unlisted"
+	// VisibilityPublic 所有人可见
+	VisibilityPublic = "public"
 )
 
 // Character represents a role in the system
@@ -22,16 +51,94 @@ type Character struct {
 	Description *string `json:"description"`
 	// 角色提示词
 	Prompt string `json:"prompt"`
+	// Greeting 开场白，语音对话连接后由角色用自己的音色说出
+	Greeting *string `json:"greeting"`
+	// AlternateGreetings 备选开场白，连接时从开场白和备选开场白中随机选择一条
+	AlternateGreetings []string `json:"alternate_greetings"`
+	// ExampleDialogues 对话示例，对话时作为之前的轮次放在上下文开头
+	ExampleDialogues []ExampleDialogue `json:"example_dialogues"`
+	// OwnerID 角色所有者的用户ID，为空的是引入所有权之前创建的角色，只有管理员可以修改
+	OwnerID *string `json:"owner_id"`
+	// Visibility 可见性: private, unlisted, public
+	Visibility string `json:"visibility"`
+	// ForkedFrom 复制来源角色的ID，来源角色被删除后保留
+	ForkedFrom *uuid.UUID `json:"forked_from"`
+	// AllowFork 是否允许其他用户复制该角色，所有者始终可以复制自己的角色
+	AllowFork bool `json:"allow_fork"`
+	// License 所有者声明的使用许可，复制的角色沿用来源角色的许可
+	License *string `json:"license"`
+	// Version 当前版本号，每次修改可编辑字段后递增
+	Version int32 `json:"version"`
 	// 角色头像URL
 	Avatar *string `json:"avatar"`
-	// 角色音色
+	// VoiceID 音色库中的音色ID，为空时使用默认音色
+	VoiceID *uuid.UUID `json:"voice_id"`
+	// 角色音色，与 VoiceID 对应音色的上游音色保持一致
 	Voice *string `json:"voice"`
 	// 是否克隆音色
 	Flag bool `json:"flag"`
 	// AudioExample 音色示例音频URL
 	AudioExample *string `json:"audio_example"`
-	// 音色状态，使用枚举值: 1-审核中, 2-可用, 3-禁用
-	Status    int32     `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	// Speech 语音合成参数，对话和合成时应用在角色音色上
+	Speech SpeechSettings `json:"speech"`
+	// VoicePreviewURL 音色可用后用该音色合成的角色试听音频URL
+	VoicePreviewURL *string `json:"voice_preview_url"`
+	// 角色状态，由 VoiceStatus 和 ReviewStatus 决定: 1-审核中, 2-可用, 3-禁用, 4-复刻失败, 5-音色审核未通过, 6-内容审核驳回
+	Status int32 `json:"status"`
+	// StatusReason 复刻失败、音色审核未通过或内容审核驳回的原因
+	StatusReason *string `json:"status_reason"`
+	// VoiceStatus 音色状态: pending / ready / failed / rejected，使用默认音色时为 ready
+	VoiceStatus string `json:"voice_status"`
+	// ReviewStatus 内容审核状态: pending / approved / rejected
+	ReviewStatus string `json:"review_status"`
+	// ReviewReason 内容审核驳回的原因
+	ReviewReason *string `json:"review_reason"`
+	// Tags 角色的标签
+	Tags []string `json:"tags"`
+	// SessionCount 语音对话次数，用于按热度排序
+	SessionCount int64 `json:"session_count"`
+	// FavoriteCount 收藏数
+	FavoriteCount int64 `json:"favorite_count"`
+	// Hotwords ASR 热词，提高角色名等专有名词的识别率
+	Hotwords []ai.Hotword `json:"hotwords"`
+	// VocabularyID 热词同步到阿里云后得到的热词表ID
+	VocabularyID *string   `json:"vocabulary_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// 热词限制
+const (
+	MaxHotwords          = 500
+	DefaultHotwordWeight = 4
+	MinHotwordWeight     = 1
+	MaxHotwordWeight     = 5
+)
+
+// NormalizeHotwords 校验热词列表，去除首尾空白和重复项，未指定权重时使用默认权重
+func NormalizeHotwords(hotwords []ai.Hotword) ([]ai.Hotword, error) {
+	if len(hotwords) > MaxHotwords {
+		return nil, fmt.Errorf("热词数量不能超过%d个", MaxHotwords)
+	}
+
+	normalized := make([]ai.Hotword, 0, len(hotwords))
+	seen := make(map[string]bool, len(hotwords))
+	for _, hotword := range hotwords {
+		hotword.Text = strings.TrimSpace(hotword.Text)
+		if hotword.Text == "" {
+			return nil, fmt.Errorf("热词文本不能为空")
+		}
+		if hotword.Weight == 0 {
+			hotword.Weight = DefaultHotwordWeight
+		}
+		if hotword.Weight < MinHotwordWeight || hotword.Weight > MaxHotwordWeight {
+			return nil, fmt.Errorf("热词 %q 的权重必须在%d到%d之间", hotword.Text, MinHotwordWeight, MaxHotwordWeight)
+		}
+		if seen[hotword.Text] {
+			continue
+		}
+		seen[hotword.Text] = true
+		normalized = append(normalized, hotword)
+	}
+	return normalized, nil
 }
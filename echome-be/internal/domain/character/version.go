@@ -0,0 +1,214 @@
+package character
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/samber/lo"
+)
+
+// 产生版本的操作
+const (
+	ChangeCreate   = "create"
+	ChangeUpdate   = "update"
+	ChangeHotwords = "hotwords"
+	ChangeTags     = "tags"
+	ChangeRestore  = "restore"
+	ChangeRollback = "rollback"
+)
+
+// ErrVersionNotFound 角色版本不存在
+var ErrVersionNotFound = errors.New("character version not found")
+
+// Snapshot 角色可编辑字段的快照，每次修改后保存为一个版本
+type Snapshot struct {
+	Name               string            `json:"name"`
+	Prompt             string            `json:"prompt"`
+	Greeting           *string           `json:"greeting"`
+	AlternateGreetings []string          `json:"alternate_greetings"`
+	ExampleDialogues   []ExampleDialogue `json:"example_dialogues"`
+	Description        *string           `json:"description"`
+	Avatar             *string           `json:"avatar"`
+	AudioExample       *string           `json:"audio_example"`
+	VoiceID            *uuid.UUID        `json:"voice_id"`
+	Visibility         string            `json:"visibility"`
+	Hotwords           []ai.Hotword      `json:"hotwords"`
+	// Tags 标签，为 nil 的是记录标签之前保存的版本，回滚时保持标签不变
+	Tags      []string       `json:"tags"`
+	AllowFork bool           `json:"allow_fork"`
+	License   *string        `json:"license"`
+	Speech    SpeechSettings `json:"speech"`
+}
+
+// Version 角色的历史版本
+type Version struct {
+	ID          uuid.UUID `json:"id"`
+	CharacterID uuid.UUID `json:"character_id"`
+	// Version 版本号，从1开始递增
+	Version int32 `json:"version"`
+	// Change 产生该版本的操作
+	Change string `json:"change"`
+	// RolledBackFrom 回滚产生的版本记录恢复的版本号
+	RolledBackFrom *int32 `json:"rolled_back_from,omitempty"`
+	// AuthorID 修改者的用户ID
+	AuthorID *string `json:"author_id"`
+	Snapshot
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SnapshotOf 返回角色当前可编辑字段的快照
+func SnapshotOf(character *Character) Snapshot {
+	return Snapshot{
+		Name:               character.Name,
+		Prompt:             character.Prompt,
+		Greeting:           character.Greeting,
+		AlternateGreetings: character.AlternateGreetings,
+		ExampleDialogues:   character.ExampleDialogues,
+		Description:        character.Description,
+		Avatar:             character.Avatar,
+		AudioExample:       character.AudioExample,
+		VoiceID:            character.VoiceID,
+		Visibility:         character.Visibility,
+		Hotwords:           character.Hotwords,
+		Tags:               lo.Ternary(character.Tags != nil, character.Tags, []string{}),
+		AllowFork:          character.AllowFork,
+		License:            character.License,
+		Speech:             character.Speech,
+	}
+}
+
+// Equal 判断两个快照的内容是否相同
+func (s Snapshot) Equal(other Snapshot) bool {
+	return reflect.DeepEqual(s.normalized(), other.normalized())
+}
+
+// normalized 把空列表统一为 nil，数据库中保存的空值和空列表视为相同
+func (s Snapshot) normalized() Snapshot {
+	s.Hotwords = lo.Ternary(len(s.Hotwords) > 0, s.Hotwords, nil)
+	s.AlternateGreetings = lo.Ternary(len(s.AlternateGreetings) > 0, s.AlternateGreetings, nil)
+	s.ExampleDialogues = lo.Ternary(len(s.ExampleDialogues) > 0, s.ExampleDialogues, nil)
+	s.Tags = lo.Ternary(len(s.Tags) > 0, s.Tags, nil)
+	return s
+}
+
+// patch 返回把角色恢复为快照内容的部分更新，热词和标签需要单独保存
+func (s Snapshot) patch() Patch {
+	return Patch{
+		Name:               Some(&s.Name),
+		Prompt:             Some(&s.Prompt),
+		Greeting:           Some(s.Greeting),
+		AlternateGreetings: Some(&s.AlternateGreetings),
+		ExampleDialogues:   Some(&s.ExampleDialogues),
+		Description:        Some(s.Description),
+		Avatar:             Some(s.Avatar),
+		AudioExample:       Some(s.AudioExample),
+		Visibility:         Some(&s.Visibility),
+		VoiceID:            Some(s.VoiceID),
+		AllowFork:          Some(&s.AllowFork),
+		License:            Some(s.License),
+		Speech:             Some(&s.Speech),
+	}
+}
+
+// sameHotwords 判断两个热词列表是否相同，nil 和空列表视为相同
+func sameHotwords(a, b []ai.Hotword) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
+
+// FieldChange 两个版本之间一个字段的变化
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+	// Diff 多行文本字段的逐行差异，删除的行以 "-" 开头，新增的行以 "+" 开头，未变的行以空格开头
+	Diff []string `json:"diff,omitempty"`
+}
+
+// VersionDiff 两个版本之间的差异
+type VersionDiff struct {
+	From    int32         `json:"from"`
+	To      int32         `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// Diff 比较两个版本，返回内容有变化的字段
+func Diff(from, to *Version) *VersionDiff {
+	diff := &VersionDiff{From: from.Version, To: to.Version, Changes: []FieldChange{}}
+	add := func(field string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field, From: a, To: b})
+		}
+	}
+	addText := func(field string, a, b string) {
+		if a != b {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field, From: a, To: b, Diff: diffLines(a, b)})
+		}
+	}
+
+	// 空列表统一为 nil，避免把 null 和空列表之间的变化当作修改
+	a, b := from.Snapshot.normalized(), to.Snapshot.normalized()
+	add("name", a.Name, b.Name)
+	addText("prompt", a.Prompt, b.Prompt)
+	addText("greeting", lo.FromPtr(a.Greeting), lo.FromPtr(b.Greeting))
+	add("alternate_greetings", a.AlternateGreetings, b.AlternateGreetings)
+	add("example_dialogues", a.ExampleDialogues, b.ExampleDialogues)
+	addText("description", lo.FromPtr(a.Description), lo.FromPtr(b.Description))
+	add("avatar", a.Avatar, b.Avatar)
+	add("audio_example", a.AudioExample, b.AudioExample)
+	add("voice_id", a.VoiceID, b.VoiceID)
+	add("visibility", a.Visibility, b.Visibility)
+	add("hotwords", a.Hotwords, b.Hotwords)
+	add("tags", a.Tags, b.Tags)
+	add("allow_fork", a.AllowFork, b.AllowFork)
+	add("license", a.License, b.License)
+	add("speech", a.Speech, b.Speech)
+	return diff
+}
+
+// diffLines 按最长公共子序列逐行比较两段文本
+func diffLines(a, b string) []string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+
+	// lcs[i][j] 为 x[i:] 和 y[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(x)+len(y))
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			lines = append(lines, " "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+x[i])
+			i++
+		default:
+			lines = append(lines, "+"+y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		lines = append(lines, "-"+x[i])
+	}
+	for ; j < len(y); j++ {
+		lines = append(lines, "+"+y[j])
+	}
+	return lines
+}
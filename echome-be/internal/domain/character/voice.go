@@ -0,0 +1,251 @@
+package character
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// voicePreviewTimeout 后台生成试听音频的超时时间
+const voicePreviewTimeout = time.Minute
+
+// SetVoice 把角色音色换成音色库中的音色，无需重新复刻
+func (s *CharacterService) SetVoice(ctx context.Context, id, voiceID uuid.UUID) (*Character, error) {
+	return s.UpdateCharacter(ctx, id, Patch{VoiceID: Some(&voiceID)})
+}
+
+// UpdateVoiceSample 用新的音频样本重新复刻角色使用的音色，用于审核未通过或复刻失败后重试
+// 音色ID不变，使用该音色的所有角色回到审核中，审核通过后重新生成试听音频
+func (s *CharacterService) UpdateVoiceSample(ctx context.Context, id uuid.UUID, audio string) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if character.VoiceID == nil {
+		return nil, ErrNoVoice
+	}
+	if _, err := s.voiceService.UpdateVoice(ctx, *character.VoiceID, voice.UpdateRequest{SampleURL: &audio}); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.GetByID(ctx, id)
+}
+
+// RecheckVoice 立即重新检查角色音色的审核状态
+func (s *CharacterService) RecheckVoice(ctx context.Context, id uuid.UUID) (*Character, error) {
+	character, err := s.editableCharacter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if character.VoiceID == nil {
+		return nil, ErrNoVoice
+	}
+	if _, err := s.voiceService.Recheck(ctx, *character.VoiceID); err != nil {
+		return nil, err
+	}
+	return s.characterRepo.GetByID(ctx, id)
+}
+
+// VoiceTTSConfig 返回角色音色的模型和音色参数，并应用角色的语音合成参数，角色未设置音色时使用默认音色
+func (s *CharacterService) VoiceTTSConfig(ctx context.Context, character *Character) (ai.TTSConfig, error) {
+	if character.VoiceID == nil {
+		return character.Speech.apply(ai.TTSConfig{}, false), nil
+	}
+	v, err := s.voiceService.GetVoice(ctx, *character.VoiceID)
+	if err != nil {
+		return ai.TTSConfig{}, err
+	}
+	return character.Speech.apply(v.TTSConfig(), v.Provider == voice.ProviderClone), nil
+}
+
+// GenerateVoicePreview 用角色音色合成试听台词，保存到文件存储并更新角色
+func (s *CharacterService) GenerateVoicePreview(ctx context.Context, character *Character) error {
+	ttsConfig, err := s.VoiceTTSConfig(ctx, character)
+	if err != nil {
+		return err
+	}
+	if ttsConfig.Voice == "" {
+		return ErrNoVoice
+	}
+	ttsConfig.Format = ai.AudioFormatMP3
+
+	var buf bytes.Buffer
+	if err := s.aiClient.HandleTTS(ctx, &buf, s.voicePreviewText(character), ttsConfig); err != nil {
+		return err
+	}
+	if buf.Len() == 0 {
+		return fmt.Errorf("TTS 未返回音频")
+	}
+
+	url, err := s.storage.Save(ctx, voicePreviewKey(character.ID), &buf)
+	if err != nil {
+		return err
+	}
+	if err := s.characterRepo.UpdateVoicePreview(ctx, character.ID, &url); err != nil {
+		return err
+	}
+	character.VoicePreviewURL = &url
+	return nil
+}
+
+// syncVoiceStatus 音色状态变化时同步使用该音色的角色的音色状态，禁用的角色保持不变
+func (s *CharacterService) syncVoiceStatus(ctx context.Context, v *voice.Voice) error {
+	characters, err := s.characterRepo.GetByVoiceID(ctx, v.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, character := range characters {
+		if character.Status == CharacterStatusDisabled {
+			continue
+		}
+		if character.VoiceStatus == v.Status && lo.FromPtr(voiceStatusReason(character)) == lo.FromPtr(v.StatusReason) {
+			continue
+		}
+		character.VoiceStatus = v.Status
+		refreshStatus(character, v.StatusReason)
+		character.UpdatedAt = time.Now()
+		if err := s.characterRepo.Update(ctx, character); err != nil {
+			return err
+		}
+
+		if v.Status == voice.StatusReady {
+			// 试听音频生成失败不影响审核结果
+			if err := s.GenerateVoicePreview(ctx, character); err != nil {
+				zap.L().Warn("生成音色试听失败", zap.String("characterID", character.ID.String()), zap.Error(err))
+			}
+		} else if err := s.clearVoicePreview(ctx, character); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usableVoice 获取可以分配给角色的音色，复刻失败或审核未通过的音色不可用
+// 只能使用模型自带音色和自己的复刻音色；inherited 为复制角色时沿用的来源角色音色，不检查所有者
+func (s *CharacterService) usableVoice(ctx context.Context, voiceID uuid.UUID, inherited bool) (*voice.Voice, error) {
+	get := s.voiceService.UsableVoice
+	if inherited {
+		get = s.voiceService.GetVoice
+	}
+	v, err := get(ctx, voiceID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status == voice.StatusFailed || v.Status == voice.StatusRejected {
+		return nil, voice.ErrVoiceUnavailable
+	}
+	return v, nil
+}
+
+// discardVoice 角色创建失败时删除随角色复刻的音色，失败时只记录日志
+func (s *CharacterService) discardVoice(ctx context.Context, v *voice.Voice) {
+	if v == nil {
+		return
+	}
+	if err := s.voiceService.DeleteVoice(ctx, v.ID); err != nil {
+		zap.L().Warn("删除音色失败", zap.String("voiceID", v.ID.String()), zap.Error(err))
+	}
+}
+
+// clearVoicePreview 删除角色的试听音频，音色变化后旧的试听不再适用
+func (s *CharacterService) clearVoicePreview(ctx context.Context, character *Character) error {
+	if character.VoicePreviewURL == nil {
+		return nil
+	}
+	if err := s.storage.Delete(ctx, voicePreviewKey(character.ID)); err != nil {
+		zap.L().Warn("删除音色试听失败", zap.String("characterID", character.ID.String()), zap.Error(err))
+	}
+	if err := s.characterRepo.UpdateVoicePreview(ctx, character.ID, nil); err != nil {
+		return err
+	}
+	character.VoicePreviewURL = nil
+	return nil
+}
+
+// generateVoicePreviewAsync 在后台生成试听音频，不阻塞请求
+func (s *CharacterService) generateVoicePreviewAsync(character *Character) {
+	c := *character
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), voicePreviewTimeout)
+		defer cancel()
+		if err := s.GenerateVoicePreview(ctx, &c); err != nil {
+			zap.L().Warn("生成音色试听失败", zap.String("characterID", c.ID.String()), zap.Error(err))
+		}
+	}()
+}
+
+// maxVoicePreviewGreetingLength 用开场白作为试听台词时截取的最大字数
+const maxVoicePreviewGreetingLength = 100
+
+// voicePreviewText 返回试听台词：优先使用角色的开场白，过长时截断；没有开场白时使用配置的台词，{name} 替换为角色名
+func (s *CharacterService) voicePreviewText(character *Character) string {
+	if greeting := strings.TrimSpace(lo.FromPtr(character.Greeting)); greeting != "" {
+		return lo.Substring(greeting, 0, maxVoicePreviewGreetingLength)
+	}
+	text := config.DefaultVoicePreviewText
+	if s.characterConfig != nil && strings.TrimSpace(s.characterConfig.VoicePreviewText) != "" {
+		text = s.characterConfig.VoicePreviewText
+	}
+	return strings.ReplaceAll(text, "{name}", character.Name)
+}
+
+// applyVoice 把音色设置到角色上，音色状态跟随音色，禁用的角色保持禁用
+func applyVoice(character *Character, v *voice.Voice) {
+	character.VoiceID = lo.ToPtr(v.ID)
+	character.Voice = lo.ToPtr(v.Voice)
+	character.Flag = v.Provider == voice.ProviderClone
+	character.VoiceStatus = v.Status
+	refreshStatus(character, v.StatusReason)
+}
+
+// clearVoice 角色改用默认音色，默认音色始终可用，禁用的角色保持禁用
+func clearVoice(character *Character) {
+	character.VoiceID = nil
+	character.Voice = nil
+	character.Flag = false
+	character.VoiceStatus = voice.StatusReady
+	refreshStatus(character, nil)
+}
+
+// refreshStatus 根据音色状态和内容审核状态计算角色状态，音色的问题优先，禁用的角色保持禁用
+// voiceReason 为音色复刻失败或审核未通过的原因
+func refreshStatus(character *Character, voiceReason *string) {
+	if character.Status == CharacterStatusDisabled {
+		return
+	}
+	status, reason := int32(CharacterStatusPending), (*string)(nil)
+	switch {
+	case character.VoiceStatus == voice.StatusFailed:
+		status, reason = CharacterStatusVoiceFailed, voiceReason
+	case character.VoiceStatus == voice.StatusRejected:
+		status, reason = CharacterStatusVoiceRejected, voiceReason
+	case character.VoiceStatus != voice.StatusReady:
+	case character.ReviewStatus == ReviewApproved:
+		status = CharacterStatusApproved
+	case character.ReviewStatus == ReviewRejected:
+		status, reason = CharacterStatusReviewRejected, character.ReviewReason
+	}
+	character.Status, character.StatusReason = status, reason
+}
+
+// voiceStatusReason 返回角色音色复刻失败或审核未通过的原因，音色没有问题时返回 nil
+func voiceStatusReason(character *Character) *string {
+	if character.VoiceStatus == voice.StatusFailed || character.VoiceStatus == voice.StatusRejected {
+		return character.StatusReason
+	}
+	return nil
+}
+
+// voicePreviewKey 试听音频在文件存储中的路径
+func voicePreviewKey(id uuid.UUID) string {
+	return "voice-previews/" + id.String() + ".mp3"
+}
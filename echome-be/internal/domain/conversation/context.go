@@ -0,0 +1,50 @@
+package conversation
+
+import (
+	"github.com/justin/echome-be/internal/domain/character"
+)
+
+// 对话示例前后的说明，让模型区分示例和真实对话
+const (
+	examplesStartNote = "以下是示范说话风格的对话示例，不是与用户的真实对话。"
+	examplesEndNote   = "示例结束，以下是与用户的真实对话。"
+)
+
+// characterPrelude 返回角色对话固定的开头：角色提示词、作为之前轮次的对话示例，以及连接时说出的开场白
+func characterPrelude(selected *character.Character, greeting string) []map[string]any {
+	prelude := []map[string]any{{"role": "system", "content": selected.Prompt}}
+	if len(selected.ExampleDialogues) > 0 {
+		prelude = append(prelude, map[string]any{"role": "system", "content": examplesStartNote})
+		for _, dialogue := range selected.ExampleDialogues {
+			for _, turn := range dialogue.Turns {
+				prelude = append(prelude, map[string]any{"role": turn.Role, "content": turn.Content})
+			}
+		}
+		prelude = append(prelude, map[string]any{"role": "system", "content": examplesEndNote})
+	}
+	if greeting != "" {
+		prelude = append(prelude, map[string]any{"role": character.RoleAssistant, "content": greeting})
+	}
+	return prelude
+}
+
+// buildMessages 在客户端发送的对话历史前加上角色对话的开头，没有角色时原样返回
+// 客户端开头的系统消息由角色提示词代替；客户端的历史已经以开场白开头时不再重复插入开场白
+func buildMessages(prelude, history []map[string]any) []map[string]any {
+	if len(prelude) == 0 {
+		return history
+	}
+
+	for len(history) > 0 && history[0]["role"] == "system" {
+		history = history[1:]
+	}
+	last := prelude[len(prelude)-1]
+	if len(history) > 0 && last["role"] == character.RoleAssistant &&
+		history[0]["role"] == character.RoleAssistant && history[0]["content"] == last["content"] {
+		history = history[1:]
+	}
+
+	messages := make([]map[string]any, 0, len(prelude)+len(history))
+	messages = append(messages, prelude...)
+	return append(messages, history...)
+}
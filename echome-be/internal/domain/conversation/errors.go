@@ -21,6 +21,7 @@ func (e *ConversationError) Error() string {
 // Error codes for conversation service
 const (
 	ErrCodeCharacterNotFound  = "CHARACTER_NOT_FOUND"
+	ErrCodeCharacterForbidden = "CHARACTER_FORBIDDEN"
 	ErrCodeSessionNotFound    = "SESSION_NOT_FOUND"
 	ErrCodeASRFailed          = "ASR_FAILED"
 	ErrCodeTTSFailed          = "TTS_FAILED"
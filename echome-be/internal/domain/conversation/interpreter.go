@@ -0,0 +1,231 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/ws"
+	"github.com/justin/echome-be/internal/infra/aliyun"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// interpreterLanguages 同声传译支持的语言，值为提示词中使用的语言名称
+var interpreterLanguages = map[string]string{
+	"zh": "中文",
+	"en": "英文",
+	"ja": "日文",
+	"ko": "韩文",
+	"fr": "法文",
+	"de": "德文",
+	"es": "西班牙文",
+	"ru": "俄文",
+}
+
+// 文字书写系统，用于根据识别文本判断语言方向
+const (
+	scriptUnknown = iota
+	scriptHan
+	scriptKana
+	scriptHangul
+	scriptCyrillic
+	scriptLatin
+)
+
+// languageScripts 各语言对应的书写系统
+var languageScripts = map[string]int{
+	"zh": scriptHan,
+	"en": scriptLatin,
+	"ja": scriptKana,
+	"ko": scriptHangul,
+	"fr": scriptLatin,
+	"de": scriptLatin,
+	"es": scriptLatin,
+	"ru": scriptCyrillic,
+}
+
+// interpreterPrompt 翻译提示词，要求模型只输出译文
+const interpreterPrompt = "你是一名专业的同声传译员。请把用户发来的%s内容准确翻译成%s。" +
+	"只输出译文本身，不要回答问题、不要解释、不要添加注释、引号或任何额外内容；" +
+	"保留原文的语气、数字和专有名词。如果原文已经是%s，原样输出。"
+
+// StartInterpreterSession 开始同声传译会话
+// 用户语音经 ASR 识别后由 LLM 翻译成目标语言，再通过 TTS 播报
+func (s *ConversationService) StartInterpreterSession(ctx context.Context, req *InterpreterRequest) error {
+	if _, ok := interpreterLanguages[req.SourceLang]; !ok {
+		return NewConversationError(ErrCodeInvalidInput, "不支持的源语言", req.SourceLang)
+	}
+	if _, ok := interpreterLanguages[req.TargetLang]; !ok {
+		return NewConversationError(ErrCodeInvalidInput, "不支持的目标语言", req.TargetLang)
+	}
+	if req.SourceLang == req.TargetLang {
+		return NewConversationError(ErrCodeInvalidInput, "源语言和目标语言不能相同", req.SourceLang)
+	}
+	if err := ai.ValidateTTSOutput(req.AudioFormat, req.SampleRate); err != nil {
+		return WrapError(ErrCodeInvalidInput, "音频输出参数无效", err)
+	}
+
+	sc := req.SafeConn
+	asrConfig := aliyun.DefaultASRConfig()
+	asrConfig.LanguageHints = []string{req.SourceLang, req.TargetLang}
+
+	// 识别出的完整句子按顺序逐句翻译，避免译文音频交叠
+	sentences := make(chan string, 16)
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer close(sentences)
+		return s.aiClient.RecognizeSpeech(ctx, sc, ai.AudioInput{}, asrConfig, func(result ai.ASRResult) error {
+			if !result.SentenceEnd {
+				return sc.WriteJSON(map[string]any{
+					"type": "source_partial",
+					"text": result.Text,
+				})
+			}
+			select {
+			case sentences <- result.Text:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	})
+
+	g.Go(func() error {
+		for text := range sentences {
+			if err := s.interpretSentence(ctx, sc, req, text); err != nil {
+				zap.L().Error("同声传译失败", zap.Error(err))
+				_ = sc.WriteJSON(map[string]any{
+					"type":    "error",
+					"message": "翻译失败: " + err.Error(),
+				})
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil && err != context.Canceled {
+		return err
+	}
+
+	_ = sc.WriteJSON(map[string]any{
+		"type":      "interpreter_finished",
+		"timestamp": time.Now(),
+	})
+	return nil
+}
+
+// interpretSentence 翻译并播报一句话，原文和译文分别作为独立事件发送
+func (s *ConversationService) interpretSentence(ctx context.Context, sc ws.WebSocketConn, req *InterpreterRequest, text string) error {
+	from, to := req.SourceLang, req.TargetLang
+	if req.AutoSwitch && detectDirectionReversed(text, from, to) {
+		from, to = to, from
+	}
+
+	if err := sc.WriteJSON(map[string]any{
+		"type":      "source_text",
+		"text":      text,
+		"lang":      from,
+		"timestamp": time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	msg := ai.DashScopeChatRequest{
+		Messages: []map[string]any{
+			{"role": "system", "content": fmt.Sprintf(interpreterPrompt, interpreterLanguages[from], interpreterLanguages[to], interpreterLanguages[to])},
+			{"role": "user", "content": text},
+		},
+	}
+
+	ttsConfig := ai.TTSConfig{
+		Voice:      req.Voice,
+		Format:     req.AudioFormat,
+		SampleRate: req.SampleRate,
+		Lang:       to,
+	}
+
+	ttsTextChan := make(chan string, 100)
+	var translation strings.Builder
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return s.aiClient.HandleStreamTTS(ctx, ws.NewAudioWriter(sc), ttsTextChan, ttsConfig)
+	})
+
+	g.Go(func() error {
+		defer close(ttsTextChan)
+		return s.aiClient.GenerateResponse(ctx, msg, func(chunk string) error {
+			if chunk == "" {
+				return nil
+			}
+			translation.WriteString(chunk)
+			select {
+			case ttsTextChan <- chunk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		})
+	})
+
+	if err := g.Wait(); err != nil {
+		return WrapError(ErrCodeAIGenerationFailed, "翻译失败", err)
+	}
+
+	return sc.WriteJSON(map[string]any{
+		"type":        "translation",
+		"text":        strings.TrimSpace(translation.String()),
+		"source_lang": from,
+		"target_lang": to,
+		"timestamp":   time.Now(),
+	})
+}
+
+// detectDirectionReversed 判断识别文本是否为目标语言，即需要反向翻译
+// 只有当文本的书写系统能唯一对应目标语言时才切换方向
+func detectDirectionReversed(text, source, target string) bool {
+	script := detectScript(text)
+	if script == scriptUnknown {
+		return false
+	}
+	return languageScripts[target] == script && languageScripts[source] != script
+}
+
+// detectScript 统计文本中各书写系统的字符数，返回占主导的书写系统
+func detectScript(text string) int {
+	counts := make(map[int]int)
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts[scriptKana]++
+		case unicode.Is(unicode.Han, r):
+			counts[scriptHan]++
+		case unicode.Is(unicode.Hangul, r):
+			counts[scriptHangul]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts[scriptCyrillic]++
+		case unicode.Is(unicode.Latin, r):
+			counts[scriptLatin]++
+		}
+	}
+
+	// 日文混用汉字和假名，出现假名即视为日文
+	if counts[scriptKana] > 0 {
+		return scriptKana
+	}
+
+	best, bestCount := scriptUnknown, 0
+	for script, count := range counts {
+		if count > bestCount || (count == bestCount && script < best) {
+			best, bestCount = script, count
+		}
+	}
+	return best
+}
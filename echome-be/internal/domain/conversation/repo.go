@@ -0,0 +1,11 @@
+package conversation
+
+import "context"
+
+// Repo 对话记录仓库接口
+type Repo interface {
+	// Start 保存新开始的对话并回填ID，有角色时累加角色的对话次数
+	Start(ctx context.Context, record *Record) error
+	// Finish 记录对话的结束时间和对话轮数
+	Finish(ctx context.Context, record *Record) error
+}
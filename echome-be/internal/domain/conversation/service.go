@@ -3,6 +3,7 @@ package conversation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net"
 	"time"
 
@@ -10,9 +11,10 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/justin/echome-be/config"
 	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/auth"
 	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/justin/echome-be/internal/domain/ws"
-	"github.com/justin/echome-be/internal/infra/aliyun"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -26,6 +28,7 @@ const (
 // ConversationService 会话服务实现
 type ConversationService struct {
 	aiClient         ai.Repo
+	conversationRepo Repo
 	characterService *character.CharacterService
 	tavilyConfig     *config.TavilyConfig
 }
@@ -33,34 +36,131 @@ type ConversationService struct {
 // NewConversationService 创建会话服务
 func NewConversationService(
 	aiClient ai.Repo,
+	conversationRepo Repo,
 	characterService *character.CharacterService,
 	tavilyConfig *config.TavilyConfig,
 ) *ConversationService {
 	return &ConversationService{
 		aiClient:         aiClient,
+		conversationRepo: conversationRepo,
 		characterService: characterService,
 		tavilyConfig:     tavilyConfig,
 	}
 }
 
 // StartVoiceConversation 开始语音会话
+// 指定角色时由服务端构建对话上下文，并在连接后用角色音色说出开场白
 func (s *ConversationService) StartVoiceConversation(ctx context.Context, req *VoiceConversationRequest) error {
-	var character *character.Character
-	var err error
+	if err := ai.ValidateTTSOutput(req.AudioFormat, req.SampleRate); err != nil {
+		return WrapError(ErrCodeInvalidInput, "音频输出参数无效", err)
+	}
+
+	var ttsConfig ai.TTSConfig
+	var selected *character.Character
+	if req.CharacterID != uuid.Nil {
+		// 只能使用当前用户可见且审核通过的角色，所有者可以使用自己尚未审核通过的角色
+		var err error
+		selected, err = s.characterService.UsableCharacter(ctx, req.CharacterID)
+		switch {
+		case errors.Is(err, character.ErrCharacterNotFound):
+			return WrapError(ErrCodeCharacterNotFound, "角色不存在", err)
+		case errors.Is(err, character.ErrCharacterUnavailable):
+			return WrapError(ErrCodeCharacterForbidden, "角色暂不可用", err)
+		case err != nil:
+			return WrapError(ErrCodeCharacterNotFound, "获取角色失败", err)
+		}
+
+		ttsConfig, err = s.characterService.VoiceTTSConfig(ctx, selected)
+		if err != nil {
+			// 音色获取失败时使用默认音色
+			zap.L().Warn("获取角色音色失败", zap.Error(err), zap.String("characterID", req.CharacterID.String()))
+		}
+	}
+	ttsConfig.Format = req.AudioFormat
+	ttsConfig.SampleRate = req.SampleRate
+
+	record := s.startRecord(ctx, selected)
+	if record != nil {
+		defer s.finishRecord(record)
+		_ = req.SafeConn.WriteJSON(map[string]any{
+			"type":              "conversation_started",
+			"conversation_id":   record.ID,
+			"character_version": record.CharacterVersion,
+		})
+	}
+
+	var prelude []map[string]any
+	if selected != nil {
+		greeting := selected.PickGreeting()
+		prelude = characterPrelude(selected, greeting)
+		if greeting != "" {
+			s.speakGreeting(ctx, req.SafeConn, greeting, ttsConfig)
+		}
+	}
+	return s.handleVoiceConversationFlow(ctx, req.SafeConn, prelude, ttsConfig, record)
+}
+
+// speakGreeting 发送开场白文本并用角色音色合成语音，合成失败不影响对话
+func (s *ConversationService) speakGreeting(ctx context.Context, sc ws.WebSocketConn, greeting string, ttsConfig ai.TTSConfig) {
+	_ = sc.WriteJSON(map[string]any{
+		"type":      "greeting",
+		"content":   greeting,
+		"timestamp": time.Now(),
+	})
+	if err := s.aiClient.HandleTTS(ctx, ws.NewAudioWriter(sc), greeting, ttsConfig); err != nil {
+		zap.L().Warn("开场白语音合成失败", zap.Error(err))
+	}
+	_ = sc.WriteJSON(map[string]any{
+		"type":      "greeting_end",
+		"timestamp": time.Now(),
+	})
+}
 
+// startRecord 记录对话开始及使用的角色版本，记录失败不影响对话，返回 nil
+func (s *ConversationService) startRecord(ctx context.Context, selected *character.Character) *Record {
+	record := &Record{StartedAt: time.Now()}
+	if selected != nil {
+		record.CharacterID = &selected.ID
+		record.CharacterVersion = &selected.Version
+	}
+	if user := auth.UserFrom(ctx); user != nil {
+		record.UserID = &user.ID
+	}
+	if err := s.conversationRepo.Start(ctx, record); err != nil {
+		zap.L().Warn("保存对话记录失败", zap.Error(err))
+		return nil
+	}
+	return record
+}
+
+// finishRecord 记录对话结束时间和对话轮数，对话结束时请求的 context 通常已取消，因此使用独立的 context
+func (s *ConversationService) finishRecord(record *Record) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	record.EndedAt = lo.ToPtr(time.Now())
+	if err := s.conversationRepo.Finish(ctx, record); err != nil {
+		zap.L().Warn("更新对话记录失败", zap.String("conversationID", record.ID.String()), zap.Error(err))
+	}
+}
+
+// StartASRSession 开始语音识别会话，指定角色且角色配置了热词时使用其热词表
+func (s *ConversationService) StartASRSession(ctx context.Context, req *ASRRequest) error {
+	var config ai.ASRConfig
 	if req.CharacterID != uuid.Nil {
-		character, err = s.characterService.GetCharacterByID(ctx, req.CharacterID)
+		character, err := s.characterService.GetCharacterByID(ctx, req.CharacterID)
 		if err != nil {
+			// 获取角色失败时仍然可以不带热词识别
 			zap.L().Warn("获取角色失败", zap.Error(err), zap.String("characterID", req.CharacterID.String()))
-			// 获取角色失败不应中断流程，可以继续无角色的对话
-			character = nil
+		} else if character.VocabularyID != nil {
+			config.VocabularyID = *character.VocabularyID
 		}
 	}
-	return s.handleVoiceConversationFlow(ctx, req.SafeConn, character)
+	return s.aiClient.HandleASR(ctx, req.SafeConn, req.Input, config)
 }
 
-// handleVoiceConversationFlow 处理语音对话流程
-func (s *ConversationService) handleVoiceConversationFlow(ctx context.Context, sc ws.WebSocketConn, character *character.Character) error {
+// handleVoiceConversationFlow 处理语音对话流程，prelude 为角色对话的开头，没有角色时为空
+// record 不为空时累计对话轮数，返回前所有轮次都已处理完
+func (s *ConversationService) handleVoiceConversationFlow(ctx context.Context, sc ws.WebSocketConn, prelude []map[string]any, ttsConfig ai.TTSConfig, record *Record) error {
 	// 使用 WithCancel 创建可以被 errgroup 控制的上下文
 	g, ctx := errgroup.WithContext(ctx)
 	defer func() {
@@ -102,6 +202,7 @@ func (s *ConversationService) handleVoiceConversationFlow(ctx context.Context, s
 				continue
 			}
 
+			msg.Messages = buildMessages(prelude, msg.Messages)
 			if msg.EnableSearch {
 				if len(msg.Messages) > 0 {
 					lastMessage := msg.Messages[len(msg.Messages)-1]
@@ -116,10 +217,13 @@ func (s *ConversationService) handleVoiceConversationFlow(ctx context.Context, s
 				}
 			}
 
-			if err := s.handleStreamingConversation(ctx, sc, msg, character); err != nil {
+			if err := s.handleStreamingConversation(ctx, sc, msg, ttsConfig); err != nil {
 				zap.L().Error("流式对话处理失败", zap.Error(err))
 				continue
 			}
+			if record != nil {
+				record.Turns++
+			}
 		}
 	})
 
@@ -131,22 +235,17 @@ func (s *ConversationService) handleStreamingConversation(
 	ctx context.Context,
 	sc ws.WebSocketConn,
 	msg ai.DashScopeChatRequest,
-	character *character.Character, // 传入整个 character 对象以获取语音信息
+	ttsConfig ai.TTSConfig,
 ) error {
 	_ = sc.WriteJSON(map[string]any{"type": "stream_start", "timestamp": time.Now()})
 
 	llmTextChan := make(chan string, 100) // Buffered channel for LLM text chunks
 
-	ttsConfig := aliyun.DefaultTTSConfig()
-	if character != nil && character.Flag && character.Voice != nil {
-		ttsConfig.Voice = *character.Voice
-	}
-
 	g, ctx := errgroup.WithContext(ctx)
 
 	// Goroutine 1: 处理TTS流
 	g.Go(func() error {
-		return s.aiClient.HandleCosyVoiceTTS(ctx, sc, llmTextChan, ttsConfig)
+		return s.aiClient.HandleStreamTTS(ctx, ws.NewAudioWriter(sc), llmTextChan, ttsConfig)
 	})
 
 	// Goroutine 2: 生成LLM响应并发送到channel
@@ -1,16 +1,57 @@
 package conversation
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/justin/echome-be/internal/domain/ai"
 	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/justin/echome-be/internal/domain/ws"
 )
 
+// Record 一次语音对话的记录，保存对话使用的角色版本，以便把对话质量的变化追溯到角色修改
+type Record struct {
+	ID uuid.UUID `json:"id"`
+	// CharacterID 对话的角色，为空时是无角色的对话
+	CharacterID *uuid.UUID `json:"character_id"`
+	// CharacterVersion 对话开始时角色的版本号
+	CharacterVersion *int32 `json:"character_version"`
+	// UserID 发起对话的用户ID
+	UserID    *string    `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	// Turns 对话轮数，用户每发送一条消息并得到回复算一轮
+	Turns int32 `json:"turns"`
+}
+
 // VoiceConversationRequest 语音对话请求
 type VoiceConversationRequest struct {
 	SafeConn    ws.WebSocketConn `json:"-"`
 	CharacterID uuid.UUID        `json:"character_id"`
+	// AudioFormat 客户端期望的音频格式: pcm / wav / mp3 / opus，为空时使用服务默认值
+	AudioFormat string `json:"audio_format"`
+	// SampleRate 客户端期望的采样率，为 0 时使用服务默认值
+	SampleRate int `json:"sample_rate"`
+}
+
+// ASRRequest 语音识别会话请求
+type ASRRequest struct {
+	SafeConn ws.WebSocketConn `json:"-"`
+	// CharacterID 指定角色时使用角色的热词表
+	CharacterID uuid.UUID     `json:"character_id"`
+	Input       ai.AudioInput `json:"input"`
+}
+
+// InterpreterRequest 同声传译会话请求
+type InterpreterRequest struct {
+	SafeConn   ws.WebSocketConn `json:"-"`
+	SourceLang string           `json:"source_lang"`
+	TargetLang string           `json:"target_lang"`
+	// AutoSwitch 根据识别出的语言自动切换翻译方向
+	AutoSwitch  bool   `json:"auto_switch"`
+	Voice       string `json:"voice"`
+	AudioFormat string `json:"audio_format"`
+	SampleRate  int    `json:"sample_rate"`
 }
 
 // VoiceConfig 角色的语音配置
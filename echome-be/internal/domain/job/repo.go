@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrJobNotFound 任务不存在
+var ErrJobNotFound = errors.New("job not found")
+
+// Repo 任务仓库接口
+type Repo interface {
+	Create(ctx context.Context, job *Job) error
+	Update(ctx context.Context, job *Job) error
+	// FindPending 查找对象上未结束的任务，不存在时返回 ErrJobNotFound
+	FindPending(ctx context.Context, jobType, refID string) (*Job, error)
+	// Due 按执行时间顺序返回到期的待执行任务
+	Due(ctx context.Context, now time.Time, limit int) ([]*Job, error)
+	// Claim 把任务的下次执行时间从 nextRunAt 推迟到 until，防止多个实例重复执行
+	// 任务已被其他实例领取时返回 false
+	Claim(ctx context.Context, id uuid.UUID, nextRunAt, until time.Time) (bool, error)
+}
@@ -0,0 +1,199 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// pollInterval 查询到期任务的间隔
+	pollInterval = 5 * time.Second
+	// batchSize 每次最多领取的任务数
+	batchSize = 20
+	// leaseDuration 任务执行期间的租约，实例崩溃后任务在租约到期后重新执行
+	leaseDuration = 5 * time.Minute
+	// runTimeout 单次执行的超时时间
+	runTimeout = time.Minute
+)
+
+// Scheduler 持久化任务调度器
+// 任务保存在仓库中，服务重启后继续执行；每个任务按处理器的策略指数退避重试
+type Scheduler struct {
+	repo     Repo
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wake     chan struct{}
+}
+
+// NewScheduler 创建任务调度器
+func NewScheduler(repo Repo) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register 注册任务类型的处理器
+func (s *Scheduler) Register(jobType string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+// Enqueue 为对象创建任务并立即执行，对象已有未结束的任务时直接返回该任务
+func (s *Scheduler) Enqueue(ctx context.Context, jobType, refID string) (*Job, error) {
+	job, err := s.repo.FindPending(ctx, jobType, refID)
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, ErrJobNotFound) {
+		return nil, err
+	}
+	return s.create(ctx, jobType, refID, time.Now())
+}
+
+// Restart 重新开始对象的任务：已有未结束的任务时重置次数和存活时间并立即执行，否则创建新任务
+func (s *Scheduler) Restart(ctx context.Context, jobType, refID string) (*Job, error) {
+	return s.Schedule(ctx, jobType, refID, time.Now())
+}
+
+// Schedule 安排对象的任务在 runAt 执行：已有未结束的任务时重置次数和存活时间并改到 runAt 执行，否则创建新任务
+func (s *Scheduler) Schedule(ctx context.Context, jobType, refID string, runAt time.Time) (*Job, error) {
+	job, err := s.repo.FindPending(ctx, jobType, refID)
+	if errors.Is(err, ErrJobNotFound) {
+		return s.create(ctx, jobType, refID, runAt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	job.Attempts = 0
+	job.NextRunAt = runAt
+	job.LastError = nil
+	job.CreatedAt = time.Now()
+	if err := s.repo.Update(ctx, job); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return job, nil
+}
+
+// create 创建在 runAt 执行的任务
+func (s *Scheduler) create(ctx context.Context, jobType, refID string, runAt time.Time) (*Job, error) {
+	job := &Job{
+		Type:      jobType,
+		RefID:     refID,
+		Status:    StatusPending,
+		NextRunAt: runAt,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return job, nil
+}
+
+// notify 唤醒调度循环
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 执行调度循环，直到 ctx 取消
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+		select {
+		case <-ctx.Done():
+			zap.L().Info("任务调度器已停止")
+			return nil
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// runDue 领取并执行到期的任务
+func (s *Scheduler) runDue(ctx context.Context) {
+	jobs, err := s.repo.Due(ctx, time.Now(), batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			zap.L().Error("查询到期任务失败", zap.Error(err))
+		}
+		return
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		claimed, err := s.repo.Claim(ctx, job.ID, job.NextRunAt, time.Now().Add(leaseDuration))
+		if err != nil {
+			zap.L().Error("领取任务失败", zap.String("jobID", job.ID.String()), zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		s.execute(ctx, job)
+	}
+}
+
+// execute 执行任务并按结果更新任务状态
+func (s *Scheduler) execute(ctx context.Context, job *Job) {
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+
+	var done bool
+	var err error
+	if ok {
+		runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+		done, err = handler.Run(runCtx, job)
+		cancel()
+	} else {
+		err = fmt.Errorf("未注册的任务类型: %s", job.Type)
+	}
+	if ctx.Err() != nil {
+		// 服务关闭，租约到期后重新执行
+		return
+	}
+
+	job.Attempts++
+	job.LastError = nil
+	if err != nil {
+		message := err.Error()
+		job.LastError = &message
+		zap.L().Warn("任务执行失败", zap.String("type", job.Type), zap.String("refID", job.RefID), zap.Int("attempts", job.Attempts), zap.Error(err))
+	}
+
+	switch {
+	case done:
+		job.Status = StatusSucceeded
+	case !ok || time.Since(job.CreatedAt) > handler.Policy.MaxAge:
+		job.Status = StatusFailed
+		if ok && handler.Expire != nil {
+			if err := handler.Expire(ctx, job); err != nil {
+				zap.L().Error("处理过期任务失败", zap.String("type", job.Type), zap.String("refID", job.RefID), zap.Error(err))
+			}
+		}
+	default:
+		job.NextRunAt = time.Now().Add(handler.Policy.Backoff(job.Attempts))
+	}
+
+	if err := s.repo.Update(ctx, job); err != nil {
+		zap.L().Error("更新任务失败", zap.String("jobID", job.ID.String()), zap.Error(err))
+	}
+}
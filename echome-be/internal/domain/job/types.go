@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status 任务状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Job 持久化的后台任务，按 Type 分派给注册的处理器
+type Job struct {
+	ID   uuid.UUID `json:"id"`
+	Type string    `json:"type"`
+	// RefID 任务处理的对象ID，如角色ID
+	RefID  string `json:"ref_id"`
+	Status Status `json:"status"`
+	// Attempts 已执行次数
+	Attempts  int       `json:"attempts"`
+	NextRunAt time.Time `json:"next_run_at"`
+	// LastError 最近一次执行的错误
+	LastError *string   `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Policy 任务的重试策略
+type Policy struct {
+	// BaseDelay 首次重试的间隔，之后每次翻倍
+	BaseDelay time.Duration
+	// MaxDelay 重试间隔上限
+	MaxDelay time.Duration
+	// MaxAge 任务从创建起的最长存活时间，超过后任务失败
+	MaxAge time.Duration
+}
+
+// Backoff 返回第 attempts 次执行后的重试间隔
+func (p Policy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// Handler 任务处理器
+type Handler struct {
+	Policy Policy
+	// Run 执行任务，返回 done 为 true 时任务完成，否则按策略稍后重试
+	Run func(ctx context.Context, job *Job) (done bool, err error)
+	// Expire 任务超过 MaxAge 仍未完成时调用，可为空
+	Expire func(ctx context.Context, job *Job) error
+}
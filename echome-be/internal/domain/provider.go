@@ -4,9 +4,17 @@ import (
 	"github.com/google/wire"
 	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/justin/echome-be/internal/domain/conversation"
+	"github.com/justin/echome-be/internal/domain/job"
+	"github.com/justin/echome-be/internal/domain/speech"
+	"github.com/justin/echome-be/internal/domain/transcription"
+	"github.com/justin/echome-be/internal/domain/voice"
 )
 
 var ServiceProviderSet = wire.NewSet(
 	character.NewCharacterService,
 	conversation.NewConversationService,
+	speech.NewSpeechService,
+	transcription.NewTranscriptionService,
+	voice.NewVoiceService,
+	job.NewScheduler,
 )
@@ -16,6 +16,8 @@ type APIError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// Issues 校验失败的具体问题，供客户端逐条提示
+	Issues any `json:"issues,omitempty"`
 }
 
 // Success 返回成功响应
@@ -36,6 +38,15 @@ func Created(c echo.Context, data any) error {
 	return c.JSON(201, response)
 }
 
+// Accepted 返回202已受理响应，用于异步任务
+func Accepted(c echo.Context, data any) error {
+	response := APIResponse{
+		Success: true,
+		Data:    data,
+	}
+	return c.JSON(202, response)
+}
+
 // Error 返回错误响应
 func Error(c echo.Context, statusCode int, code, message string, details ...string) error {
 	apiError := &APIError{
@@ -60,6 +71,32 @@ func BadRequest(c echo.Context, message string, details ...string) error {
 	return Error(c, 400, "BAD_REQUEST", message, details...)
 }
 
+// ValidationFailed 返回400错误，并附带逐条的校验问题
+func ValidationFailed(c echo.Context, message string, issues any, details ...string) error {
+	apiError := &APIError{
+		Code:    "VALIDATION_FAILED",
+		Message: message,
+		Issues:  issues,
+	}
+	if len(details) > 0 {
+		apiError.Details = details[0]
+	}
+	return c.JSON(400, APIResponse{
+		Success: false,
+		Error:   apiError,
+	})
+}
+
+// Unauthorized 返回401错误
+func Unauthorized(c echo.Context, message string, details ...string) error {
+	return Error(c, 401, "UNAUTHORIZED", message, details...)
+}
+
+// Forbidden 返回403错误
+func Forbidden(c echo.Context, message string, details ...string) error {
+	return Error(c, 403, "FORBIDDEN", message, details...)
+}
+
 // NotFound 返回404错误
 func NotFound(c echo.Context, message string, details ...string) error {
 	return Error(c, 404, "NOT_FOUND", message, details...)
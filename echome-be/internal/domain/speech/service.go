@@ -0,0 +1,113 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/samber/lo"
+)
+
+// SpeechService 文本转语音服务，用于通知、试听和离线内容生成
+type SpeechService struct {
+	aiClient         ai.Repo
+	characterService *character.CharacterService
+	voiceService     *voice.VoiceService
+}
+
+// NewSpeechService 创建文本转语音服务
+func NewSpeechService(aiClient ai.Repo, characterService *character.CharacterService, voiceService *voice.VoiceService) *SpeechService {
+	return &SpeechService{
+		aiClient:         aiClient,
+		characterService: characterService,
+		voiceService:     voiceService,
+	}
+}
+
+// prepare 校验请求并解析出 TTS 参数
+// 角色不存在时返回 character.ErrCharacterNotFound，音色不存在时返回 voice.ErrVoiceNotFound
+func (s *SpeechService) prepare(ctx context.Context, req *SynthesisRequest) (ai.TTSConfig, error) {
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		return ai.TTSConfig{}, ErrEmptyText
+	}
+	if utf8.RuneCountInString(req.Text) > MaxTextLength {
+		return ai.TTSConfig{}, ErrTextTooLong
+	}
+	if lo.Count([]bool{req.CharacterID != uuid.Nil, req.VoiceID != uuid.Nil, req.Voice != ""}, true) > 1 {
+		return ai.TTSConfig{}, ErrVoiceConflict
+	}
+	if req.Format == "" {
+		req.Format = DefaultFormat
+	}
+	if err := ai.ValidateTTSOutput(req.Format, req.SampleRate); err != nil {
+		return ai.TTSConfig{}, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+	if err := ai.ValidateTTSRate(req.Speed); err != nil {
+		return ai.TTSConfig{}, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+
+	config := ai.TTSConfig{
+		Voice:      req.Voice,
+		Format:     req.Format,
+		SampleRate: req.SampleRate,
+		Rate:       req.Speed,
+	}
+	if req.VoiceID != uuid.Nil {
+		v, err := s.voiceService.UsableVoice(ctx, req.VoiceID)
+		if err != nil {
+			return ai.TTSConfig{}, err
+		}
+		config.Model = v.Model
+		config.Voice = v.Voice
+	}
+	if req.CharacterID != uuid.Nil {
+		c, err := s.characterService.UsableCharacter(ctx, req.CharacterID)
+		if err != nil {
+			return ai.TTSConfig{}, err
+		}
+		voiceConfig, err := s.characterService.VoiceTTSConfig(ctx, c)
+		if err != nil {
+			return ai.TTSConfig{}, err
+		}
+		// 使用角色的音色和语音合成参数，请求中指定的语速优先
+		config.Model, config.Voice = voiceConfig.Model, voiceConfig.Voice
+		config.Pitch, config.Volume = voiceConfig.Pitch, voiceConfig.Volume
+		if config.Rate == 0 {
+			config.Rate = voiceConfig.Rate
+		}
+	}
+	return config, nil
+}
+
+// Stream 合成语音并边合成边写入 w，参数校验失败时不会写入任何数据
+func (s *SpeechService) Stream(ctx context.Context, w io.Writer, req *SynthesisRequest) error {
+	config, err := s.prepare(ctx, req)
+	if err != nil {
+		return err
+	}
+	return s.aiClient.HandleTTS(ctx, w, req.Text, config)
+}
+
+// Synthesize 合成完整的音频文件，wav 格式会回填文件头中的长度
+func (s *SpeechService) Synthesize(ctx context.Context, req *SynthesisRequest) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.Stream(ctx, &buf, req); err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 {
+		return nil, fmt.Errorf("TTS 未返回音频")
+	}
+	data := buf.Bytes()
+	if req.Format == ai.AudioFormatWAV {
+		data = finalizeWAV(data)
+	}
+	return data, nil
+}
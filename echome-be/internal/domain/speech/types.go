@@ -0,0 +1,65 @@
+package speech
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+// MaxTextLength 单次合成的最大字数，CosyVoice 单次 continue-task 的文本上限
+const MaxTextLength = 2000
+
+// DefaultFormat HTTP 合成默认输出格式
+const DefaultFormat = ai.AudioFormatMP3
+
+var (
+	// ErrEmptyText 待合成文本为空
+	ErrEmptyText = errors.New("text is required")
+	// ErrTextTooLong 待合成文本超过上限
+	ErrTextTooLong = errors.New("text exceeds 2000 characters")
+	// ErrVoiceConflict 同时指定了角色、音色库音色或模型音色中的多个
+	ErrVoiceConflict = errors.New("character_id, voice_id and voice are mutually exclusive")
+	// ErrInvalidOutput 输出格式、采样率或语速不受支持
+	ErrInvalidOutput = errors.New("invalid audio output")
+)
+
+// SynthesisRequest 文本合成请求
+type SynthesisRequest struct {
+	Text string
+	// CharacterID 使用角色的音色，与 VoiceID、Voice 三选一，都为空时使用默认音色
+	CharacterID uuid.UUID
+	// VoiceID 使用音色库中的音色
+	VoiceID uuid.UUID
+	// Voice 模型自带的音色名
+	Voice      string
+	Format     string
+	SampleRate int
+	// Speed 语速，取值 0.5~2.0，0 表示默认语速，使用角色时为角色的语速
+	Speed float64
+}
+
+// ContentType 返回音频格式对应的 MIME 类型
+func ContentType(format string) string {
+	switch format {
+	case ai.AudioFormatWAV:
+		return "audio/wav"
+	case ai.AudioFormatMP3:
+		return "audio/mpeg"
+	case ai.AudioFormatOpus:
+		return "audio/ogg"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// finalizeWAV 用实际长度回填流式 WAV 文件头中的占位长度
+func finalizeWAV(data []byte) []byte {
+	if len(data) < 44 || string(data[0:4]) != "RIFF" || string(data[36:40]) != "data" {
+		return data
+	}
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+	binary.LittleEndian.PutUint32(data[40:44], uint32(len(data)-44))
+	return data
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound 文件不存在，或URL不是由本存储保存的文件
+var ErrNotFound = errors.New("file not found in storage")
+
+// Repo 文件存储接口
+type Repo interface {
+	// Save 保存文件，返回文件的访问URL
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+	Delete(ctx context.Context, key string) error
+	// Open 打开 Save 返回的URL对应的文件，其他URL返回 ErrNotFound
+	Open(ctx context.Context, url string) (io.ReadCloser, error)
+	// Public 返回的URL能否被阿里云等外部服务访问
+	Public() bool
+}
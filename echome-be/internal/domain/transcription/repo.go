@@ -0,0 +1,17 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrJobNotFound 转写任务不存在或已过期
+var ErrJobNotFound = errors.New("transcription job not found")
+
+// Repo 转写任务仓库接口
+type Repo interface {
+	Save(ctx context.Context, job *Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+}
@@ -0,0 +1,165 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+// jobTimeout 单个转写任务的最长执行时间
+const jobTimeout = time.Hour
+
+var (
+	// ErrInvalidURL 音频地址不是有效的 http/https URL
+	ErrInvalidURL = errors.New("audio url must be an absolute http or https url")
+	// ErrUnsupportedFormat 不支持的音频格式
+	ErrUnsupportedFormat = errors.New("unsupported audio format, supported: wav, mp3, m4a")
+	// ErrPublicStorageRequired m4a 需要通过录音文件识别转写，要求文件能被阿里云下载
+	ErrPublicStorageRequired = errors.New("m4a uploads require storage.public_base_url to be configured")
+)
+
+// TranscriptionService 文件转写服务
+type TranscriptionService struct {
+	repo     Repo
+	aiClient ai.Repo
+	storage  storage.Repo
+}
+
+// NewTranscriptionService 创建文件转写服务
+func NewTranscriptionService(repo Repo, aiClient ai.Repo, storage storage.Repo) *TranscriptionService {
+	return &TranscriptionService{
+		repo:     repo,
+		aiClient: aiClient,
+		storage:  storage,
+	}
+}
+
+// GetJob 获取转写任务
+func (s *TranscriptionService) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// SubmitURL 提交可公开访问的音频URL，使用录音文件识别异步转写
+func (s *TranscriptionService) SubmitURL(ctx context.Context, fileURL string) (*Job, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	job := newJob(SourceURL)
+	job.FileURL = fileURL
+	return s.start(ctx, job, func(ctx context.Context) (*ai.Transcript, error) {
+		return s.aiClient.TranscribeURL(ctx, fileURL)
+	})
+}
+
+// SubmitFile 提交上传的音频文件，path 为临时文件路径，其所有权转交给服务，任务结束后删除
+// 存储可以公开访问时走录音文件识别，否则 wav/mp3 分块送入实时识别
+func (s *TranscriptionService) SubmitFile(ctx context.Context, fileName, format, path string) (*Job, error) {
+	format = strings.ToLower(format)
+	var err error
+	switch {
+	case format != FormatWAV && format != FormatMP3 && format != FormatM4A:
+		err = ErrUnsupportedFormat
+	case format == FormatM4A && !s.storage.Public():
+		err = ErrPublicStorageRequired
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	job := newJob(SourceUpload)
+	job.FileName = fileName
+	job.Format = format
+	created, err := s.start(ctx, job, func(ctx context.Context) (*ai.Transcript, error) {
+		defer os.Remove(path)
+		return s.transcribeFile(ctx, job.ID, format, path)
+	})
+	if err != nil {
+		os.Remove(path)
+	}
+	return created, err
+}
+
+// transcribeFile 转写本地音频文件
+func (s *TranscriptionService) transcribeFile(ctx context.Context, id uuid.UUID, format, path string) (*ai.Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if !s.storage.Public() {
+		return s.aiClient.TranscribeAudio(ctx, f, format)
+	}
+
+	key := "transcriptions/" + id.String() + "." + format
+	fileURL, err := s.storage.Save(ctx, key, f)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := s.storage.Delete(context.Background(), key); err != nil {
+			zap.L().Warn("删除转写音频失败", zap.String("key", key), zap.Error(err))
+		}
+	}()
+	return s.aiClient.TranscribeURL(ctx, fileURL)
+}
+
+// start 保存任务并在后台执行，返回任务提交时的快照
+func (s *TranscriptionService) start(ctx context.Context, job *Job, transcribe func(ctx context.Context) (*ai.Transcript, error)) (*Job, error) {
+	if err := s.repo.Save(ctx, job); err != nil {
+		return nil, err
+	}
+	created := *job
+	go s.run(job, transcribe)
+	return &created, nil
+}
+
+// run 执行转写并更新任务状态
+func (s *TranscriptionService) run(job *Job, transcribe func(ctx context.Context) (*ai.Transcript, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	job.Status = StatusRunning
+	job.UpdatedAt = time.Now()
+	if err := s.repo.Save(ctx, job); err != nil {
+		zap.L().Warn("更新转写任务失败", zap.String("jobID", job.ID.String()), zap.Error(err))
+	}
+
+	result, err := transcribe(ctx)
+	now := time.Now()
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if err != nil {
+		zap.L().Error("转写任务失败", zap.String("jobID", job.ID.String()), zap.Error(err))
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = StatusSucceeded
+		job.Result = result
+	}
+	if err := s.repo.Save(context.Background(), job); err != nil {
+		zap.L().Warn("更新转写任务失败", zap.String("jobID", job.ID.String()), zap.Error(err))
+	}
+}
+
+func newJob(source string) *Job {
+	now := time.Now()
+	return &Job{
+		ID:        uuid.New(),
+		Status:    StatusPending,
+		Source:    source,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,41 @@
+package transcription
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+// 字幕格式
+const (
+	SubtitleSRT = "srt"
+	SubtitleVTT = "vtt"
+)
+
+// FormatSRT 把句子导出为 SRT 字幕
+func FormatSRT(sentences []ai.TranscriptSentence) string {
+	var sb strings.Builder
+	for i, sentence := range sentences {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1, subtitleTime(sentence.BeginTime, ","), subtitleTime(sentence.EndTime, ","), strings.TrimSpace(sentence.Text))
+	}
+	return sb.String()
+}
+
+// FormatVTT 把句子导出为 WebVTT 字幕
+func FormatVTT(sentences []ai.TranscriptSentence) string {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+	for _, sentence := range sentences {
+		fmt.Fprintf(&sb, "%s --> %s\n%s\n\n",
+			subtitleTime(sentence.BeginTime, "."), subtitleTime(sentence.EndTime, "."), strings.TrimSpace(sentence.Text))
+	}
+	return sb.String()
+}
+
+// subtitleTime 把毫秒格式化为 HH:MM:SS,mmm，SRT 与 VTT 只有毫秒分隔符不同
+func subtitleTime(ms int, sep string) string {
+	ms = max(ms, 0)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
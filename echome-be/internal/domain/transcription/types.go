@@ -0,0 +1,56 @@
+package transcription
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+// Status 转写任务状态
+type Status string
+
+// 转写任务状态枚举定义
+const (
+	StatusPending   Status = "pending"   // 排队中
+	StatusRunning   Status = "running"   // 转写中
+	StatusSucceeded Status = "succeeded" // 已完成
+	StatusFailed    Status = "failed"    // 失败
+)
+
+// 音频来源
+const (
+	SourceURL    = "url"
+	SourceUpload = "upload"
+)
+
+// 支持上传的音频格式
+const (
+	FormatWAV = "wav"
+	FormatMP3 = "mp3"
+	FormatM4A = "m4a"
+)
+
+// Job 文件转写任务
+type Job struct {
+	ID     uuid.UUID `json:"id"`
+	Status Status    `json:"status"`
+	// Source 音频来源: url / upload
+	Source string `json:"source"`
+	// FileURL 来源为 url 时的音频地址
+	FileURL string `json:"file_url,omitempty"`
+	// FileName 来源为 upload 时的原始文件名
+	FileName string `json:"file_name,omitempty"`
+	Format   string `json:"format,omitempty"`
+	// Error 任务失败原因
+	Error      string         `json:"error,omitempty"`
+	Result     *ai.Transcript `json:"result,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// Finished 任务是否已经结束
+func (j *Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
@@ -0,0 +1,46 @@
+package voice
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// CanUse 判断用户能否使用音色，模型自带音色所有人可用，复刻音色只有所有者可用
+func CanUse(user *auth.User, voice *Voice) bool {
+	return voice.Provider == ProviderBuiltin || user.IsOwner(voice.OwnerID)
+}
+
+// CanEdit 判断用户能否修改、删除或重新检查音色
+func CanEdit(user *auth.User, voice *Voice) bool {
+	return user.IsOwner(voice.OwnerID)
+}
+
+// UsableVoice 获取当前用户可以使用的音色，其他用户的复刻音色按不存在处理，避免泄露音色样本
+func (s *VoiceService) UsableVoice(ctx context.Context, id uuid.UUID) (*Voice, error) {
+	voice, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CanUse(auth.UserFrom(ctx), voice) {
+		return nil, ErrVoiceNotFound
+	}
+	return voice, nil
+}
+
+// editableVoice 获取当前用户可以修改的音色，匿名用户返回 auth.ErrUnauthenticated
+func (s *VoiceService) editableVoice(ctx context.Context, id uuid.UUID) (*Voice, error) {
+	voice, err := s.UsableVoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user := auth.UserFrom(ctx)
+	switch {
+	case user == nil:
+		return nil, auth.ErrUnauthenticated
+	case !CanEdit(user, voice):
+		return nil, auth.ErrForbidden
+	}
+	return voice, nil
+}
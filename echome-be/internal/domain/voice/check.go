@@ -0,0 +1,137 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/job"
+	"go.uber.org/zap"
+)
+
+// JobTypeVoiceCheck 检查复刻音色审核状态的任务
+const JobTypeVoiceCheck = "voice_check"
+
+// voiceCheckPolicy 音色审核通常几分钟内完成，超过一天仍未完成视为复刻失败
+var voiceCheckPolicy = job.Policy{
+	BaseDelay: 30 * time.Second,
+	MaxDelay:  30 * time.Minute,
+	MaxAge:    24 * time.Hour,
+}
+
+// ErrVoiceAlreadyReady 音色已审核通过，无需重新检查
+var ErrVoiceAlreadyReady = errors.New("voice is already approved")
+
+// SchedulePendingChecks 为审核中但没有检查任务的音色创建任务，用于服务启动和旧数据迁移
+func (s *VoiceService) SchedulePendingChecks(ctx context.Context) error {
+	voices, err := s.repo.GetByStatus(ctx, StatusPending)
+	if err != nil {
+		return err
+	}
+	for _, voice := range voices {
+		if voice.Provider != ProviderClone {
+			continue
+		}
+		if _, err := s.scheduler.Enqueue(ctx, JobTypeVoiceCheck, voice.ID.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recheck 立即重新检查复刻音色的审核状态
+// 复刻失败的音色回到审核中，检查任务重新计算存活时间；只有所有者可以重新检查
+func (s *VoiceService) Recheck(ctx context.Context, id uuid.UUID) (*Voice, error) {
+	voice, err := s.editableVoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if voice.Provider != ProviderClone {
+		return nil, ErrNotCloned
+	}
+	if voice.Status == StatusReady {
+		return nil, ErrVoiceAlreadyReady
+	}
+
+	if voice.Status != StatusPending {
+		if err := s.setStatus(ctx, voice, StatusPending, nil); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.scheduler.Restart(ctx, JobTypeVoiceCheck, id.String()); err != nil {
+		return nil, err
+	}
+	return voice, nil
+}
+
+// runVoiceCheck 查询上游音色状态：通过时音色可用并生成试听，未通过时记录原因，审核中时稍后重试
+func (s *VoiceService) runVoiceCheck(ctx context.Context, j *job.Job) (bool, error) {
+	voice, err := s.pendingVoice(ctx, j)
+	if err != nil {
+		return false, err
+	}
+	if voice == nil {
+		// 音色已删除或不再审核中，任务结束
+		return true, nil
+	}
+
+	info, err := s.aiClient.GetVoiceStatus(ctx, voice.Voice)
+	if err != nil {
+		return false, err
+	}
+
+	switch info.Status {
+	case ai.VoiceStatusOK:
+		if err := s.setStatus(ctx, voice, StatusReady, nil); err != nil {
+			return false, err
+		}
+		// 试听音频生成失败不影响审核结果
+		if err := s.GeneratePreview(ctx, voice); err != nil {
+			zap.L().Warn("生成音色试听失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+		}
+		return true, nil
+	case ai.VoiceStatusUndeployed:
+		reason := "音色审核未通过，请更换音频样本后重试"
+		return true, s.setStatus(ctx, voice, StatusRejected, &reason)
+	case ai.VoiceStatusDeploying:
+		return false, nil
+	default:
+		return false, fmt.Errorf("未知的音色状态: %s", info.Status)
+	}
+}
+
+// expireVoiceCheck 检查任务超时，音色标记为复刻失败
+func (s *VoiceService) expireVoiceCheck(ctx context.Context, j *job.Job) error {
+	voice, err := s.pendingVoice(ctx, j)
+	if err != nil || voice == nil {
+		return err
+	}
+
+	reason := "音色审核超时"
+	if j.LastError != nil {
+		reason += ": " + *j.LastError
+	}
+	return s.setStatus(ctx, voice, StatusFailed, &reason)
+}
+
+// pendingVoice 读取任务对应的音色，音色已删除或不再审核中时返回 nil
+func (s *VoiceService) pendingVoice(ctx context.Context, j *job.Job) (*Voice, error) {
+	id, err := uuid.Parse(j.RefID)
+	if err != nil {
+		return nil, nil
+	}
+	voice, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, ErrVoiceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if voice.Status != StatusPending || voice.Provider != ProviderClone {
+		return nil, nil
+	}
+	return voice, nil
+}
@@ -0,0 +1,27 @@
+package voice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrVoiceNotFound 音色不存在
+var ErrVoiceNotFound = errors.New("voice not found")
+
+// Repo 音色仓库接口
+type Repo interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Voice, error)
+	// List 获取所有音色，按创建时间倒序
+	List(ctx context.Context) ([]*Voice, error)
+	// GetByStatus 根据状态获取音色列表
+	GetByStatus(ctx context.Context, status string) ([]*Voice, error)
+	// Save 保存音色，已存在时覆盖，新建时回填ID
+	Save(ctx context.Context, voice *Voice) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// CountCharacters 统计使用该音色的角色数量
+	CountCharacters(ctx context.Context, id uuid.UUID) (int64, error)
+	// CountCharactersWithDeleted 统计引用该音色的角色数量，包括尚未清除的已删除角色
+	CountCharactersWithDeleted(ctx context.Context, id uuid.UUID) (int64, error)
+}
@@ -0,0 +1,127 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 复刻样本要求
+const (
+	MinSampleSeconds    = 10
+	MaxSampleSeconds    = 20
+	MinSampleRate       = 16000
+	MaxSampleBytes      = 10 << 20
+	maxClippingRatio    = 0.001
+	minLoudnessDBFS     = -35.0
+	maxSilenceRatio     = 0.4
+	sampleDurationSlack = 0.05
+)
+
+// 样本问题代码
+const (
+	IssueUnreachable       = "unreachable"
+	IssueTooLarge          = "too_large"
+	IssueUnsupportedFormat = "unsupported_format"
+	IssueTooShort          = "too_short"
+	IssueTooLong           = "too_long"
+	IssueLowSampleRate     = "low_sample_rate"
+	IssueClipping          = "clipping"
+	IssueTooQuiet          = "too_quiet"
+	IssueTooMuchSilence    = "too_much_silence"
+)
+
+var (
+	// ErrSampleUnreachable 样本无法下载
+	ErrSampleUnreachable = errors.New("sample audio could not be downloaded")
+	// ErrSampleTooLarge 样本文件过大
+	ErrSampleTooLarge = errors.New("sample audio is too large")
+	// ErrSampleFormat 样本无法解码
+	ErrSampleFormat = errors.New("sample audio could not be decoded")
+)
+
+// SampleStats 复刻样本的分析结果
+type SampleStats struct {
+	Format          string  `json:"format"`
+	SampleRate      int     `json:"sample_rate"`
+	Channels        int     `json:"channels"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	// PeakDBFS 峰值电平
+	PeakDBFS float64 `json:"peak_dbfs"`
+	// LoudnessDBFS 非静音部分的平均电平
+	LoudnessDBFS float64 `json:"loudness_dbfs"`
+	// ClippingRatio 削波采样所占比例
+	ClippingRatio float64 `json:"clipping_ratio"`
+	// SilenceRatio 静音所占比例
+	SilenceRatio float64 `json:"silence_ratio"`
+}
+
+// SampleAnalyzer 下载并分析复刻样本
+// 下载失败返回 ErrSampleUnreachable，文件过大返回 ErrSampleTooLarge，无法解码返回 ErrSampleFormat
+type SampleAnalyzer interface {
+	Analyze(ctx context.Context, url string) (*SampleStats, error)
+}
+
+// SampleIssue 样本未满足的要求
+type SampleIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// SampleError 样本未通过校验
+type SampleError struct {
+	Issues []SampleIssue `json:"issues"`
+	// Stats 样本的分析结果，下载或解码失败时为空
+	Stats *SampleStats `json:"stats,omitempty"`
+}
+
+func (e *SampleError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		messages = append(messages, issue.Message)
+	}
+	return "音频样本不符合要求: " + strings.Join(messages, "；")
+}
+
+// CheckSample 按复刻要求检查样本，返回未满足的要求
+func CheckSample(stats *SampleStats) []SampleIssue {
+	var issues []SampleIssue
+	add := func(code, format string, args ...any) {
+		issues = append(issues, SampleIssue{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case stats.DurationSeconds < MinSampleSeconds-sampleDurationSlack:
+		add(IssueTooShort, "时长%.1f秒，请录制%d~%d秒的连续朗读", stats.DurationSeconds, MinSampleSeconds, MaxSampleSeconds)
+	case stats.DurationSeconds > MaxSampleSeconds+sampleDurationSlack:
+		add(IssueTooLong, "时长%.1f秒，请剪辑到%d~%d秒", stats.DurationSeconds, MinSampleSeconds, MaxSampleSeconds)
+	}
+	if stats.SampleRate < MinSampleRate {
+		add(IssueLowSampleRate, "采样率%dHz过低，请使用不低于%dHz的录音", stats.SampleRate, MinSampleRate)
+	}
+	if stats.ClippingRatio > maxClippingRatio {
+		add(IssueClipping, "%.2f%%的采样出现削波失真，请降低录音音量或远离麦克风", stats.ClippingRatio*100)
+	}
+	if stats.LoudnessDBFS < minLoudnessDBFS {
+		add(IssueTooQuiet, "音量过低（%.1f dBFS），请靠近麦克风或提高录音增益", stats.LoudnessDBFS)
+	}
+	if stats.SilenceRatio > maxSilenceRatio {
+		add(IssueTooMuchSilence, "静音占%.0f%%，请去掉首尾和中间的长时间停顿", stats.SilenceRatio*100)
+	}
+	return issues
+}
+
+// sampleIssueFromError 把下载或解码错误转换为样本问题
+func sampleIssueFromError(err error) (SampleIssue, bool) {
+	switch {
+	case errors.Is(err, ErrSampleUnreachable):
+		return SampleIssue{Code: IssueUnreachable, Message: "无法下载音频样本，请确认URL可以公开访问"}, true
+	case errors.Is(err, ErrSampleTooLarge):
+		return SampleIssue{Code: IssueTooLarge, Message: fmt.Sprintf("音频样本超过%dMB", MaxSampleBytes>>20)}, true
+	case errors.Is(err, ErrSampleFormat):
+		return SampleIssue{Code: IssueUnsupportedFormat, Message: "无法解析音频样本，请上传16位PCM编码的wav或mp3文件"}, true
+	default:
+		return SampleIssue{}, false
+	}
+}
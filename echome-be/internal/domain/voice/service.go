@@ -0,0 +1,389 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/job"
+	"github.com/justin/echome-be/internal/domain/storage"
+	"github.com/justin/echome-be/internal/infra/aliyun"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// orphanGracePeriod 上游音色创建超过该时长仍未登记才视为孤儿，避免误删正在创建的音色
+const orphanGracePeriod = 24 * time.Hour
+
+var (
+	// ErrVoiceInUse 音色仍被角色使用
+	ErrVoiceInUse = errors.New("voice is still used by a character")
+	// ErrInvalidVoice 音色参数无效
+	ErrInvalidVoice = errors.New("invalid voice")
+	// ErrNotCloned 音色不是复刻音色
+	ErrNotCloned = errors.New("voice is not a cloned voice")
+	// ErrVoiceUnavailable 音色复刻失败或审核未通过，不能分配给角色
+	ErrVoiceUnavailable = errors.New("voice is not available")
+)
+
+// StatusListener 音色状态变化时的回调
+type StatusListener func(ctx context.Context, voice *Voice) error
+
+// VoiceService 音色库服务，管理模型自带音色和复刻音色
+type VoiceService struct {
+	repo            Repo
+	aiClient        ai.Repo
+	analyzer        SampleAnalyzer
+	storage         storage.Repo
+	scheduler       *job.Scheduler
+	characterConfig *config.CharacterConfig
+	listeners       []StatusListener
+}
+
+// NewVoiceService 创建音色库服务，并注册音色审核检查任务
+func NewVoiceService(repo Repo, aiClient ai.Repo, analyzer SampleAnalyzer, storage storage.Repo, scheduler *job.Scheduler, characterConfig *config.CharacterConfig) *VoiceService {
+	s := &VoiceService{
+		repo:            repo,
+		aiClient:        aiClient,
+		analyzer:        analyzer,
+		storage:         storage,
+		scheduler:       scheduler,
+		characterConfig: characterConfig,
+	}
+	scheduler.Register(JobTypeVoiceCheck, job.Handler{
+		Policy: voiceCheckPolicy,
+		Run:    s.runVoiceCheck,
+		Expire: s.expireVoiceCheck,
+	})
+	return s
+}
+
+// OnStatusChange 注册音色状态变化的回调，需在服务启动前调用
+func (s *VoiceService) OnStatusChange(listener StatusListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// ValidateSample 在复刻前下载并检查音频样本，不符合要求时返回 *SampleError
+func (s *VoiceService) ValidateSample(ctx context.Context, url string) error {
+	stats, err := s.analyzer.Analyze(ctx, url)
+	if err != nil {
+		if issue, ok := sampleIssueFromError(err); ok {
+			return &SampleError{Issues: []SampleIssue{issue}}
+		}
+		return err
+	}
+	if issues := CheckSample(stats); len(issues) > 0 {
+		return &SampleError{Issues: issues, Stats: stats}
+	}
+	return nil
+}
+
+// GetVoice 获取音色，不检查当前用户能否使用，用于合成已分配给角色的音色
+func (s *VoiceService) GetVoice(ctx context.Context, id uuid.UUID) (*Voice, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListVoices 列出当前用户可以使用的音色：模型自带音色和自己的复刻音色，管理员可以看到所有音色
+func (s *VoiceService) ListVoices(ctx context.Context) ([]*Voice, error) {
+	voices, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user := auth.UserFrom(ctx)
+	return lo.Filter(voices, func(voice *Voice, _ int) bool {
+		return CanUse(user, voice)
+	}), nil
+}
+
+// CreateVoice 创建音色
+// 模型自带音色直接可用；复刻音色先校验样本再复刻，审核通过前处于审核中
+func (s *VoiceService) CreateVoice(ctx context.Context, req CreateRequest) (*Voice, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidVoice)
+	}
+	if req.Provider == "" {
+		req.Provider = ProviderBuiltin
+		if req.SampleURL != "" {
+			req.Provider = ProviderClone
+		}
+	}
+
+	voice := &Voice{
+		Name:           req.Name,
+		OwnerID:        req.OwnerID,
+		Provider:       req.Provider,
+		Model:          req.Model,
+		CharacterOwned: req.CharacterOwned,
+	}
+	if voice.Model == "" {
+		voice.Model = aliyun.DefaultTTSConfig().Model
+	}
+
+	switch req.Provider {
+	case ProviderBuiltin:
+		if req.Voice == "" {
+			return nil, fmt.Errorf("%w: voice is required for builtin voices", ErrInvalidVoice)
+		}
+		voice.Voice = req.Voice
+		voice.Status = StatusReady
+	case ProviderClone:
+		if req.SampleURL == "" {
+			return nil, fmt.Errorf("%w: sample_url is required for cloned voices", ErrInvalidVoice)
+		}
+		// 复刻音色只能由复刻时指定的模型合成
+		if req.Model != "" && req.Model != voice.Model {
+			return nil, fmt.Errorf("%w: cloned voices only support model %s", ErrInvalidVoice, voice.Model)
+		}
+		if err := s.ValidateSample(ctx, req.SampleURL); err != nil {
+			return nil, err
+		}
+		voiceID, err := s.aiClient.VoiceClone(ctx, req.SampleURL)
+		if err != nil {
+			return nil, err
+		}
+		voice.Voice = *voiceID
+		voice.SampleURL = &req.SampleURL
+		voice.Status = StatusPending
+	default:
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidVoice, req.Provider)
+	}
+
+	if err := s.repo.Save(ctx, voice); err != nil {
+		if voice.Provider == ProviderClone {
+			s.deleteUpstream(ctx, voice.Voice)
+		}
+		return nil, err
+	}
+
+	if voice.Status == StatusReady {
+		// 试听音频生成失败不影响音色使用
+		if err := s.GeneratePreview(ctx, voice); err != nil {
+			zap.L().Warn("生成音色试听失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+		}
+	} else if _, err := s.scheduler.Enqueue(ctx, JobTypeVoiceCheck, voice.ID.String()); err != nil {
+		zap.L().Warn("创建音色检查任务失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+	}
+	return voice, nil
+}
+
+// UpdateVoice 修改音色名或更换复刻样本
+// 更换样本时上游音色ID不变，音色回到审核中，使用该音色的角色同步回到审核中；只有所有者可以修改
+func (s *VoiceService) UpdateVoice(ctx context.Context, id uuid.UUID, req UpdateRequest) (*Voice, error) {
+	voice, err := s.editableVoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidVoice)
+		}
+		voice.Name = name
+	}
+
+	resample := req.SampleURL != nil
+	if resample {
+		if voice.Provider != ProviderClone {
+			return nil, ErrNotCloned
+		}
+		if err := s.ValidateSample(ctx, *req.SampleURL); err != nil {
+			return nil, err
+		}
+		if err := s.aiClient.UpdateVoice(ctx, voice.Voice, *req.SampleURL); err != nil {
+			return nil, err
+		}
+		voice.SampleURL = req.SampleURL
+		if voice.PreviewURL != nil {
+			s.deletePreview(ctx, voice)
+			voice.PreviewURL = nil
+		}
+	}
+
+	if !resample {
+		if err := s.repo.Save(ctx, voice); err != nil {
+			return nil, err
+		}
+		return voice, nil
+	}
+	if err := s.setStatus(ctx, voice, StatusPending, nil); err != nil {
+		return nil, err
+	}
+	if _, err := s.scheduler.Restart(ctx, JobTypeVoiceCheck, id.String()); err != nil {
+		return nil, err
+	}
+	return voice, nil
+}
+
+// DeleteVoice 删除未被角色使用的音色，复刻音色同时删除上游音色以释放配额；只有所有者可以删除
+func (s *VoiceService) DeleteVoice(ctx context.Context, id uuid.UUID) error {
+	voice, err := s.editableVoice(ctx, id)
+	if err != nil {
+		return err
+	}
+	count, err := s.repo.CountCharacters(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrVoiceInUse
+	}
+
+	if voice.Provider == ProviderClone {
+		if err := s.aiClient.DeleteVoice(ctx, voice.Voice); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if voice.PreviewURL != nil {
+		s.deletePreview(ctx, voice)
+	}
+	return nil
+}
+
+// ReleaseCharacterVoice 删除随角色复刻且不再被任何角色引用的音色，包括尚未清除的已删除角色
+// 用户在音色库中创建的音色和仍被引用的音色保留；上游音色删除失败时由 Reconcile 回收
+func (s *VoiceService) ReleaseCharacterVoice(ctx context.Context, id uuid.UUID) error {
+	voice, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, ErrVoiceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !voice.CharacterOwned || voice.Provider != ProviderClone {
+		return nil
+	}
+	count, err := s.repo.CountCharactersWithDeleted(ctx, id)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil && !errors.Is(err, ErrVoiceNotFound) {
+		return err
+	}
+	s.deleteUpstream(ctx, voice.Voice)
+	if voice.PreviewURL != nil {
+		s.deletePreview(ctx, voice)
+	}
+	return nil
+}
+
+// GeneratePreview 用音色合成试听台词，保存到文件存储
+func (s *VoiceService) GeneratePreview(ctx context.Context, voice *Voice) error {
+	text := config.DefaultVoicePreviewText
+	if s.characterConfig != nil && strings.TrimSpace(s.characterConfig.VoicePreviewText) != "" {
+		text = s.characterConfig.VoicePreviewText
+	}
+	text = strings.ReplaceAll(text, "{name}", voice.Name)
+
+	var buf bytes.Buffer
+	ttsConfig := voice.TTSConfig()
+	ttsConfig.Format = ai.AudioFormatMP3
+	if err := s.aiClient.HandleTTS(ctx, &buf, text, ttsConfig); err != nil {
+		return err
+	}
+	if buf.Len() == 0 {
+		return fmt.Errorf("TTS 未返回音频")
+	}
+
+	url, err := s.storage.Save(ctx, previewKey(voice.ID), &buf)
+	if err != nil {
+		return err
+	}
+	voice.PreviewURL = &url
+	return s.repo.Save(ctx, voice)
+}
+
+// Reconcile 清理孤儿音色，返回删除的上游音色数量
+// 孤儿音色指上游存在、音色库中没有对应记录且创建超过宽限期的复刻音色
+func (s *VoiceService) Reconcile(ctx context.Context) (int, error) {
+	upstream, err := s.aiClient.ListVoices(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("列出上游音色失败: %w", err)
+	}
+	voices, err := s.repo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	registered := make(map[string]*Voice, len(voices))
+	for _, voice := range voices {
+		if voice.Provider == ProviderClone {
+			registered[voice.Voice] = voice
+		}
+	}
+
+	deleted := 0
+	for _, info := range upstream {
+		if _, ok := registered[info.VoiceID]; ok {
+			delete(registered, info.VoiceID)
+			continue
+		}
+		if info.CreatedAt.IsZero() || time.Since(info.CreatedAt) <= orphanGracePeriod {
+			continue
+		}
+		if err := s.aiClient.DeleteVoice(ctx, info.VoiceID); err != nil {
+			zap.L().Warn("删除孤儿音色失败", zap.String("voiceID", info.VoiceID), zap.Error(err))
+			continue
+		}
+		deleted++
+	}
+
+	// 上游已不存在的复刻音色无法再合成，标记为复刻失败
+	for _, voice := range registered {
+		if voice.Status == StatusFailed {
+			continue
+		}
+		zap.L().Warn("复刻音色在上游不存在", zap.String("voiceID", voice.ID.String()), zap.String("voice", voice.Voice))
+		reason := "上游音色不存在"
+		if err := s.setStatus(ctx, voice, StatusFailed, &reason); err != nil {
+			zap.L().Warn("更新音色状态失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+		}
+	}
+	return deleted, nil
+}
+
+// setStatus 更新音色状态并通知回调，回调失败只记录日志
+func (s *VoiceService) setStatus(ctx context.Context, voice *Voice, status string, reason *string) error {
+	voice.Status = status
+	voice.StatusReason = reason
+	if err := s.repo.Save(ctx, voice); err != nil {
+		return err
+	}
+	for _, listener := range s.listeners {
+		if err := listener(ctx, voice); err != nil {
+			zap.L().Warn("同步音色状态失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// deleteUpstream 尽力删除上游音色，失败时由 Reconcile 回收
+func (s *VoiceService) deleteUpstream(ctx context.Context, voiceID string) {
+	if err := s.aiClient.DeleteVoice(ctx, voiceID); err != nil {
+		zap.L().Warn("删除上游音色失败", zap.String("voice", voiceID), zap.Error(err))
+	}
+}
+
+// deletePreview 尽力删除试听音频
+func (s *VoiceService) deletePreview(ctx context.Context, voice *Voice) {
+	if err := s.storage.Delete(ctx, previewKey(voice.ID)); err != nil {
+		zap.L().Warn("删除音色试听失败", zap.String("voiceID", voice.ID.String()), zap.Error(err))
+	}
+}
+
+// previewKey 音色试听音频在文件存储中的路径
+func previewKey(id uuid.UUID) string {
+	return "voice-previews/voices/" + id.String() + ".mp3"
+}
@@ -0,0 +1,80 @@
+package voice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+// 音色来源
+const (
+	ProviderBuiltin = "builtin" // 模型自带音色
+	ProviderClone   = "clone"   // 用音频样本复刻的音色
+)
+
+// 音色状态
+const (
+	StatusPending  = "pending"  // 复刻审核中
+	StatusReady    = "ready"    // 可用
+	StatusFailed   = "failed"   // 复刻失败，如审核超时或上游持续出错
+	StatusRejected = "rejected" // 审核未通过，需要更换样本
+)
+
+// Voice 音色库中的音色，一个音色可以被多个角色使用
+type Voice struct {
+	ID uuid.UUID `json:"id"`
+	// Name 音色名
+	Name string `json:"name"`
+	// OwnerID 音色所有者
+	OwnerID *string `json:"owner_id"`
+	// Provider 音色来源: builtin / clone
+	Provider string `json:"provider"`
+	// Model 合成使用的模型，复刻音色只能由复刻时指定的模型合成
+	Model string `json:"model"`
+	// Voice 上游音色：模型自带的音色名或复刻得到的音色ID
+	Voice string `json:"voice"`
+	// Status 音色状态: pending / ready / failed / rejected
+	Status string `json:"status"`
+	// StatusReason 复刻失败或审核未通过的原因
+	StatusReason *string `json:"status_reason"`
+	// SampleURL 复刻使用的音频样本
+	SampleURL *string `json:"sample_url"`
+	// PreviewURL 音色可用后合成的试听音频
+	PreviewURL *string `json:"preview_url"`
+	// CharacterOwned 创建角色时随角色复刻的音色，最后一个引用它的角色清除后自动删除
+	CharacterOwned bool      `json:"character_owned"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TTSConfig 返回使用该音色合成时的模型和音色参数
+func (v *Voice) TTSConfig() ai.TTSConfig {
+	return ai.TTSConfig{
+		Model: v.Model,
+		Voice: v.Voice,
+	}
+}
+
+// CreateRequest 创建音色的参数
+type CreateRequest struct {
+	Name    string
+	OwnerID *string
+	// Provider 音色来源，为空时按是否提供样本推断
+	Provider string
+	// Voice 模型自带的音色名，builtin 音色必填
+	Voice string
+	// Model 合成使用的模型，为空时使用默认模型
+	Model string
+	// SampleURL 复刻使用的音频样本，clone 音色必填
+	SampleURL string
+	// CharacterOwned 随角色复刻的音色，最后一个引用它的角色清除后自动删除
+	CharacterOwned bool
+}
+
+// UpdateRequest 更新音色的参数，为空的字段保持不变
+type UpdateRequest struct {
+	Name *string
+	// SampleURL 新的音频样本，会用它重新复刻音色
+	SampleURL *string
+}
@@ -0,0 +1,32 @@
+package ws
+
+import (
+	"github.com/gorilla/websocket"
+)
+
+// AudioWriter 把 WebSocket 连接适配为 TTS 输出
+// 音频写为二进制帧，事件写为 JSON 文本帧
+type AudioWriter struct {
+	conn WebSocketConn
+}
+
+// NewAudioWriter 创建 WebSocket 音频输出
+func NewAudioWriter(conn WebSocketConn) *AudioWriter {
+	return &AudioWriter{conn: conn}
+}
+
+// Write 把一段音频作为一个二进制帧发送
+// 连接可能异步发送，因此复制数据后再写出
+func (w *AudioWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteEvent 发送 JSON 事件
+func (w *AudioWriter) WriteEvent(event any) error {
+	return w.conn.WriteJSON(event)
+}
@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/justin/echome-be/internal/domain/auth"
+)
+
+// userID 返回当前用户ID，匿名请求返回 nil
+func userID(ctx context.Context) *string {
+	if user := auth.UserFrom(ctx); user != nil {
+		return &user.ID
+	}
+	return nil
+}
@@ -1,10 +1,19 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
 	"github.com/google/uuid"
 	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/auth"
 	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/justin/echome-be/internal/domain/voice"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 // CreateCharacterRequest 定义创建角色请求体结构
@@ -15,7 +24,80 @@ type CreateCharacterRequest struct {
 	Name         string  `json:"name"`          // 必须，角色名称
 	Prompt       string  `json:"prompt"`        // 必须，角色提示词
 	Avatar       *string `json:"avatar"`        // 可选，角色头像
-	Flag         bool    `json:"flag"`          // 必须，标志位
+	Flag         bool    `json:"flag"`          // 必须，为 true 时用 audio 复刻新音色
+	VoiceID      *string `json:"voice_id"`      // 可选，使用音色库中的音色，与 flag 互斥
+	Visibility   string  `json:"visibility"`    // 可选，可见性: private/unlisted/public，默认 private
+	// 可选，ASR 热词
+	Hotwords []ai.Hotword `json:"hotwords"`
+	// 可选，开场白，最多2000字
+	Greeting *string `json:"greeting"`
+	// 可选，备选开场白，最多20条
+	AlternateGreetings []string `json:"alternate_greetings"`
+	// 可选，对话示例，最多20段，每段最多20轮
+	ExampleDialogues []character.ExampleDialogue `json:"example_dialogues"`
+	// 可选，标签，最多10个，不存在的标签自动创建
+	Tags []string `json:"tags"`
+	// 可选，是否允许其他用户复制，默认 true
+	AllowFork *bool `json:"allow_fork"`
+	// 可选，使用许可，最多100字
+	License *string `json:"license"`
+	// 可选，语音合成参数，省略的字段使用默认值
+	Speech character.SpeechSettings `json:"speech"`
+}
+
+// ReplaceCharacterRequest 定义整体更新角色请求体结构，省略的可选字段会被清空
+type ReplaceCharacterRequest struct {
+	Name         string     `json:"name"`          // 必须，角色名称，最多50字
+	Prompt       string     `json:"prompt"`        // 必须，角色提示词
+	Description  *string    `json:"description"`   // 可选，角色描述，最多500字
+	Avatar       *string    `json:"avatar"`        // 可选，角色头像URL
+	AudioExample *string    `json:"audio_example"` // 可选，示例音频URL
+	VoiceID      *uuid.UUID `json:"voice_id"`      // 可选，音色库中的音色，为空时使用默认音色
+	Visibility   string     `json:"visibility"`    // 必须，可见性: private/unlisted/public
+	// 可选，开场白，最多2000字
+	Greeting *string `json:"greeting"`
+	// 可选，备选开场白，最多20条
+	AlternateGreetings []string `json:"alternate_greetings"`
+	// 可选，对话示例，最多20段，每段最多20轮
+	ExampleDialogues []character.ExampleDialogue `json:"example_dialogues"`
+	// 可选，是否允许其他用户复制，省略时为 true
+	AllowFork *bool `json:"allow_fork"`
+	// 可选，使用许可，最多100字
+	License *string `json:"license"`
+	// 可选，语音合成参数，省略的字段使用默认值
+	Speech character.SpeechSettings `json:"speech"`
+}
+
+// PatchCharacterRequest 定义部分更新角色请求体结构，只修改出现的字段
+type PatchCharacterRequest struct {
+	Name         character.Optional[string]    `json:"name" swaggertype:"string"`
+	Prompt       character.Optional[string]    `json:"prompt" swaggertype:"string"`
+	Description  character.Optional[string]    `json:"description" swaggertype:"string"`
+	Avatar       character.Optional[string]    `json:"avatar" swaggertype:"string"`
+	AudioExample character.Optional[string]    `json:"audio_example" swaggertype:"string"`
+	VoiceID      character.Optional[uuid.UUID] `json:"voice_id" swaggertype:"string"`
+	Visibility   character.Optional[string]    `json:"visibility" swaggertype:"string"`
+	Greeting     character.Optional[string]    `json:"greeting" swaggertype:"string"`
+	// AlternateGreetings 备选开场白，传 null 或空列表表示清空
+	AlternateGreetings character.Optional[[]string] `json:"alternate_greetings" swaggertype:"array,string"`
+	// ExampleDialogues 对话示例，传 null 或空列表表示清空
+	ExampleDialogues character.Optional[[]character.ExampleDialogue] `json:"example_dialogues" swaggertype:"array,object"`
+	// AllowFork 是否允许其他用户复制，传 null 表示恢复为允许
+	AllowFork character.Optional[bool]   `json:"allow_fork" swaggertype:"boolean"`
+	License   character.Optional[string] `json:"license" swaggertype:"string"`
+	// Speech 语音合成参数，整体替换，传 null 表示恢复默认
+	Speech character.Optional[character.SpeechSettings] `json:"speech" swaggertype:"object"`
+}
+
+// UpdateHotwordsRequest 定义更新角色热词请求体结构
+type UpdateHotwordsRequest struct {
+	Hotwords []ai.Hotword `json:"hotwords"`
+}
+
+// UpdateCharacterVoiceRequest 定义更换角色音色请求体结构，voice_id 与 audio 二选一
+type UpdateCharacterVoiceRequest struct {
+	VoiceID string `json:"voice_id"` // 可选，换成音色库中的音色
+	Audio   string `json:"audio"`    // 可选，新的音频样本URL，重新复刻角色当前使用的音色
 }
 
 type CharacterHandlers struct {
@@ -33,33 +115,150 @@ func (h *CharacterHandlers) RegisterRoutes(e *echo.Echo) {
 	e.GET("/api/characters", h.GetCharacters)
 	e.GET("/api/characters/:id", h.GetCharacterByID)
 	e.POST("/api/character", h.CreateCharacter)
+	e.POST("/api/characters/import", h.ImportCharacter)
+	e.POST("/api/characters/:id/fork", h.ForkCharacter)
+	e.GET("/api/characters/:id/export", h.ExportCharacter)
+	e.PUT("/api/characters/:id/hotwords", h.UpdateHotwords)
+	e.DELETE("/api/characters/:id/hotwords", h.DeleteHotwords)
+	e.PUT("/api/characters/:id", h.ReplaceCharacter)
+	e.PATCH("/api/characters/:id", h.PatchCharacter)
+	e.DELETE("/api/characters/:id", h.DeleteCharacter)
+	e.POST("/api/characters/:id/restore", h.RestoreCharacter)
+	e.GET("/api/characters/:id/versions", h.GetCharacterVersions)
+	e.GET("/api/characters/:id/versions/diff", h.DiffCharacterVersions)
+	e.GET("/api/characters/:id/versions/:version", h.GetCharacterVersion)
+	e.POST("/api/characters/:id/versions/:version/rollback", h.RollbackCharacter)
+	e.PUT("/api/characters/:id/voice", h.UpdateCharacterVoice)
+	e.POST("/api/characters/:id/voice/recheck", h.RecheckVoice)
+	e.POST("/api/characters/:id/voice/preview", h.PreviewCharacterSpeech)
+	e.PUT("/api/characters/:id/tags", h.SetCharacterTags)
+	e.GET("/api/characters/:id/reviews", h.GetCharacterReviews)
+	e.GET("/api/characters/:id/stats", h.GetCharacterStats)
+	e.PUT("/api/characters/:id/favorite", h.FavoriteCharacter)
+	e.DELETE("/api/characters/:id/favorite", h.UnfavoriteCharacter)
+	e.GET("/api/me/favorites", h.GetFavorites)
+	e.GET("/api/admin/characters/pending", h.GetPendingReviews)
+	e.POST("/api/admin/characters/:id/approve", h.ApproveCharacter)
+	e.POST("/api/admin/characters/:id/reject", h.RejectCharacter)
+	e.GET("/api/tags", h.GetTags)
+	e.POST("/api/tags", h.CreateTag)
+	e.PATCH("/api/tags/:id", h.UpdateTag)
+	e.DELETE("/api/tags/:id", h.DeleteTag)
 }
 
 // GetCharacters handles GET /api/characters
-// @Summary 获取所有角色
-// @Description 获取所有可用角色的列表
+// @Summary 获取角色列表
+// @Description 分页获取当前用户可见的角色列表，包括公开角色和自己的角色，默认按创建时间倒序；q 检索角色名和描述，支持中文
+// @Description 默认只返回可用的角色，owner=me 时默认返回自己所有状态的角色
+// @Description 第一页的 facets 统计符合条件的所有角色中最常见的标签及角色数，可用于按标签筛选
 // @Tags characters
 // @Accept json
 // @Produce json
-// @Success 200 {array} character.Character
+// @Param q query string false "检索词"
+// @Param owner query string false "所有者的用户ID，me 表示当前用户"
+// @Param status query string false "状态: 1-审核中, 2-可用, 3-禁用, 4-复刻失败, 5-音色审核未通过, 6-内容审核驳回，all 表示不过滤，默认2"
+// @Param cloned query bool false "是否使用复刻音色"
+// @Param tag query string false "只返回带有该标签的角色"
+// @Param sort query string false "排序方式: newest 按创建时间, popular 按语音对话次数，默认newest"
+// @Param limit query int false "每页数量，默认20，最多100"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Success 200 {object} character.Page
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string "owner=me 但未登录"
+// @Failure 500 {object} map[string]string
 // @Router /api/characters [get]
 func (h *CharacterHandlers) GetCharacters(c echo.Context) error {
-	characters, err := h.characterService.GetAllCharacters(c.Request().Context())
+	query, err := parseListQuery(c)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return domain.Unauthorized(c, "Authentication required", err.Error())
+		}
+		return domain.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	page, err := h.characterService.ListCharacters(c.Request().Context(), query)
 	if err != nil {
+		if errors.Is(err, character.ErrInvalidSort) {
+			return domain.BadRequest(c, "Invalid query parameters", err.Error())
+		}
 		return domain.InternalError(c, "Failed to get characters", err.Error())
 	}
 
-	return domain.Success(c, characters)
+	return domain.Success(c, page)
+}
+
+// parseListQuery 解析角色列表的查询参数
+func parseListQuery(c echo.Context) (character.ListQuery, error) {
+	query := character.ListQuery{
+		Tag:    c.QueryParam("tag"),
+		Search: c.QueryParam("q"),
+		Sort:   c.QueryParam("sort"),
+	}
+
+	owner := c.QueryParam("owner")
+	switch owner {
+	case "":
+	case "me":
+		query.OwnerID = userID(c.Request().Context())
+		if query.OwnerID == nil {
+			return query, auth.ErrUnauthenticated
+		}
+	default:
+		query.OwnerID = &owner
+	}
+
+	switch status := c.QueryParam("status"); status {
+	case "":
+		// 默认只展示可用的角色，查看自己的角色时展示所有状态
+		if owner == "me" {
+			break
+		}
+		query.Status = lo.ToPtr(int32(character.CharacterStatusApproved))
+	case "all":
+	default:
+		value, err := strconv.ParseInt(status, 10, 32)
+		if err != nil {
+			return query, fmt.Errorf("invalid status: %s", status)
+		}
+		query.Status = lo.ToPtr(int32(value))
+	}
+
+	if cloned := c.QueryParam("cloned"); cloned != "" {
+		value, err := strconv.ParseBool(cloned)
+		if err != nil {
+			return query, fmt.Errorf("invalid cloned: %s", cloned)
+		}
+		query.Cloned = &value
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			return query, fmt.Errorf("invalid limit: %s", limit)
+		}
+		query.Limit = value
+	}
+
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		decoded, err := character.DecodeCursor(cursor)
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = decoded
+	}
+	return query, nil
 }
 
 // GetCharacterByID handles GET /api/characters/:id
 // @Summary 获取角色详情
-// @Description 根据角色ID获取详细信息
+// @Description 根据角色ID获取详细信息，其他用户的私有角色按不存在处理
 // @Tags characters
 // @Accept json
 // @Produce json
 // @Param id path string true "角色ID"
 // @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /api/characters/{id} [get]
 func (h *CharacterHandlers) GetCharacterByID(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
@@ -69,21 +268,23 @@ func (h *CharacterHandlers) GetCharacterByID(c echo.Context) error {
 
 	character, err := h.characterService.GetCharacterByID(c.Request().Context(), id)
 	if err != nil {
-		return domain.NotFound(c, "Character not found", err.Error())
+		return characterError(c, err, "Failed to get character")
 	}
 
 	return domain.Success(c, character)
 }
 
 // CreateCharacter handles POST /api/character
-// @Summary 创建角色（语音克隆）
-// @Description 通过语音克隆创建角色
+// @Summary 创建角色
+// @Description 创建归当前用户所有的角色，flag 为 true 时用 audio 复刻新音色并加入音色库，也可以通过 voice_id 使用音色库中已有的音色
 // @Tags characters
 // @Accept json
 // @Produce json
 // @Param request body CreateCharacterRequest true "创建角色的请求体参数"
-// @Success 200
-// @Failure 400 {object} map[string]string
+// @Success 201 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "字段或音频样本不符合要求时 error.issues 列出具体问题"
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/character [post]
 func (h *CharacterHandlers) CreateCharacter(c echo.Context) error {
@@ -92,25 +293,375 @@ func (h *CharacterHandlers) CreateCharacter(c echo.Context) error {
 		return domain.BadRequest(c, "Invalid request body", err.Error())
 	}
 
-	// 验证必填字段
-	if requestBody.Name == "" || requestBody.Prompt == "" {
-		return domain.BadRequest(c, "Missing required fields", "name, prompt and flag are required")
+	if requestBody.Flag && (requestBody.Audio == nil || *requestBody.Audio == "") {
+		return domain.BadRequest(c, "Missing required fields", "audio is required when flag is true")
+	}
+	var voiceID *uuid.UUID
+	if requestBody.VoiceID != nil && *requestBody.VoiceID != "" {
+		if requestBody.Flag {
+			return domain.BadRequest(c, "Conflicting fields", "voice_id and flag are mutually exclusive")
+		}
+		id, err := uuid.Parse(*requestBody.VoiceID)
+		if err != nil {
+			return domain.BadRequest(c, "Invalid voice ID", err.Error())
+		}
+		voiceID = &id
+	}
+
+	hotwords, err := character.NormalizeHotwords(requestBody.Hotwords)
+	if err != nil {
+		return domain.BadRequest(c, "Invalid hotwords", err.Error())
 	}
 
 	// 创建角色信息
 	characterInfo := &character.Character{
-		Name:         requestBody.Name,
-		Prompt:       requestBody.Prompt,
-		Avatar:       requestBody.Avatar,
-		Description:  requestBody.Description,
-		AudioExample: requestBody.Audio,
-		Flag:         requestBody.Flag,
+		Name:               requestBody.Name,
+		Prompt:             requestBody.Prompt,
+		Avatar:             requestBody.Avatar,
+		Description:        requestBody.Description,
+		AudioExample:       requestBody.Audio,
+		Flag:               requestBody.Flag,
+		VoiceID:            voiceID,
+		Visibility:         requestBody.Visibility,
+		Hotwords:           hotwords,
+		Greeting:           requestBody.Greeting,
+		AlternateGreetings: requestBody.AlternateGreetings,
+		ExampleDialogues:   requestBody.ExampleDialogues,
+		Tags:               requestBody.Tags,
+		AllowFork:          lo.FromPtrOr(requestBody.AllowFork, true),
+		License:            requestBody.License,
+		Speech:             requestBody.Speech,
 	}
 	// 执行语音克隆并创建角色
-	err := h.characterService.CreateCharacter(c.Request().Context(), requestBody.Audio, characterInfo)
+	created, err := h.characterService.CreateCharacter(c.Request().Context(), requestBody.Audio, characterInfo)
+	if err != nil {
+		return characterError(c, err, "Failed to create character")
+	}
+
+	return domain.Created(c, created)
+}
+
+// ReplaceCharacter handles PUT /api/characters/:id
+// @Summary 更新角色
+// @Description 整体替换角色的可编辑字段，省略的可选字段会被清空；voice_id 为空时使用默认音色。热词和音色样本通过各自的接口修改
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body ReplaceCharacterRequest true "角色字段"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id} [put]
+func (h *CharacterHandlers) ReplaceCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody ReplaceCharacterRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	updated, err := h.characterService.UpdateCharacter(c.Request().Context(), id, character.Patch{
+		Name:               character.Some(&requestBody.Name),
+		Prompt:             character.Some(&requestBody.Prompt),
+		Description:        character.Some(requestBody.Description),
+		Avatar:             character.Some(requestBody.Avatar),
+		AudioExample:       character.Some(requestBody.AudioExample),
+		VoiceID:            character.Some(requestBody.VoiceID),
+		Visibility:         character.Some(&requestBody.Visibility),
+		Greeting:           character.Some(requestBody.Greeting),
+		AlternateGreetings: character.Some(&requestBody.AlternateGreetings),
+		ExampleDialogues:   character.Some(&requestBody.ExampleDialogues),
+		AllowFork:          character.Some(requestBody.AllowFork),
+		License:            character.Some(requestBody.License),
+		Speech:             character.Some(&requestBody.Speech),
+	})
+	if err != nil {
+		return characterError(c, err, "Failed to update character")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// PatchCharacter handles PATCH /api/characters/:id
+// @Summary 部分更新角色
+// @Description 只修改请求中出现的字段，可选字段传 null 表示清空；voice_id 传 null 时使用默认音色
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body PatchCharacterRequest true "要修改的字段"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id} [patch]
+func (h *CharacterHandlers) PatchCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody PatchCharacterRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	updated, err := h.characterService.UpdateCharacter(c.Request().Context(), id, character.Patch{
+		Name:               requestBody.Name,
+		Prompt:             requestBody.Prompt,
+		Description:        requestBody.Description,
+		Avatar:             requestBody.Avatar,
+		AudioExample:       requestBody.AudioExample,
+		VoiceID:            requestBody.VoiceID,
+		Visibility:         requestBody.Visibility,
+		Greeting:           requestBody.Greeting,
+		AlternateGreetings: requestBody.AlternateGreetings,
+		ExampleDialogues:   requestBody.ExampleDialogues,
+		AllowFork:          requestBody.AllowFork,
+		License:            requestBody.License,
+		Speech:             requestBody.Speech,
+	})
+	if err != nil {
+		return characterError(c, err, "Failed to update character")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// RestoreCharacter handles POST /api/characters/:id/restore
+// @Summary 恢复角色
+// @Description 恢复 30 天保留期内已删除的角色，已清除的角色返回 404；删除期间其音色被删除时改用默认音色
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string "角色不存在或未被删除"
+// @Failure 409 {object} map[string]string "角色名已被其他角色使用"
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/restore [post]
+func (h *CharacterHandlers) RestoreCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	restored, err := h.characterService.RestoreCharacter(c.Request().Context(), id)
+	if err != nil {
+		return characterError(c, err, "Failed to restore character")
+	}
+
+	return domain.Success(c, restored)
+}
+
+// UpdateHotwords handles PUT /api/characters/:id/hotwords
+// @Summary 更新角色热词
+// @Description 覆盖角色的 ASR 热词列表并同步到阿里云热词表，空列表等同于删除
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body UpdateHotwordsRequest true "热词列表，weight 取值1~5，默认4"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/hotwords [put]
+func (h *CharacterHandlers) UpdateHotwords(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody UpdateHotwordsRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+	hotwords, err := character.NormalizeHotwords(requestBody.Hotwords)
+	if err != nil {
+		return domain.BadRequest(c, "Invalid hotwords", err.Error())
+	}
+
+	updated, err := h.characterService.UpdateHotwords(c.Request().Context(), id, hotwords)
+	if err != nil {
+		return characterError(c, err, "Failed to update hotwords")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// DeleteHotwords handles DELETE /api/characters/:id/hotwords
+// @Summary 删除角色热词
+// @Description 清空角色的 ASR 热词并删除阿里云热词表
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/hotwords [delete]
+func (h *CharacterHandlers) DeleteHotwords(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	updated, err := h.characterService.DeleteHotwords(c.Request().Context(), id)
+	if err != nil {
+		return characterError(c, err, "Failed to delete hotwords")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// DeleteCharacter handles DELETE /api/characters/:id
+// @Summary 删除角色
+// @Description 软删除角色，30 天内可通过 POST /api/characters/{id}/restore 恢复，之后角色连同热词表和试听音频一起清除；创建角色时复刻的音色在最后一个引用它的角色清除后删除，其他音色保留在音色库中
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id} [delete]
+func (h *CharacterHandlers) DeleteCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	if err := h.characterService.DeleteCharacter(c.Request().Context(), id); err != nil {
+		return characterError(c, err, "Failed to delete character")
+	}
+
+	return domain.Success(c, id)
+}
+
+// UpdateCharacterVoice handles PUT /api/characters/:id/voice
+// @Summary 更换角色音色
+// @Description 通过 voice_id 换成音色库中的音色，无需重新复刻；或通过 audio 用新样本重新复刻角色当前使用的音色，使用该音色的角色都会回到审核中
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body UpdateCharacterVoiceRequest true "音色ID或音频样本URL"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "音频样本不符合要求时 error.issues 列出具体问题"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/voice [put]
+func (h *CharacterHandlers) UpdateCharacterVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody UpdateCharacterVoiceRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+	if (requestBody.VoiceID == "") == (requestBody.Audio == "") {
+		return domain.BadRequest(c, "Invalid request body", "exactly one of voice_id and audio is required")
+	}
+
+	ctx := c.Request().Context()
+	var updated *character.Character
+	if requestBody.VoiceID != "" {
+		voiceID, err := uuid.Parse(requestBody.VoiceID)
+		if err != nil {
+			return domain.BadRequest(c, "Invalid voice ID", err.Error())
+		}
+		updated, err = h.characterService.SetVoice(ctx, id, voiceID)
+	} else {
+		updated, err = h.characterService.UpdateVoiceSample(ctx, id, requestBody.Audio)
+	}
+	if err != nil {
+		return characterError(c, err, "Failed to update character voice")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// RecheckVoice handles POST /api/characters/:id/voice/recheck
+// @Summary 重新检查音色审核状态
+// @Description 立即查询角色所用复刻音色的审核状态，复刻失败的音色回到审核中并重新开始检查
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/voice/recheck [post]
+func (h *CharacterHandlers) RecheckVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	updated, err := h.characterService.RecheckVoice(c.Request().Context(), id)
 	if err != nil {
-		return domain.InternalError(c, "Failed to clone voice and create character", err.Error())
+		return characterVoiceError(c, err, "Failed to recheck voice")
+	}
+
+	return domain.Success(c, updated)
+}
+
+// characterError 把角色操作的错误转换为响应
+func characterError(c echo.Context, err error, message string) error {
+	var validationErr *character.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return domain.ValidationFailed(c, "Invalid character fields", validationErr.Issues, validationErr.Error())
+	case errors.Is(err, character.ErrNameTaken):
+		return domain.Error(c, http.StatusConflict, "NAME_TAKEN", "Character name is already taken", err.Error())
+	case errors.Is(err, voice.ErrVoiceNotFound):
+		// 请求体中引用的音色不存在
+		return domain.BadRequest(c, "Voice not found", err.Error())
+	}
+	return characterVoiceError(c, err, message)
+}
+
+// characterVoiceError 把角色音色操作的错误转换为响应
+func characterVoiceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, character.ErrCharacterNotFound):
+		return domain.NotFound(c, "Character not found", err.Error())
+	case errors.Is(err, character.ErrVersionNotFound):
+		return domain.NotFound(c, "Character version not found", err.Error())
+	case errors.Is(err, character.ErrNoVoice):
+		return domain.BadRequest(c, "Character has no voice from the voice library", err.Error())
 	}
+	return voiceError(c, err, message)
+}
 
-	return domain.Success(c, uuid.Nil)
+// sampleValidationFailed 返回音频样本的校验问题
+func sampleValidationFailed(c echo.Context, sampleErr *voice.SampleError) error {
+	return domain.ValidationFailed(c, "Voice sample does not meet requirements", sampleErr.Issues, sampleErr.Error())
 }
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/labstack/echo/v4"
+)
+
+// ImportCharacter handles POST /api/characters/import
+// @Summary 导入角色卡
+// @Description 从 SillyTavern/TavernAI 角色卡创建角色，支持 V1/V2 JSON 和嵌入角色卡的 PNG，可以用 multipart 字段 file 上传或直接作为请求体发送
+// @Description system_prompt、description、personality、scenario 组成提示词，first_mes 和 alternate_greetings 作为开场白，mes_example 拆分为对话示例，tags 作为角色标签，creator_notes 作为角色描述，PNG 图片作为角色头像
+// @Tags characters
+// @Accept multipart/form-data,json,png
+// @Produce json
+// @Param file formData file false "角色卡文件"
+// @Param visibility query string false "可见性: private/unlisted/public，默认 private"
+// @Success 201 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "角色卡无法解析，或字段不符合要求时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string "角色名已被其他角色使用"
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/import [post]
+func (h *CharacterHandlers) ImportCharacter(c echo.Context) error {
+	data, err := readCardUpload(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return domain.Error(c, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Character card is too large", fmt.Sprintf("max %d MB", character.MaxCardSize>>20))
+		}
+		return domain.BadRequest(c, "Invalid upload", err.Error())
+	}
+
+	created, err := h.characterService.ImportCharacter(c.Request().Context(), data, c.QueryParam("visibility"))
+	if err != nil {
+		if errors.Is(err, character.ErrInvalidCard) {
+			return domain.BadRequest(c, "Invalid character card", err.Error())
+		}
+		return characterError(c, err, "Failed to import character")
+	}
+	return domain.Created(c, created)
+}
+
+// readCardUpload 读取上传的角色卡，multipart 请求读取 file 字段，否则读取整个请求体
+func readCardUpload(c echo.Context) ([]byte, error) {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, character.MaxCardSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	switch {
+	case err == nil:
+		if fileHeader.Size > character.MaxCardSize {
+			return nil, &http.MaxBytesError{Limit: character.MaxCardSize}
+		}
+		f, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(f)
+	case errors.Is(err, http.ErrNotMultipart):
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > character.MaxCardSize {
+			return nil, &http.MaxBytesError{Limit: character.MaxCardSize}
+		}
+		return data, nil
+	default:
+		return nil, err
+	}
+}
+
+// ExportCharacter handles GET /api/characters/:id/export
+// @Summary 导出角色卡
+// @Description 把角色导出为 Character Card V2 JSON，或嵌入角色卡的 PNG 头像；提示词导出为 description，开场白导出为 first_mes 和 alternate_greetings，对话示例导出为 mes_example，标签导出为 tags，角色描述导出为 creator_notes
+// @Description 只有保存在本服务的头像会嵌入 PNG，外部地址的头像使用占位图，原地址保存在 extensions.echome.avatar
+// @Tags characters
+// @Produce json,png
+// @Param id path string true "角色ID"
+// @Param format query string false "导出格式: json/png，默认 json"
+// @Success 200 {object} character.Card
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/export [get]
+func (h *CharacterHandlers) ExportCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	ctx := c.Request().Context()
+	switch format := c.QueryParam("format"); format {
+	case "", "json":
+		card, err := h.characterService.ExportCard(ctx, id)
+		if err != nil {
+			return characterError(c, err, "Failed to export character")
+		}
+		data, err := json.MarshalIndent(card, "", "  ")
+		if err != nil {
+			return domain.InternalError(c, "Failed to export character", err.Error())
+		}
+		setAttachment(c, card.Data.Name+".json")
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, data)
+	case "png":
+		data, err := h.characterService.ExportCardPNG(ctx, id)
+		if err != nil {
+			return characterError(c, err, "Failed to export character")
+		}
+		setAttachment(c, id.String()+".png")
+		return c.Blob(http.StatusOK, "image/png", data)
+	default:
+		return domain.BadRequest(c, "Invalid format", "format must be json or png")
+	}
+}
+
+// setAttachment 设置下载文件名，非 ASCII 文件名按 RFC 2231 编码
+func setAttachment(c echo.Context, filename string) {
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/labstack/echo/v4"
+)
+
+// ForkCharacterRequest 定义复制角色请求体结构
+type ForkCharacterRequest struct {
+	Name       string `json:"name"`       // 可选，新角色名，默认为“来源角色名的副本”
+	Visibility string `json:"visibility"` // 可选，可见性: private/unlisted/public，默认 private
+}
+
+// ForkCharacter handles POST /api/characters/:id/fork
+// @Summary 复制角色
+// @Description 以可见的角色为模板创建归当前用户所有的新角色，复制提示词、描述、头像、开场白、对话示例、热词、标签和音色，forked_from 记录来源角色
+// @Description 新角色沿用来源角色的许可并重新进入审核；其他用户的角色须审核通过且 allow_fork 为 true
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "来源角色ID"
+// @Param request body ForkCharacterRequest false "新角色的名称和可见性"
+// @Success 201 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "来源角色不可用或不允许复制"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "角色名已被使用"
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/fork [post]
+func (h *CharacterHandlers) ForkCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody ForkCharacterRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	forked, err := h.characterService.ForkCharacter(c.Request().Context(), id, character.ForkRequest{
+		Name:       requestBody.Name,
+		Visibility: requestBody.Visibility,
+	})
+	switch {
+	case errors.Is(err, character.ErrForkNotAllowed):
+		return domain.Forbidden(c, "Character does not allow forking", err.Error())
+	case errors.Is(err, character.ErrCharacterUnavailable):
+		return domain.Forbidden(c, "Character is not available", err.Error())
+	case err != nil:
+		return characterError(c, err, "Failed to fork character")
+	}
+	return domain.Created(c, forked)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/labstack/echo/v4"
+)
+
+// ApproveCharacterRequest 定义审核通过请求体结构
+type ApproveCharacterRequest struct {
+	Version *int32  `json:"version"` // 可选，审核的角色版本号，与当前版本不一致时返回409
+	Reason  *string `json:"reason"`  // 可选，审核意见，最多500字
+}
+
+// RejectCharacterRequest 定义驳回请求体结构
+type RejectCharacterRequest struct {
+	Version *int32 `json:"version"` // 可选，审核的角色版本号，与当前版本不一致时返回409
+	Reason  string `json:"reason"`  // 必须，驳回原因，最多500字，所有者可以看到
+}
+
+// GetPendingReviews handles GET /api/admin/characters/pending
+// @Summary 获取待审核角色
+// @Description 分页获取等待内容审核的角色，包括所有用户的角色，按创建时间倒序；只有管理员可以调用
+// @Description 新建的角色和修改过内容的角色进入待审核，可见性和热词的修改不需要重新审核
+// @Tags reviews
+// @Produce json
+// @Param q query string false "检索词"
+// @Param owner query string false "所有者的用户ID"
+// @Param cloned query bool false "是否使用复刻音色"
+// @Param tag query string false "只返回带有该标签的角色"
+// @Param limit query int false "每页数量，默认20，最多100"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Success 200 {object} character.Page
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/characters/pending [get]
+func (h *CharacterHandlers) GetPendingReviews(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return domain.Unauthorized(c, "Authentication required", err.Error())
+		}
+		return domain.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	page, err := h.characterService.ListPendingReviews(c.Request().Context(), query)
+	if err != nil {
+		if errors.Is(err, character.ErrInvalidSort) {
+			return domain.BadRequest(c, "Invalid query parameters", err.Error())
+		}
+		return voiceError(c, err, "Failed to get pending characters")
+	}
+	return domain.Success(c, page)
+}
+
+// ApproveCharacter handles POST /api/admin/characters/:id/approve
+// @Summary 审核通过角色
+// @Description 内容审核通过，音色也可用时角色变为可用；审核决定记录在角色的审核记录中，只有管理员可以调用
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body ApproveCharacterRequest false "审核意见"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "角色在审核期间被修改过"
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/characters/{id}/approve [post]
+func (h *CharacterHandlers) ApproveCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody ApproveCharacterRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	approved, err := h.characterService.ApproveCharacter(c.Request().Context(), id, requestBody.Version, requestBody.Reason)
+	if err != nil {
+		return reviewError(c, err, "Failed to approve character")
+	}
+	return domain.Success(c, approved)
+}
+
+// RejectCharacter handles POST /api/admin/characters/:id/reject
+// @Summary 驳回角色
+// @Description 内容审核驳回，角色状态变为6，所有者修改内容后重新进入审核；审核决定记录在角色的审核记录中，只有管理员可以调用
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body RejectCharacterRequest true "驳回原因"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "未填写原因时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "角色在审核期间被修改过"
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/characters/{id}/reject [post]
+func (h *CharacterHandlers) RejectCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody RejectCharacterRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	rejected, err := h.characterService.RejectCharacter(c.Request().Context(), id, requestBody.Version, requestBody.Reason)
+	if err != nil {
+		return reviewError(c, err, "Failed to reject character")
+	}
+	return domain.Success(c, rejected)
+}
+
+// GetCharacterReviews handles GET /api/characters/:id/reviews
+// @Summary 获取角色审核记录
+// @Description 获取角色每次审核通过或驳回的记录，按时间倒序，只有所有者可以查看
+// @Tags reviews
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {array} character.Review
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/reviews [get]
+func (h *CharacterHandlers) GetCharacterReviews(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	reviews, err := h.characterService.ListReviews(c.Request().Context(), id)
+	if err != nil {
+		return characterError(c, err, "Failed to get character reviews")
+	}
+	return domain.Success(c, reviews)
+}
+
+// reviewError 把审核操作的错误转换为响应
+func reviewError(c echo.Context, err error, message string) error {
+	var validationErr *character.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return domain.ValidationFailed(c, "Invalid review fields", validationErr.Issues, validationErr.Error())
+	case errors.Is(err, character.ErrReviewStale):
+		return domain.Error(c, http.StatusConflict, "REVIEW_STALE", "Character has changed since the reviewed version", err.Error())
+	}
+	return characterVoiceError(c, err, message)
+}
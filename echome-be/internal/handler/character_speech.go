@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/justin/echome-be/internal/domain/speech"
+	"github.com/labstack/echo/v4"
+)
+
+// PreviewSpeechRequest 定义试听语音合成参数请求体结构
+type PreviewSpeechRequest struct {
+	// 必须，要试听的语音合成参数，不会保存
+	Speech character.SpeechSettings `json:"speech"`
+	// 可选，试听文本，最多200字，默认为角色的开场白或试听台词
+	Text string `json:"text"`
+}
+
+// PreviewCharacterSpeech handles POST /api/characters/:id/voice/preview
+// @Summary 试听语音合成参数
+// @Description 用尚未保存的语速、语调、音量和模型合成角色的试听音频，返回 mp3，调好后通过更新角色接口保存到 speech 字段
+// @Tags characters
+// @Accept json
+// @Produce audio/mpeg
+// @Param id path string true "角色ID"
+// @Param request body PreviewSpeechRequest true "试听参数"
+// @Success 200 {file} binary
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/voice/preview [post]
+func (h *CharacterHandlers) PreviewCharacterSpeech(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody PreviewSpeechRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	data, err := h.characterService.PreviewSpeech(c.Request().Context(), id, character.SpeechPreviewRequest{
+		Speech: requestBody.Speech,
+		Text:   requestBody.Text,
+	})
+	if err != nil {
+		return characterError(c, err, "Failed to preview speech")
+	}
+	return c.Blob(http.StatusOK, speech.ContentType(ai.AudioFormatMP3), data)
+}
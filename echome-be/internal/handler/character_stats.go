@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/labstack/echo/v4"
+)
+
+// GetCharacterStats handles GET /api/characters/:id/stats
+// @Summary 获取角色使用统计
+// @Description 获取角色的语音对话次数、对话轮数、语音时长、用户数和收藏数，以及最近若干天每天的统计，只有所有者可以查看
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param days query int false "每天统计的天数，包括今天，默认30，最多90"
+// @Success 200 {object} character.Stats
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/stats [get]
+func (h *CharacterHandlers) GetCharacterStats(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var days int
+	if value := c.QueryParam("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days <= 0 {
+			return domain.BadRequest(c, "Invalid query parameters", fmt.Sprintf("invalid days: %s", value))
+		}
+	}
+
+	stats, err := h.characterService.GetStats(c.Request().Context(), id, days)
+	if err != nil {
+		return characterError(c, err, "Failed to get character stats")
+	}
+	return domain.Success(c, stats)
+}
+
+// FavoriteCharacter handles PUT /api/characters/:id/favorite
+// @Summary 收藏角色
+// @Description 收藏当前用户可见的角色，重复收藏不报错
+// @Tags favorites
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/favorite [put]
+func (h *CharacterHandlers) FavoriteCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	favorited, err := h.characterService.FavoriteCharacter(c.Request().Context(), id)
+	if err != nil {
+		return characterError(c, err, "Failed to favorite character")
+	}
+	return domain.Success(c, favorited)
+}
+
+// UnfavoriteCharacter handles DELETE /api/characters/:id/favorite
+// @Summary 取消收藏角色
+// @Description 取消收藏，未收藏时不报错
+// @Tags favorites
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/favorite [delete]
+func (h *CharacterHandlers) UnfavoriteCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	if err := h.characterService.UnfavoriteCharacter(c.Request().Context(), id); err != nil {
+		return characterError(c, err, "Failed to unfavorite character")
+	}
+	return domain.Success(c, id)
+}
+
+// GetFavorites handles GET /api/me/favorites
+// @Summary 获取我收藏的角色
+// @Description 分页获取当前用户收藏的角色，包括所有状态，已对当前用户不可见的角色不返回
+// @Tags favorites
+// @Produce json
+// @Param q query string false "检索词"
+// @Param tag query string false "只返回带有该标签的角色"
+// @Param sort query string false "排序方式: newest, popular，默认newest"
+// @Param limit query int false "每页数量，默认20，最多100"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Success 200 {object} character.Page
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me/favorites [get]
+func (h *CharacterHandlers) GetFavorites(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return domain.Unauthorized(c, "Authentication required", err.Error())
+		}
+		return domain.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	page, err := h.characterService.ListFavorites(c.Request().Context(), query)
+	if err != nil {
+		if errors.Is(err, character.ErrInvalidSort) {
+			return domain.BadRequest(c, "Invalid query parameters", err.Error())
+		}
+		return voiceError(c, err, "Failed to get favorite characters")
+	}
+	return domain.Success(c, page)
+}
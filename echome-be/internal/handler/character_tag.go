@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateTagRequest 定义创建标签请求体结构
+type CreateTagRequest struct {
+	Name      string `json:"name"`       // 必须，标签名，最多20字，保存时转换为小写
+	Category  bool   `json:"category"`   // 可选，是否为精选分类
+	SortOrder int32  `json:"sort_order"` // 可选，分类的展示顺序，从小到大
+}
+
+// UpdateTagRequest 定义修改标签请求体结构，只修改出现的字段
+type UpdateTagRequest struct {
+	Name      character.Optional[string] `json:"name" swaggertype:"string"`
+	Category  character.Optional[bool]   `json:"category" swaggertype:"boolean"`
+	SortOrder character.Optional[int32]  `json:"sort_order" swaggertype:"integer"`
+}
+
+// SetCharacterTagsRequest 定义设置角色标签请求体结构
+type SetCharacterTagsRequest struct {
+	Tags []string `json:"tags"` // 最多10个，不存在的标签自动创建
+}
+
+// GetTags handles GET /api/tags
+// @Summary 获取标签
+// @Description 获取所有标签，精选分类按展示顺序排在前面，其余按名称排序
+// @Tags tags
+// @Produce json
+// @Param category query bool false "为 true 时只返回精选分类"
+// @Success 200 {array} character.Tag
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags [get]
+func (h *CharacterHandlers) GetTags(c echo.Context) error {
+	var categoriesOnly bool
+	if category := c.QueryParam("category"); category != "" {
+		value, err := strconv.ParseBool(category)
+		if err != nil {
+			return domain.BadRequest(c, "Invalid query parameters", "invalid category: "+category)
+		}
+		categoriesOnly = value
+	}
+
+	tags, err := h.characterService.ListTags(c.Request().Context(), categoriesOnly)
+	if err != nil {
+		return domain.InternalError(c, "Failed to get tags", err.Error())
+	}
+	return domain.Success(c, tags)
+}
+
+// CreateTag handles POST /api/tags
+// @Summary 创建标签
+// @Description 创建标签或精选分类，只有管理员可以调用
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body CreateTagRequest true "标签"
+// @Success 201 {object} character.Tag
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 409 {object} map[string]string "同名标签已存在"
+// @Failure 500 {object} map[string]string
+// @Router /api/tags [post]
+func (h *CharacterHandlers) CreateTag(c echo.Context) error {
+	var requestBody CreateTagRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	tag, err := h.characterService.CreateTag(c.Request().Context(), &character.Tag{
+		Name:      requestBody.Name,
+		Category:  requestBody.Category,
+		SortOrder: requestBody.SortOrder,
+	})
+	if err != nil {
+		return tagError(c, err, "Failed to create tag")
+	}
+	return domain.Created(c, tag)
+}
+
+// UpdateTag handles PATCH /api/tags/:id
+// @Summary 修改标签
+// @Description 修改标签名、是否为精选分类及展示顺序，只有管理员可以调用
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "标签ID"
+// @Param request body UpdateTagRequest true "要修改的字段"
+// @Success 200 {object} character.Tag
+// @Failure 400 {object} domain.APIResponse "字段校验失败时 error.issues 列出具体字段"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "同名标签已存在"
+// @Failure 500 {object} map[string]string
+// @Router /api/tags/{id} [patch]
+func (h *CharacterHandlers) UpdateTag(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid tag ID", err.Error())
+	}
+
+	var requestBody UpdateTagRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	tag, err := h.characterService.UpdateTag(c.Request().Context(), id, character.TagPatch{
+		Name:      requestBody.Name,
+		Category:  requestBody.Category,
+		SortOrder: requestBody.SortOrder,
+	})
+	if err != nil {
+		return tagError(c, err, "Failed to update tag")
+	}
+	return domain.Success(c, tag)
+}
+
+// DeleteTag handles DELETE /api/tags/:id
+// @Summary 删除标签
+// @Description 删除标签并从所有角色上移除，只有管理员可以调用
+// @Tags tags
+// @Produce json
+// @Param id path string true "标签ID"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是管理员"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags/{id} [delete]
+func (h *CharacterHandlers) DeleteTag(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid tag ID", err.Error())
+	}
+
+	if err := h.characterService.DeleteTag(c.Request().Context(), id); err != nil {
+		return tagError(c, err, "Failed to delete tag")
+	}
+	return domain.Success(c, id)
+}
+
+// SetCharacterTags handles PUT /api/characters/:id/tags
+// @Summary 设置角色标签
+// @Description 覆盖角色的标签，空列表表示清空；标签名保存时转换为小写，不存在的标签自动创建
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param request body SetCharacterTagsRequest true "标签列表"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} domain.APIResponse "标签不符合要求时 error.issues 列出具体问题"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/tags [put]
+func (h *CharacterHandlers) SetCharacterTags(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	var requestBody SetCharacterTagsRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	updated, err := h.characterService.SetCharacterTags(c.Request().Context(), id, requestBody.Tags)
+	if err != nil {
+		return characterError(c, err, "Failed to update character tags")
+	}
+	return domain.Success(c, updated)
+}
+
+// tagError 把标签操作的错误转换为响应
+func tagError(c echo.Context, err error, message string) error {
+	var validationErr *character.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return domain.ValidationFailed(c, "Invalid tag fields", validationErr.Issues, validationErr.Error())
+	case errors.Is(err, character.ErrTagNotFound):
+		return domain.NotFound(c, "Tag not found", err.Error())
+	case errors.Is(err, character.ErrTagExists):
+		return domain.Error(c, http.StatusConflict, "TAG_EXISTS", "Tag already exists", err.Error())
+	}
+	return voiceError(c, err, message)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// GetCharacterVersions handles GET /api/characters/:id/versions
+// @Summary 获取角色历史版本
+// @Description 列出角色每次修改后保存的版本，按版本号倒序，只有角色所有者可以查看
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {array} character.Version
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/versions [get]
+func (h *CharacterHandlers) GetCharacterVersions(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+
+	versions, err := h.characterService.ListVersions(c.Request().Context(), id)
+	if err != nil {
+		return characterError(c, err, "Failed to get character versions")
+	}
+	return domain.Success(c, versions)
+}
+
+// GetCharacterVersion handles GET /api/characters/:id/versions/:version
+// @Summary 获取角色的指定版本
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param version path int true "版本号"
+// @Success 200 {object} character.Version
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/versions/{version} [get]
+func (h *CharacterHandlers) GetCharacterVersion(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+	version, err := parseVersion(c.Param("version"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid version", err.Error())
+	}
+
+	v, err := h.characterService.GetVersion(c.Request().Context(), id, version)
+	if err != nil {
+		return characterError(c, err, "Failed to get character version")
+	}
+	return domain.Success(c, v)
+}
+
+// DiffCharacterVersions handles GET /api/characters/:id/versions/diff
+// @Summary 比较角色的两个版本
+// @Description 返回两个版本之间有变化的字段，提示词和描述附带逐行差异
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param from query int true "旧版本号"
+// @Param to query int true "新版本号"
+// @Success 200 {object} character.VersionDiff
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/versions/diff [get]
+func (h *CharacterHandlers) DiffCharacterVersions(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+	from, err := parseVersion(c.QueryParam("from"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid from version", err.Error())
+	}
+	to, err := parseVersion(c.QueryParam("to"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid to version", err.Error())
+	}
+
+	diff, err := h.characterService.DiffVersions(c.Request().Context(), id, from, to)
+	if err != nil {
+		return characterError(c, err, "Failed to diff character versions")
+	}
+	return domain.Success(c, diff)
+}
+
+// RollbackCharacter handles POST /api/characters/:id/versions/:version/rollback
+// @Summary 回滚角色
+// @Description 把角色恢复为指定版本的内容，包括热词和音色；回滚会产生一个新版本，历史版本保持不变
+// @Tags characters
+// @Produce json
+// @Param id path string true "角色ID"
+// @Param version path int true "要恢复的版本号"
+// @Success 200 {object} character.Character
+// @Failure 400 {object} map[string]string "版本引用的音色已被删除或不可用"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是角色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "角色名已被其他角色使用"
+// @Failure 500 {object} map[string]string
+// @Router /api/characters/{id}/versions/{version}/rollback [post]
+func (h *CharacterHandlers) RollbackCharacter(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid character ID", err.Error())
+	}
+	version, err := parseVersion(c.Param("version"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid version", err.Error())
+	}
+
+	rolledBack, err := h.characterService.RollbackCharacter(c.Request().Context(), id, version)
+	if err != nil {
+		return characterError(c, err, "Failed to roll back character")
+	}
+	return domain.Success(c, rolledBack)
+}
+
+// parseVersion 解析版本号，版本号从1开始
+func parseVersion(s string) (int32, error) {
+	version, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version: %q", s)
+	}
+	return int32(version), nil
+}
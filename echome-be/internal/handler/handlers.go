@@ -4,6 +4,9 @@ import (
 	"github.com/justin/echome-be/internal/domain/ai"
 	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/justin/echome-be/internal/domain/conversation"
+	"github.com/justin/echome-be/internal/domain/speech"
+	"github.com/justin/echome-be/internal/domain/transcription"
+	"github.com/justin/echome-be/internal/domain/voice"
 	"github.com/labstack/echo/v4"
 )
 
@@ -12,8 +15,8 @@ type Handlers struct {
 }
 
 // NewHandlers
-func NewHandlers(characterService *character.CharacterService, aiService ai.Repo, conversationService *conversation.ConversationService) *Handlers {
-	router := NewRouter(characterService, aiService, conversationService)
+func NewHandlers(characterService *character.CharacterService, aiService ai.Repo, conversationService *conversation.ConversationService, transcriptionService *transcription.TranscriptionService, speechService *speech.SpeechService, voiceService *voice.VoiceService) *Handlers {
+	router := NewRouter(characterService, aiService, conversationService, transcriptionService, speechService, voiceService)
 	return &Handlers{
 		router: router,
 	}
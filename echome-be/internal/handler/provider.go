@@ -11,5 +11,8 @@ var (
 		NewHandlers,
 		NewCharacterHandlers,
 		NewWebSocketHandlers,
+		NewTranscriptionHandlers,
+		NewTTSHandlers,
+		NewVoiceHandlers,
 	)
 )
@@ -4,12 +4,18 @@ import (
 	"github.com/justin/echome-be/internal/domain/ai"
 	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/justin/echome-be/internal/domain/conversation"
+	"github.com/justin/echome-be/internal/domain/speech"
+	"github.com/justin/echome-be/internal/domain/transcription"
+	"github.com/justin/echome-be/internal/domain/voice"
 	"github.com/labstack/echo/v4"
 )
 
 type Router struct {
-	characterHandlers *CharacterHandlers
-	webSocketHandlers *WebSocketHandlers
+	characterHandlers     *CharacterHandlers
+	webSocketHandlers     *WebSocketHandlers
+	transcriptionHandlers *TranscriptionHandlers
+	ttsHandlers           *TTSHandlers
+	voiceHandlers         *VoiceHandlers
 }
 
 // NewRouter 创建路由
@@ -17,10 +23,16 @@ func NewRouter(
 	characterService *character.CharacterService,
 	aiClient ai.Repo,
 	conversationService *conversation.ConversationService,
+	transcriptionService *transcription.TranscriptionService,
+	speechService *speech.SpeechService,
+	voiceService *voice.VoiceService,
 ) *Router {
 	return &Router{
-		characterHandlers: NewCharacterHandlers(characterService),
-		webSocketHandlers: NewWebSocketHandlers(aiClient, conversationService),
+		characterHandlers:     NewCharacterHandlers(characterService),
+		webSocketHandlers:     NewWebSocketHandlers(aiClient, conversationService),
+		transcriptionHandlers: NewTranscriptionHandlers(transcriptionService),
+		ttsHandlers:           NewTTSHandlers(speechService),
+		voiceHandlers:         NewVoiceHandlers(voiceService),
 	}
 }
 
@@ -31,6 +43,20 @@ func (r *Router) RegisterAllRoutes(e *echo.Echo) {
 
 	// 注册 WebSocket 路由
 	r.webSocketHandlers.RegisterRoutes(e)
+
+	// 注册转写路由
+	r.transcriptionHandlers.RegisterRoutes(e)
+
+	// 注册文本转语音路由
+	r.ttsHandlers.RegisterRoutes(e)
+
+	// 注册音色路由
+	r.voiceHandlers.RegisterRoutes(e)
+}
+
+// GetVoiceService 获取音色服务
+func (r *Router) GetVoiceService() *voice.VoiceService {
+	return r.voiceHandlers.voiceService
 }
 
 // GetCharacterService 获取角色服务
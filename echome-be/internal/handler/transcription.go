@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/transcription"
+	"github.com/labstack/echo/v4"
+)
+
+// maxTranscriptionUploadSize 上传音频文件的大小上限
+const maxTranscriptionUploadSize = 200 << 20
+
+// CreateTranscriptionRequest 定义通过URL创建转写任务的请求体结构
+type CreateTranscriptionRequest struct {
+	URL string `json:"url" form:"url"` // 必须，可公开访问的音频地址
+}
+
+type TranscriptionHandlers struct {
+	transcriptionService *transcription.TranscriptionService
+}
+
+func NewTranscriptionHandlers(transcriptionService *transcription.TranscriptionService) *TranscriptionHandlers {
+	return &TranscriptionHandlers{
+		transcriptionService: transcriptionService,
+	}
+}
+
+// RegisterRoutes 注册转写相关路由
+func (h *TranscriptionHandlers) RegisterRoutes(e *echo.Echo) {
+	e.POST("/api/transcriptions", h.CreateTranscription)
+	e.GET("/api/transcriptions/:id", h.GetTranscription)
+	e.GET("/api/transcriptions/:id/subtitles", h.GetSubtitles)
+}
+
+// CreateTranscription handles POST /api/transcriptions
+// @Summary 创建文件转写任务
+// @Description 上传音频文件（multipart 字段 file，支持 wav/mp3/m4a）或提交 JSON {"url": "..."}，返回异步任务，通过 GET /api/transcriptions/{id} 轮询结果
+// @Tags transcriptions
+// @Accept multipart/form-data,json
+// @Produce json
+// @Param file formData file false "音频文件"
+// @Param format formData string false "音频格式，默认取文件扩展名"
+// @Param request body CreateTranscriptionRequest false "音频URL"
+// @Success 202 {object} transcription.Job
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/transcriptions [post]
+func (h *TranscriptionHandlers) CreateTranscription(c echo.Context) error {
+	req := c.Request()
+	ctx := req.Context()
+	// 先限制请求体大小，FormFile 会在检查文件大小之前解析并暂存整个请求体，预留 1MB 给其他表单字段
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxTranscriptionUploadSize+1<<20)
+
+	var job *transcription.Job
+	var maxBytesErr *http.MaxBytesError
+	fileHeader, err := c.FormFile("file")
+	switch {
+	case err == nil && fileHeader.Size > maxTranscriptionUploadSize, errors.As(err, &maxBytesErr):
+		return domain.BadRequest(c, "File too large", fmt.Sprintf("max %d MB", maxTranscriptionUploadSize>>20))
+	case err == nil:
+		format := c.FormValue("format")
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(fileHeader.Filename), ".")
+		}
+		path, err := saveUploadToTemp(fileHeader)
+		if err != nil {
+			return domain.InternalError(c, "Failed to save upload", err.Error())
+		}
+		job, err = h.transcriptionService.SubmitFile(ctx, fileHeader.Filename, format, path)
+		if err != nil {
+			return transcriptionError(c, err)
+		}
+	case errors.Is(err, http.ErrMissingFile), errors.Is(err, http.ErrNotMultipart):
+		var requestBody CreateTranscriptionRequest
+		if err := c.Bind(&requestBody); err != nil {
+			return domain.BadRequest(c, "Invalid request body", err.Error())
+		}
+		job, err = h.transcriptionService.SubmitURL(ctx, requestBody.URL)
+		if err != nil {
+			return transcriptionError(c, err)
+		}
+	default:
+		return domain.BadRequest(c, "Invalid upload", err.Error())
+	}
+
+	return domain.Accepted(c, job)
+}
+
+// GetTranscription handles GET /api/transcriptions/:id
+// @Summary 查询转写任务
+// @Description 查询转写任务状态，完成后返回全文和句子时间戳
+// @Tags transcriptions
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} transcription.Job
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/transcriptions/{id} [get]
+func (h *TranscriptionHandlers) GetTranscription(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid transcription ID", err.Error())
+	}
+
+	job, err := h.transcriptionService.GetJob(c.Request().Context(), id)
+	if err != nil {
+		return jobLookupError(c, err)
+	}
+	return domain.Success(c, job)
+}
+
+// GetSubtitles handles GET /api/transcriptions/:id/subtitles
+// @Summary 导出字幕
+// @Description 以 SRT 或 WebVTT 格式导出已完成任务的字幕
+// @Tags transcriptions
+// @Produce plain
+// @Param id path string true "任务ID"
+// @Param format query string false "字幕格式: srt/vtt，默认srt"
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/transcriptions/{id}/subtitles [get]
+func (h *TranscriptionHandlers) GetSubtitles(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid transcription ID", err.Error())
+	}
+
+	job, err := h.transcriptionService.GetJob(c.Request().Context(), id)
+	if err != nil {
+		return jobLookupError(c, err)
+	}
+	if job.Status != transcription.StatusSucceeded || job.Result == nil {
+		return domain.Error(c, http.StatusConflict, "NOT_READY", "Transcription not finished", string(job.Status))
+	}
+
+	name := job.ID.String()
+	switch c.QueryParam("format") {
+	case "", transcription.SubtitleSRT:
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name+".srt"))
+		return c.Blob(http.StatusOK, "application/x-subrip; charset=utf-8", []byte(transcription.FormatSRT(job.Result.Sentences)))
+	case transcription.SubtitleVTT:
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name+".vtt"))
+		return c.Blob(http.StatusOK, "text/vtt; charset=utf-8", []byte(transcription.FormatVTT(job.Result.Sentences)))
+	default:
+		return domain.BadRequest(c, "Invalid subtitle format", "supported: srt, vtt")
+	}
+}
+
+// jobLookupError 把查询任务的错误转换为响应
+func jobLookupError(c echo.Context, err error) error {
+	if errors.Is(err, transcription.ErrJobNotFound) {
+		return domain.NotFound(c, "Transcription not found", err.Error())
+	}
+	return domain.InternalError(c, "Failed to get transcription", err.Error())
+}
+
+// transcriptionError 把提交任务的错误转换为响应
+func transcriptionError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, transcription.ErrInvalidURL),
+		errors.Is(err, transcription.ErrUnsupportedFormat),
+		errors.Is(err, transcription.ErrPublicStorageRequired):
+		return domain.BadRequest(c, "Invalid transcription request", err.Error())
+	default:
+		return domain.InternalError(c, "Failed to create transcription", err.Error())
+	}
+}
+
+// saveUploadToTemp 把上传文件复制到临时文件，请求结束后 multipart 临时文件会被清理
+func saveUploadToTemp(fileHeader *multipart.FileHeader) (string, error) {
+	src, err := fileHeader.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp("", "echome-upload-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+	return dst.Name(), nil
+}
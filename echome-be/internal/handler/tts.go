@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/justin/echome-be/internal/domain/speech"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// SynthesizeSpeechRequest 定义文本转语音的请求体结构
+type SynthesizeSpeechRequest struct {
+	Text        string  `json:"text"`                   // 必须，最多2000字
+	CharacterID string  `json:"character_id,omitempty"` // 可选，使用角色的音色，与 voice_id、voice 三选一
+	VoiceID     string  `json:"voice_id,omitempty"`     // 可选，使用音色库中的音色
+	Voice       string  `json:"voice,omitempty"`        // 可选，模型自带的音色名
+	Format      string  `json:"format,omitempty"`       // 可选，pcm/wav/mp3/opus，默认mp3
+	SampleRate  int     `json:"sample_rate,omitempty"`  // 可选，输出采样率
+	Speed       float64 `json:"speed,omitempty"`        // 可选，语速 0.5~2.0，默认1.0
+	Stream      bool    `json:"stream,omitempty"`       // 可选，为 true 时以分块传输边合成边返回
+}
+
+type TTSHandlers struct {
+	speechService *speech.SpeechService
+}
+
+func NewTTSHandlers(speechService *speech.SpeechService) *TTSHandlers {
+	return &TTSHandlers{
+		speechService: speechService,
+	}
+}
+
+// RegisterRoutes 注册文本转语音路由
+func (h *TTSHandlers) RegisterRoutes(e *echo.Echo) {
+	e.POST("/api/tts", h.SynthesizeSpeech)
+}
+
+// SynthesizeSpeech handles POST /api/tts
+// @Summary 文本转语音
+// @Description 合成一段文本，默认返回完整音频文件；stream 为 true 时以分块传输边合成边返回
+// @Tags tts
+// @Accept json
+// @Produce audio/mpeg,audio/wav,audio/ogg,application/octet-stream
+// @Param request body SynthesizeSpeechRequest true "合成参数"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "角色尚未审核通过"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tts [post]
+func (h *TTSHandlers) SynthesizeSpeech(c echo.Context) error {
+	var requestBody SynthesizeSpeechRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	req := &speech.SynthesisRequest{
+		Text:       requestBody.Text,
+		Voice:      requestBody.Voice,
+		Format:     requestBody.Format,
+		SampleRate: requestBody.SampleRate,
+		Speed:      requestBody.Speed,
+	}
+	if requestBody.CharacterID != "" {
+		id, err := uuid.Parse(requestBody.CharacterID)
+		if err != nil {
+			return domain.BadRequest(c, "Invalid character ID", err.Error())
+		}
+		req.CharacterID = id
+	}
+	if requestBody.VoiceID != "" {
+		id, err := uuid.Parse(requestBody.VoiceID)
+		if err != nil {
+			return domain.BadRequest(c, "Invalid voice ID", err.Error())
+		}
+		req.VoiceID = id
+	}
+
+	ctx := c.Request().Context()
+	if !requestBody.Stream {
+		data, err := h.speechService.Synthesize(ctx, req)
+		if err != nil {
+			return speechError(c, err)
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", "speech."+req.Format))
+		return c.Blob(http.StatusOK, speech.ContentType(req.Format), data)
+	}
+
+	w := &chunkedAudioWriter{c: c, req: req}
+	if err := h.speechService.Stream(ctx, w, req); err != nil {
+		if !w.started {
+			return speechError(c, err)
+		}
+		// 响应头已经发出，只能中断传输
+		zap.L().Error("流式合成语音失败", zap.Error(err))
+		return nil
+	}
+	if !w.started {
+		return domain.InternalError(c, "Failed to synthesize speech", "no audio returned")
+	}
+	return nil
+}
+
+// chunkedAudioWriter 在第一次写入时发送响应头，之后每次写入后立即刷新，由 HTTP 分块传输返回
+// 合成在写出音频之前失败时仍然可以返回 JSON 错误
+type chunkedAudioWriter struct {
+	c       echo.Context
+	req     *speech.SynthesisRequest
+	started bool
+}
+
+func (w *chunkedAudioWriter) Write(p []byte) (int, error) {
+	resp := w.c.Response()
+	if !w.started {
+		w.started = true
+		resp.Header().Set(echo.HeaderContentType, speech.ContentType(w.req.Format))
+		resp.WriteHeader(http.StatusOK)
+	}
+	n, err := resp.Write(p)
+	if err != nil {
+		return n, err
+	}
+	resp.Flush()
+	return n, nil
+}
+
+// speechError 把合成错误转换为响应
+func speechError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, character.ErrCharacterNotFound):
+		return domain.NotFound(c, "Character not found", err.Error())
+	case errors.Is(err, character.ErrCharacterUnavailable):
+		return domain.Forbidden(c, "Character is not available", err.Error())
+	case errors.Is(err, voice.ErrVoiceNotFound):
+		return domain.NotFound(c, "Voice not found", err.Error())
+	case errors.Is(err, speech.ErrEmptyText),
+		errors.Is(err, speech.ErrTextTooLong),
+		errors.Is(err, speech.ErrVoiceConflict),
+		errors.Is(err, speech.ErrInvalidOutput):
+		return domain.BadRequest(c, "Invalid speech request", err.Error())
+	default:
+		return domain.InternalError(c, "Failed to synthesize speech", err.Error())
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateVoiceRequest 定义创建音色请求体结构
+type CreateVoiceRequest struct {
+	Name      string `json:"name"`       // 必须，音色名
+	Provider  string `json:"provider"`   // 可选，builtin/clone，默认按是否提供 sample_url 推断
+	Voice     string `json:"voice"`      // builtin 必须，模型自带的音色名
+	Model     string `json:"model"`      // 可选，合成使用的模型，默认 cosyvoice-v2
+	SampleURL string `json:"sample_url"` // clone 必须，复刻使用的音频样本URL
+}
+
+// UpdateVoiceRequest 定义更新音色请求体结构，省略的字段保持不变
+type UpdateVoiceRequest struct {
+	Name      *string `json:"name"`       // 可选，新的音色名
+	SampleURL *string `json:"sample_url"` // 可选，新的音频样本URL，会重新复刻音色
+}
+
+type VoiceHandlers struct {
+	voiceService *voice.VoiceService
+}
+
+func NewVoiceHandlers(voiceService *voice.VoiceService) *VoiceHandlers {
+	return &VoiceHandlers{
+		voiceService: voiceService,
+	}
+}
+
+// RegisterRoutes 注册音色相关路由
+func (h *VoiceHandlers) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/voices", h.GetVoices)
+	e.POST("/api/voices", h.CreateVoice)
+	e.GET("/api/voices/:id", h.GetVoice)
+	e.PATCH("/api/voices/:id", h.UpdateVoice)
+	e.DELETE("/api/voices/:id", h.DeleteVoice)
+	e.POST("/api/voices/:id/recheck", h.RecheckVoice)
+}
+
+// GetVoices handles GET /api/voices
+// @Summary 获取音色库
+// @Description 列出音色库中的模型自带音色和当前用户自己的复刻音色，管理员可以看到所有音色
+// @Tags voices
+// @Produce json
+// @Success 200 {array} voice.Voice
+// @Failure 500 {object} map[string]string
+// @Router /api/voices [get]
+func (h *VoiceHandlers) GetVoices(c echo.Context) error {
+	voices, err := h.voiceService.ListVoices(c.Request().Context())
+	if err != nil {
+		return domain.InternalError(c, "Failed to get voices", err.Error())
+	}
+	return domain.Success(c, voices)
+}
+
+// CreateVoice handles POST /api/voices
+// @Summary 创建音色
+// @Description 登记模型自带音色，或用音频样本复刻新音色；复刻音色审核通过前处于 pending 状态
+// @Tags voices
+// @Accept json
+// @Produce json
+// @Param request body CreateVoiceRequest true "音色参数"
+// @Success 201 {object} voice.Voice
+// @Failure 400 {object} domain.APIResponse "音频样本不符合要求时 error.issues 列出具体问题"
+// @Failure 500 {object} map[string]string
+// @Router /api/voices [post]
+func (h *VoiceHandlers) CreateVoice(c echo.Context) error {
+	var requestBody CreateVoiceRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	ctx := c.Request().Context()
+	created, err := h.voiceService.CreateVoice(ctx, voice.CreateRequest{
+		Name:      requestBody.Name,
+		OwnerID:   userID(ctx),
+		Provider:  requestBody.Provider,
+		Voice:     requestBody.Voice,
+		Model:     requestBody.Model,
+		SampleURL: requestBody.SampleURL,
+	})
+	if err != nil {
+		return voiceError(c, err, "Failed to create voice")
+	}
+	return domain.Created(c, created)
+}
+
+// GetVoice handles GET /api/voices/:id
+// @Summary 获取音色详情
+// @Description 其他用户的复刻音色按不存在处理
+// @Tags voices
+// @Produce json
+// @Param id path string true "音色ID"
+// @Success 200 {object} voice.Voice
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/voices/{id} [get]
+func (h *VoiceHandlers) GetVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid voice ID", err.Error())
+	}
+
+	v, err := h.voiceService.UsableVoice(c.Request().Context(), id)
+	if err != nil {
+		return voiceError(c, err, "Failed to get voice")
+	}
+	return domain.Success(c, v)
+}
+
+// UpdateVoice handles PATCH /api/voices/:id
+// @Summary 更新音色
+// @Description 修改音色名，或为复刻音色更换音频样本；更换样本后音色及使用它的角色回到审核中。只有所有者可以修改
+// @Tags voices
+// @Accept json
+// @Produce json
+// @Param id path string true "音色ID"
+// @Param request body UpdateVoiceRequest true "要修改的字段"
+// @Success 200 {object} voice.Voice
+// @Failure 400 {object} domain.APIResponse "音频样本不符合要求时 error.issues 列出具体问题"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是音色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/voices/{id} [patch]
+func (h *VoiceHandlers) UpdateVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid voice ID", err.Error())
+	}
+
+	var requestBody UpdateVoiceRequest
+	if err := c.Bind(&requestBody); err != nil {
+		return domain.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	updated, err := h.voiceService.UpdateVoice(c.Request().Context(), id, voice.UpdateRequest{
+		Name:      requestBody.Name,
+		SampleURL: requestBody.SampleURL,
+	})
+	if err != nil {
+		return voiceError(c, err, "Failed to update voice")
+	}
+	return domain.Success(c, updated)
+}
+
+// DeleteVoice handles DELETE /api/voices/:id
+// @Summary 删除音色
+// @Description 删除未被角色使用的音色，复刻音色同时从上游删除以释放配额
+// @Tags voices
+// @Produce json
+// @Param id path string true "音色ID"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是音色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/voices/{id} [delete]
+func (h *VoiceHandlers) DeleteVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid voice ID", err.Error())
+	}
+
+	if err := h.voiceService.DeleteVoice(c.Request().Context(), id); err != nil {
+		return voiceError(c, err, "Failed to delete voice")
+	}
+	return domain.Success(c, id)
+}
+
+// RecheckVoice handles POST /api/voices/:id/recheck
+// @Summary 重新检查音色审核状态
+// @Description 立即查询复刻音色的审核状态，复刻失败的音色回到审核中并重新开始检查
+// @Tags voices
+// @Produce json
+// @Param id path string true "音色ID"
+// @Success 200 {object} voice.Voice
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string "不是音色的所有者"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/voices/{id}/recheck [post]
+func (h *VoiceHandlers) RecheckVoice(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return domain.BadRequest(c, "Invalid voice ID", err.Error())
+	}
+
+	v, err := h.voiceService.Recheck(c.Request().Context(), id)
+	if err != nil {
+		return voiceError(c, err, "Failed to recheck voice")
+	}
+	return domain.Success(c, v)
+}
+
+// voiceError 把音色相关的错误转换为响应，未识别的错误返回500
+func voiceError(c echo.Context, err error, message string) error {
+	var sampleErr *voice.SampleError
+	switch {
+	case errors.As(err, &sampleErr):
+		return sampleValidationFailed(c, sampleErr)
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return domain.Unauthorized(c, "Authentication required", err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		return domain.Forbidden(c, "Permission denied", err.Error())
+	case errors.Is(err, voice.ErrVoiceNotFound):
+		return domain.NotFound(c, "Voice not found", err.Error())
+	case errors.Is(err, voice.ErrVoiceInUse):
+		return domain.Error(c, http.StatusConflict, "VOICE_IN_USE", "Voice is used by a character", err.Error())
+	case errors.Is(err, voice.ErrVoiceAlreadyReady):
+		return domain.Error(c, http.StatusConflict, "ALREADY_APPROVED", "Voice is already approved", err.Error())
+	case errors.Is(err, voice.ErrInvalidVoice),
+		errors.Is(err, voice.ErrNotCloned),
+		errors.Is(err, voice.ErrVoiceUnavailable):
+		return domain.BadRequest(c, "Invalid voice request", err.Error())
+	}
+	return domain.InternalError(c, message, err.Error())
+}
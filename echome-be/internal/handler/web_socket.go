@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -31,12 +32,21 @@ func NewWebSocketHandlers(aiClient ai.Repo, conversationService *conversation.Co
 func (h *WebSocketHandlers) RegisterRoutes(e *echo.Echo) {
 	e.GET("/ws/asr", h.HandleASRWebSocket)
 	e.GET("/ws/voice-conversation", h.HandleVoiceConversationWebSocket)
+	e.GET("/ws/interpreter", h.HandleInterpreterWebSocket)
 }
 
 // HandleASRWebSocket handles ASR WebSocket connection
 // @Summary 语音识别WebSocket连接
-// @Description 建立语音识别的WebSocket连接，用于实时语音转文本
+// @Description 建立语音识别的WebSocket连接，用于实时语音转文本。
+// @Description 音频格式可以通过查询参数声明，也可以在首条消息中发送 {"type":"start","format":"webm","sample_rate":48000,"channels":1}。
+// @Description 支持 pcm（任意采样率、多声道）、wav、ogg/opus、webm/opus。pcm/wav 下混为单声道并重采样后送入识别；
+// @Description Opus 不在服务端解码，只接受单声道：ogg/opus 原样转发，webm/opus 转封装为 ogg/opus，由识别服务按 48kHz 解码。
+// @Description 首条文本消息不是合法的 start 消息时返回 asr_error 并关闭识别。
 // @Tags websocket
+// @Param format query string false "音频格式: pcm/wav/ogg/opus/webm，默认pcm"
+// @Param sample_rate query int false "PCM采样率，支持 8000/11025/12000/16000/22050/24000/32000/44100/48000，默认16000"
+// @Param channels query int false "PCM声道数，默认1"
+// @Param characterId query string false "角色ID，使用该角色的热词表"
 // @Success 101
 // @Router /ws/asr [get]
 func (h *WebSocketHandlers) HandleASRWebSocket(c echo.Context) error {
@@ -46,8 +56,22 @@ func (h *WebSocketHandlers) HandleASRWebSocket(c echo.Context) error {
 		zap.L().Error("Failed to upgrade to WebSocket", zap.Error(err))
 		return err
 	}
+	cid, err := uuid.Parse(c.QueryParam("characterId"))
+	if err != nil {
+		cid = uuid.Nil
+	}
+	req := &conversation.ASRRequest{
+		SafeConn:    ws,
+		CharacterID: cid,
+		Input: ai.AudioInput{
+			Format:     c.QueryParam("format"),
+			SampleRate: queryInt(c, "sample_rate"),
+			Channels:   queryInt(c, "channels"),
+		},
+	}
+
 	// Use AI service to handle ASR WebSocket connection
-	if err := h.aiClient.HandleASR(c.Request().Context(), ws); err != nil {
+	if err := h.conversationService.StartASRSession(c.Request().Context(), req); err != nil {
 		zap.L().Error("ASR WebSocket error", zap.Error(err))
 		return err
 	}
@@ -58,8 +82,13 @@ func (h *WebSocketHandlers) HandleASRWebSocket(c echo.Context) error {
 // HandleVoiceConversationWebSocket handles voice conversation via WebSocket
 // @Summary 语音对话WebSocket连接
 // @Description 建立WebSocket连接，用户通过WebSocket消息发送语音或文本，返回AI生成的响应
+// @Description 指定角色时，服务端用角色提示词代替消息开头的系统消息，并把对话示例作为之前的轮次插入上下文；
+// @Description 角色有开场白时，连接后先发送 {"type":"greeting","content":"..."} 和开场白语音，再发送 {"type":"greeting_end"}，客户端无需把开场白放进消息历史
 // @Tags websocket
-// @Param characterId query string false "角色ID"
+// @Param characterId query string false "角色ID，其他用户的角色须可见且审核通过"
+// @Param token query string false "访问令牌，浏览器无法为 WebSocket 设置 Authorization 头时使用"
+// @Param audio_format query string false "TTS音频格式: pcm/wav/mp3/opus"
+// @Param sample_rate query int false "TTS采样率"
 // @Success 101
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -94,6 +123,8 @@ func (h *WebSocketHandlers) HandleVoiceConversationWebSocket(c echo.Context) err
 	voiceConvReq := &conversation.VoiceConversationRequest{
 		SafeConn:    ws,
 		CharacterID: cid,
+		AudioFormat: c.QueryParam("audio_format"),
+		SampleRate:  queryInt(c, "sample_rate"),
 	}
 
 	// 启动语音对话
@@ -107,6 +138,71 @@ func (h *WebSocketHandlers) HandleVoiceConversationWebSocket(c echo.Context) err
 	return nil
 }
 
+// HandleInterpreterWebSocket handles live interpreter via WebSocket
+// @Summary 同声传译WebSocket连接
+// @Description 建立同声传译的WebSocket连接，客户端发送语音，服务端返回原文、译文事件和译文语音
+// @Tags websocket
+// @Param source_lang query string false "源语言，默认zh"
+// @Param target_lang query string false "目标语言，默认en"
+// @Param auto_switch query bool false "是否根据识别语言自动切换翻译方向，默认true"
+// @Param voice query string false "译文播报音色"
+// @Param audio_format query string false "TTS音频格式: pcm/wav/mp3/opus"
+// @Param sample_rate query int false "TTS采样率"
+// @Success 101
+// @Failure 400 {object} map[string]string
+// @Router /ws/interpreter [get]
+func (h *WebSocketHandlers) HandleInterpreterWebSocket(c echo.Context) error {
+	req := &conversation.InterpreterRequest{
+		SourceLang:  c.QueryParam("source_lang"),
+		TargetLang:  c.QueryParam("target_lang"),
+		AutoSwitch:  c.QueryParam("auto_switch") != "false",
+		Voice:       c.QueryParam("voice"),
+		AudioFormat: c.QueryParam("audio_format"),
+		SampleRate:  queryInt(c, "sample_rate"),
+	}
+	if req.SourceLang == "" {
+		req.SourceLang = "zh"
+	}
+	if req.TargetLang == "" {
+		req.TargetLang = "en"
+	}
+
+	// 升级到WebSocket
+	ws, err := upgradeToWebSocket(c)
+	if err != nil {
+		return err
+	}
+	req.SafeConn = ws
+
+	if err := ws.WriteJSON(map[string]any{
+		"type":        "connection_established",
+		"source_lang": req.SourceLang,
+		"target_lang": req.TargetLang,
+		"timestamp":   time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err := h.conversationService.StartInterpreterSession(c.Request().Context(), req); err != nil {
+		zap.L().Error("Interpreter WebSocket error", zap.Error(err))
+		_ = ws.WriteJSON(map[string]string{
+			"type":    "error",
+			"message": "Failed to start interpreter session: " + err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// queryInt 读取整数查询参数，缺失或无效时返回0
+func queryInt(c echo.Context, name string) int {
+	value, err := strconv.Atoi(c.QueryParam(name))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 // 升级HTTP连接到WebSocket
 func upgradeToWebSocket(c echo.Context) (*ws.SafeConn, error) {
 	upgrader := websocket.Upgrader{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/justin/echome-be/internal/domain/ai"
 	"github.com/justin/echome-be/internal/domain/ws"
+	"github.com/justin/echome-be/internal/infra/audio"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -35,9 +37,36 @@ func sendHeartbeat(ctx context.Context, ws *websocket.Conn, interval time.Durati
 }
 
 // HandleASR 通过阿里云Model Studio Paraformer处理语音识别
-func (client *AliClient) HandleASR(ctx context.Context, clientWS ws.WebSocketConn) error {
+func (client *AliClient) HandleASR(ctx context.Context, clientWS ws.WebSocketConn, input ai.AudioInput, config ai.ASRConfig) error {
+	return client.RecognizeSpeech(ctx, clientWS, input, resolveASRConfig(config), func(result ai.ASRResult) error {
+		return writeASRResult(clientWS, result)
+	})
+}
+
+// RecognizeSpeech 识别客户端发送的音频，识别结果交给 onResult 处理
+func (client *AliClient) RecognizeSpeech(ctx context.Context, clientWS ws.WebSocketConn, input ai.AudioInput, config ai.ASRConfig, onResult func(ai.ASRResult) error) error {
+	// 读取客户端的 start 消息，确定上传音频的格式
+	input, firstChunk, err := readAudioStart(clientWS, input)
+	if err != nil {
+		return fmt.Errorf("读取客户端音频格式失败: %w", err)
+	}
+
+	// 将客户端音频统一转换为 ASR 接受的格式
+	converter, output, err := audio.NewConverter(input.Format, input.SampleRate, input.Channels, config.SampleRate)
+	if err != nil {
+		// 采样率、声道数等参数来自 start 消息或查询参数
+		code := "INVALID_AUDIO_FORMAT"
+		if errors.Is(err, audio.ErrInvalidParams) {
+			code = "INVALID_START_MESSAGE"
+		}
+		writeASRError(clientWS, code, err.Error())
+		return fmt.Errorf("不支持的音频格式: %w", err)
+	}
+	config.Format = output.Format
+	config.SampleRate = output.SampleRate
+
 	// 连接到阿里云Model Studio ASR WebSocket
-	asrWS, taskID, err := connectToModelStudioASR(client.apiKey, DefaultASRConfig())
+	asrWS, taskID, err := connectToModelStudioASR(client.apiKey, config)
 	if err != nil {
 		return fmt.Errorf("连接Model Studio ASR失败: %w", err)
 	}
@@ -53,17 +82,35 @@ func (client *AliClient) HandleASR(ctx context.Context, clientWS ws.WebSocketCon
 
 	// 从客户端读取音频并发送到阿里云
 	g.Go(func() error {
-		return forwardAudioToModelStudio(ctx, clientWS, asrWS, taskID)
+		return forwardAudioToModelStudio(ctx, clientWS, asrWS, taskID, converter, firstChunk)
 	})
 
 	// 从阿里云读取识别结果
 	g.Go(func() error {
-		return handleModelStudioASRResults(ctx, asrWS, clientWS)
+		return handleModelStudioASRResults(ctx, asrWS, clientWS, onResult)
 	})
 
 	return g.Wait()
 }
 
+// resolveASRConfig 用默认配置补全未指定的 ASR 参数
+func resolveASRConfig(config ai.ASRConfig) ai.ASRConfig {
+	defaults := DefaultASRConfig()
+	if config.Model == "" {
+		config.Model = defaults.Model
+	}
+	if config.Format == "" {
+		config.Format = defaults.Format
+	}
+	if config.SampleRate == 0 {
+		config.SampleRate = defaults.SampleRate
+	}
+	if config.LanguageHints == nil {
+		config.LanguageHints = defaults.LanguageHints
+	}
+	return config
+}
+
 // connectToModelStudioASR 连接到阿里云WebSocket实时ASR服务
 func connectToModelStudioASR(apiKey string, config ai.ASRConfig) (*websocket.Conn, string, error) {
 	// 阿里云WebSocket实时ASR URL
@@ -93,6 +140,15 @@ func connectToModelStudioASR(apiKey string, config ai.ASRConfig) (*websocket.Con
 	taskID := uuid.New().String()
 
 	// 根据文档发送初始化参数 - 启用心跳机制
+	parameters := map[string]any{
+		"format":         config.Format,
+		"sample_rate":    config.SampleRate,
+		"language_hints": config.LanguageHints,
+		"heartbeat":      true,
+	}
+	if config.VocabularyID != "" {
+		parameters["vocabulary_id"] = config.VocabularyID
+	}
 	initMsg := map[string]any{
 		"header": map[string]any{
 			"action":    "run-task",
@@ -104,13 +160,8 @@ func connectToModelStudioASR(apiKey string, config ai.ASRConfig) (*websocket.Con
 			"task":       "asr",
 			"function":   "recognition",
 			"model":      config.Model,
-			"parameters": map[string]any{
-				"format":         config.Format,
-				"sample_rate":    config.SampleRate,
-				"language_hints": config.LanguageHints,
-				"heartbeat":      true,
-			},
-			"input": map[string]any{},
+			"parameters": parameters,
+			"input":      map[string]any{},
 		},
 	}
 
@@ -161,31 +212,75 @@ func connectToModelStudioASR(apiKey string, config ai.ASRConfig) (*websocket.Con
 	return ws, taskID, nil
 }
 
+// readAudioStart 读取客户端的第一条消息
+// 如果是 start 消息则用其中声明的格式覆盖 input；如果直接是音频数据，则作为第一块音频返回
+// 其他文本消息无法确定音频格式，向客户端发送 asr_error 后返回错误
+func readAudioStart(clientWS ws.WebSocketConn, input ai.AudioInput) (ai.AudioInput, []byte, error) {
+	messageType, data, err := clientWS.ReadMessage()
+	if err != nil {
+		return input, nil, err
+	}
+	if messageType == websocket.BinaryMessage {
+		return input, data, nil
+	}
+
+	var start struct {
+		Type string `json:"type"`
+		ai.AudioInput
+	}
+	if err := json.Unmarshal(data, &start); err != nil || start.Type != "start" {
+		message := "首条文本消息必须是 start 消息"
+		if err != nil {
+			message = fmt.Sprintf("start 消息格式错误: %v", err)
+		}
+		writeASRError(clientWS, "INVALID_START_MESSAGE", message)
+		return input, nil, errors.New(message)
+	}
+
+	if start.Format != "" {
+		input.Format = start.Format
+	}
+	if start.SampleRate > 0 {
+		input.SampleRate = start.SampleRate
+	}
+	if start.Channels > 0 {
+		input.Channels = start.Channels
+	}
+	zap.L().Info("客户端声明音频格式",
+		zap.String("format", input.Format),
+		zap.Int("sample_rate", input.SampleRate),
+		zap.Int("channels", input.Channels),
+	)
+	return input, nil, nil
+}
+
 // forwardAudioToModelStudio 转发音频数据到阿里云WebSocket ASR
-func forwardAudioToModelStudio(ctx context.Context, clientWS ws.WebSocketConn, asrWS *websocket.Conn, taskID string) error {
+// 音频先经过 converter 转换，firstChunk 为读取 start 消息时已收到的音频
+func forwardAudioToModelStudio(ctx context.Context, clientWS ws.WebSocketConn, asrWS *websocket.Conn, taskID string, converter audio.Converter, firstChunk []byte) error {
+	sendAudio := func(data []byte) error {
+		return sendASRAudio(asrWS, data)
+	}
+
 	defer func() {
-		zap.L().Info("音频发送结束，发送finish-task指令")
-		// 发送结束信号 - 根据文档格式，使用相同的taskID
-		endMsg := map[string]any{
-			"header": map[string]any{
-				"action":    "finish-task",
-				"task_id":   taskID,
-				"streaming": "duplex",
-			},
-			"payload": map[string]any{
-				"input": map[string]any{},
-			},
+		// 发送转换器中缓冲的剩余音频
+		if rest, err := converter.Flush(); err != nil {
+			zap.L().Warn("音频转换收尾失败", zap.Error(err))
+		} else if err := sendAudio(rest); err != nil {
+			zap.L().Warn("发送剩余音频失败", zap.Error(err))
 		}
+		sendASRFinishTask(asrWS, taskID)
+	}()
 
-		// 设置写入超时
-		if err := asrWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-			zap.L().Warn("设置写入超时失败", zap.Error(err))
+	if firstChunk != nil {
+		converted, err := converter.Convert(firstChunk)
+		if err != nil {
+			writeASRError(clientWS, "INVALID_AUDIO_FORMAT", err.Error())
+			return fmt.Errorf("音频转换失败: %w", err)
 		}
-
-		if err := asrWS.WriteJSON(endMsg); err != nil {
-			zap.L().Warn("发送finish-task指令失败", zap.Error(err))
+		if err := sendAudio(converted); err != nil {
+			return err
 		}
-	}()
+	}
 
 	for {
 		select {
@@ -201,13 +296,13 @@ func forwardAudioToModelStudio(ctx context.Context, clientWS ws.WebSocketConn, a
 
 			switch messageType {
 			case websocket.BinaryMessage:
-				// 设置写入超时
-				if err := asrWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-					zap.L().Warn("设置写入超时失败", zap.Error(err))
+				converted, err := converter.Convert(data)
+				if err != nil {
+					writeASRError(clientWS, "INVALID_AUDIO_FORMAT", err.Error())
+					return fmt.Errorf("音频转换失败: %w", err)
 				}
-
-				if err := asrWS.WriteMessage(websocket.BinaryMessage, data); err != nil {
-					return fmt.Errorf("转发音频数据失败: %w", err)
+				if err := sendAudio(converted); err != nil {
+					return err
 				}
 			case websocket.TextMessage:
 				var msg map[string]any
@@ -224,8 +319,49 @@ func forwardAudioToModelStudio(ctx context.Context, clientWS ws.WebSocketConn, a
 	}
 }
 
+// sendASRAudio 发送一段音频到阿里云WebSocket ASR
+func sendASRAudio(asrWS *websocket.Conn, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	// 设置写入超时
+	if err := asrWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		zap.L().Warn("设置写入超时失败", zap.Error(err))
+	}
+	if err := asrWS.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return fmt.Errorf("转发音频数据失败: %w", err)
+	}
+	return nil
+}
+
+// sendASRFinishTask 音频发送结束后发送 finish-task 指令
+func sendASRFinishTask(asrWS *websocket.Conn, taskID string) {
+	zap.L().Info("音频发送结束，发送finish-task指令")
+	// 发送结束信号 - 根据文档格式，使用相同的taskID
+	endMsg := map[string]any{
+		"header": map[string]any{
+			"action":    "finish-task",
+			"task_id":   taskID,
+			"streaming": "duplex",
+		},
+		"payload": map[string]any{
+			"input": map[string]any{},
+		},
+	}
+
+	// 设置写入超时
+	if err := asrWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		zap.L().Warn("设置写入超时失败", zap.Error(err))
+	}
+
+	if err := asrWS.WriteJSON(endMsg); err != nil {
+		zap.L().Warn("发送finish-task指令失败", zap.Error(err))
+	}
+}
+
 // handleModelStudioASRResults 处理阿里云WebSocket ASR识别结果
-func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, clientWS ws.WebSocketConn) error {
+// clientWS 为 nil 时（如文件转写）不向客户端发送任务完成和失败通知
+func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, clientWS ws.WebSocketConn, onResult func(ai.ASRResult) error) error {
 	resultReceived := false
 
 	for {
@@ -276,25 +412,21 @@ func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, cli
 										if heartbeat, ok := sentence["heartbeat"].(bool); ok && heartbeat {
 											continue
 										}
-										// 构建客户端响应
-										clientResponse := map[string]any{
-											"type": "asr_result",
-											"text": text,
-										}
-
+										result := ai.ASRResult{Text: text}
 										// 添加句子结束标志
 										if sentenceEnd, ok := sentence["sentence_end"].(bool); ok {
-											clientResponse["sentence_end"] = sentenceEnd
+											result.SentenceEnd = sentenceEnd
 										}
-
-										// 设置写入超时
-										if err := clientWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-											zap.L().Warn("设置客户端写入超时失败", zap.Error(err))
+										// 句子时间戳，未结束的句子 end_time 为 null
+										if beginTime, ok := sentence["begin_time"].(float64); ok {
+											result.BeginTime = int(beginTime)
+										}
+										if endTime, ok := sentence["end_time"].(float64); ok {
+											result.EndTime = int(endTime)
 										}
 
-										// 发送到客户端
-										if err := clientWS.WriteJSON(clientResponse); err != nil {
-											zap.L().Warn("向客户端发送ASR结果失败", zap.Error(err))
+										if err := onResult(result); err != nil {
+											zap.L().Warn("处理ASR结果失败", zap.Error(err))
 										}
 									}
 								}
@@ -306,9 +438,11 @@ func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, cli
 							zap.L().Warn("任务完成但未收到任何识别结果")
 						}
 						// 发送任务完成通知给客户端
-						_ = clientWS.WriteJSON(map[string]any{
-							"type": "asr_finished",
-						})
+						if clientWS != nil {
+							_ = clientWS.WriteJSON(map[string]any{
+								"type": "asr_finished",
+							})
+						}
 						return nil
 					case "task-failed":
 						// 根据文档，错误信息在header中
@@ -322,11 +456,9 @@ func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, cli
 						}
 						zap.L().Error("ASR任务失败", zap.String("error_code", errorCode), zap.String("error_message", errorMessage))
 						// 发送错误信息给客户端
-						_ = clientWS.WriteJSON(map[string]any{
-							"type":          "asr_error",
-							"error_code":    errorCode,
-							"error_message": errorMessage,
-						})
+						if clientWS != nil {
+							writeASRError(clientWS, errorCode, errorMessage)
+						}
 						return fmt.Errorf("ASR任务失败: %s - %s", errorCode, errorMessage)
 					default:
 					}
@@ -335,3 +467,26 @@ func handleModelStudioASRResults(ctx context.Context, asrWS *websocket.Conn, cli
 		}
 	}
 }
+
+// writeASRResult 将识别结果发送给客户端
+func writeASRResult(clientWS ws.WebSocketConn, result ai.ASRResult) error {
+	// 设置写入超时
+	if err := clientWS.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		zap.L().Warn("设置客户端写入超时失败", zap.Error(err))
+	}
+
+	return clientWS.WriteJSON(map[string]any{
+		"type":         "asr_result",
+		"text":         result.Text,
+		"sentence_end": result.SentenceEnd,
+	})
+}
+
+// writeASRError 向客户端发送 asr_error 事件，发送失败时忽略
+func writeASRError(clientWS ws.WebSocketConn, code, message string) {
+	_ = clientWS.WriteJSON(map[string]any{
+		"type":          "asr_error",
+		"error_code":    code,
+		"error_message": message,
+	})
+}
@@ -0,0 +1,114 @@
+package aliyun
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+const (
+	// pcmChannels TTS 输出的声道数
+	pcmChannels = 1
+	// pcmBitsPerSample TTS 输出的 PCM 位深
+	pcmBitsPerSample = 16
+	// wavStreamSize 流式 WAV 中未知长度字段使用的占位值
+	wavStreamSize = 0xFFFFFFFF
+)
+
+// upstreamFormat 返回向阿里云请求的音频格式
+// wav 由服务端自行添加流式文件头，因此向上游请求裸 PCM
+func upstreamFormat(format string) string {
+	if format == ai.AudioFormatWAV {
+		return ai.AudioFormatPCM
+	}
+	return format
+}
+
+// audioOutput 向输出写出合成音频
+// 输出实现 ai.EventWriter 时，在第一帧音频之前发送 audio_format 事件
+// wav 格式还会在首帧前加上流式文件头
+type audioOutput struct {
+	w          io.Writer
+	events     ai.EventWriter
+	format     string
+	sampleRate int
+	started    bool
+	// written 已写出的音频字节数，不含 wav 文件头
+	written int
+	// elapsedMs 压缩格式下按时间戳推算的已输出时长
+	elapsedMs int
+	// sentences 已发送时间戳的句子数，用于生成整个输出流内的句子序号
+	sentences int
+}
+
+// newAudioOutput 创建音频输出
+func newAudioOutput(w io.Writer, config ai.TTSConfig) *audioOutput {
+	events, _ := w.(ai.EventWriter)
+	return &audioOutput{
+		w:          w,
+		events:     events,
+		format:     config.Format,
+		sampleRate: config.SampleRate,
+	}
+}
+
+// writeAudio 写出一帧音频
+func (o *audioOutput) writeAudio(data []byte) error {
+	o.written += len(data)
+	if !o.started {
+		o.started = true
+		if err := o.writeEvent(o.formatEvent()); err != nil {
+			return err
+		}
+		if o.format == ai.AudioFormatWAV {
+			data = append(wavStreamHeader(o.sampleRate), data...)
+		}
+	}
+	_, err := o.w.Write(data)
+	return err
+}
+
+// writeEvent 发送 JSON 事件，输出不接收事件时忽略
+func (o *audioOutput) writeEvent(event any) error {
+	if o.events == nil {
+		return nil
+	}
+	return o.events.WriteEvent(event)
+}
+
+// formatEvent 构建 audio_format 事件，描述随后二进制帧的格式
+func (o *audioOutput) formatEvent() map[string]any {
+	event := map[string]any{
+		"type":        "audio_format",
+		"format":      o.format,
+		"sample_rate": o.sampleRate,
+		"channels":    pcmChannels,
+	}
+	if o.format == ai.AudioFormatPCM || o.format == ai.AudioFormatWAV {
+		event["bits_per_sample"] = pcmBitsPerSample
+		event["encoding"] = "s16le"
+	}
+	return event
+}
+
+// wavStreamHeader 构建流式 WAV 文件头
+// 音频总长度未知，RIFF 与 data 块长度使用 0xFFFFFFFF 占位，播放器会读到流结束为止
+func wavStreamHeader(sampleRate int) []byte {
+	blockAlign := pcmChannels * pcmBitsPerSample / 8
+	header := make([]byte, 44)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], wavStreamSize)
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16) // fmt 块长度
+	binary.LittleEndian.PutUint16(header[20:22], 1)  // PCM
+	binary.LittleEndian.PutUint16(header[22:24], pcmChannels)
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:36], pcmBitsPerSample)
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], wavStreamSize)
+	return header
+}
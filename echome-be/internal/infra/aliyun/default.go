@@ -9,9 +9,11 @@ const tavilyAPIURL = "https://api.tavily.com/search"
 // DefaultTTSConfig 提供默认 TTS 配置
 func DefaultTTSConfig() ai.TTSConfig {
 	return ai.TTSConfig{
-		Model:  "cosyvoice-v2",
-		Voice:  "longxiaochun_v2",
-		Format: "pcm",
+		Model:      voiceTargetModel,
+		Voice:      "longxiaochun_v2",
+		Format:     ai.AudioFormatPCM,
+		SampleRate: 22050,
+		Mode:       QwenTTSModeServerCommit,
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/infra/ttscache"
 )
 
 // 确保AliClient实现domain.AIRepo接口
@@ -22,15 +23,25 @@ type AliClient struct {
 	maxTokens    int
 	temperature  float32
 	tavilyAPIKey string
+	// ttsConfig 未指定参数时使用的 TTS 默认配置
+	ttsConfig ai.TTSConfig
+	// ttsCache 短句音频缓存，为 nil 时不启用
+	ttsCache *ttscache.Cache
+	// ttsCacheMaxTextLength 可缓存句子的最大字数
+	ttsCacheMaxTextLength int
 }
 
-func NewAliClient(apiKey string, endpoint string, timeout int, maxRetries int, llmModel string, maxTokens int, temperature float32, tavilyAPIKey string) *AliClient {
+func NewAliClient(apiKey string, endpoint string, timeout int, maxRetries int, llmModel string, maxTokens int, temperature float32, tavilyAPIKey string, ttsConfig ai.TTSConfig, ttsCache *ttscache.Cache, ttsCacheMaxTextLength int) *AliClient {
 	// 为超时配置设置默认值
 	httpTimeout := 30 * time.Second
 	if timeout > 0 {
 		httpTimeout = time.Duration(timeout) * time.Second
 	}
 
+	if ttsCacheMaxTextLength <= 0 {
+		ttsCacheMaxTextLength = DefaultTTSCacheMaxTextLength
+	}
+
 	return &AliClient{
 		apiKey:       apiKey,
 		endPoint:     endpoint,
@@ -40,6 +51,10 @@ func NewAliClient(apiKey string, endpoint string, timeout int, maxRetries int, l
 		maxTokens:    maxTokens,
 		temperature:  temperature,
 		tavilyAPIKey: tavilyAPIKey,
+		ttsConfig:    ttsConfig,
+		ttsCache:     ttsCache,
+
+		ttsCacheMaxTextLength: ttsCacheMaxTextLength,
 		httpClient: &http.Client{
 			Timeout: httpTimeout,
 			Transport: &http.Transport{
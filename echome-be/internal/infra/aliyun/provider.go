@@ -2,6 +2,8 @@ package aliyun
 
 import (
 	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/infra/ttscache"
 )
 
 // ProvideAliClient 创建阿里云百炼API客户端的提供者函数
@@ -16,5 +18,45 @@ func ProvideAliClient(cfg *config.Config) *AliClient {
 		cfg.Aliyun.LLM.MaxTokens,
 		cfg.Aliyun.LLM.Temperature,
 		cfg.Tavily.APIKey,
+		ttsConfigFromService(cfg.Aliyun.TTS),
+		ttsCacheFromService(cfg.Aliyun.TTS.Cache),
+		cfg.Aliyun.TTS.Cache.MaxTextLength,
 	)
 }
+
+// ttsCacheFromService 根据配置创建 TTS 短句缓存，未启用时返回 nil
+func ttsCacheFromService(cache config.TTSCacheConfig) *ttscache.Cache {
+	if !cache.Enabled {
+		return nil
+	}
+	maxMemoryMB := cache.MaxMemoryMB
+	if maxMemoryMB <= 0 {
+		maxMemoryMB = 64
+	}
+	maxDiskMB := cache.MaxDiskMB
+	if maxDiskMB <= 0 {
+		maxDiskMB = 1024
+	}
+	return ttscache.New(maxMemoryMB*1024*1024, cache.Dir, int64(maxDiskMB)*1024*1024)
+}
+
+// ttsConfigFromService 以内置默认值为基础，应用配置文件中的 TTS 配置
+func ttsConfigFromService(tts config.TTSServiceConfig) ai.TTSConfig {
+	ttsConfig := DefaultTTSConfig()
+	if tts.Model != "" {
+		ttsConfig.Model = tts.Model
+	}
+	if tts.DefaultVoice != "" {
+		ttsConfig.Voice = tts.DefaultVoice
+	}
+	if tts.SampleRate > 0 {
+		ttsConfig.SampleRate = tts.SampleRate
+	}
+	if tts.ResponseFormat != "" {
+		ttsConfig.Format = tts.ResponseFormat
+	}
+	if tts.Mode != "" {
+		ttsConfig.Mode = tts.Mode
+	}
+	return ttsConfig
+}
@@ -0,0 +1,280 @@
+package aliyun
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"golang.org/x/sync/errgroup"
+)
+
+const qwenTTSRealtimeURL = "wss://dashscope.aliyuncs.com/api-ws/v1/realtime"
+
+// Qwen-TTS Realtime 的提交模式
+const (
+	// QwenTTSModeServerCommit 由服务端自动断句合成
+	QwenTTSModeServerCommit = "server_commit"
+	// QwenTTSModeCommit 由客户端提交文本缓冲区触发合成
+	QwenTTSModeCommit = "commit"
+)
+
+// sentenceEndings 在 commit 模式下触发提交的句末标点
+const sentenceEndings = "。！？；.!?;\n"
+
+// qwenLanguageTypes 语言代码到 Qwen-TTS language_type 的映射
+var qwenLanguageTypes = map[string]string{
+	"zh": "Chinese",
+	"en": "English",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"fr": "French",
+	"de": "German",
+	"es": "Spanish",
+	"ru": "Russian",
+	"it": "Italian",
+	"pt": "Portuguese",
+}
+
+// HandleStreamTTS 根据模型名称选择 CosyVoice 或 Qwen-TTS Realtime 合成语音
+func (client *AliClient) HandleStreamTTS(ctx context.Context, w io.Writer, textStream <-chan string, config ai.TTSConfig) error {
+	config = client.resolveTTSConfig(config)
+	if ai.IsQwenTTSModel(config.Model) {
+		return client.HandleQwenTTS(ctx, w, textStream, config)
+	}
+	return client.HandleCosyVoiceTTS(ctx, w, textStream, config)
+}
+
+// HandleQwenTTS 通过 Qwen-TTS Realtime WebSocket 会话协议合成语音
+func (client *AliClient) HandleQwenTTS(ctx context.Context, w io.Writer, textStream <-chan string, config ai.TTSConfig) error {
+	config = client.resolveTTSConfig(config)
+	qwenWS, err := connectToQwenTTS(client.apiKey, config.Model)
+	if err != nil {
+		return fmt.Errorf("连接 Qwen-TTS 失败: %w", err)
+	}
+	defer qwenWS.Close()
+
+	mode := config.Mode
+	// 1. 发送会话配置，在启动读写协程之前发送，失败时没有需要回收的协程
+	if err := sendQwenSessionUpdate(qwenWS, config, mode); err != nil {
+		return fmt.Errorf("发送 session.update 失败: %w", err)
+	}
+
+	// 带缓冲，发送方还没开始等待时读取方也不会丢失信号
+	sessionUpdated := make(chan struct{}, 1)
+	g, ctx := errgroup.WithContext(ctx)
+
+	// 任一方失败、客户端断开或合成结束时关闭连接，使阻塞在 ReadMessage 上的读取方立即返回
+	go func() {
+		<-ctx.Done()
+		qwenWS.Close()
+	}()
+
+	// 2. 从 Qwen-TTS 读取事件并转发音频
+	g.Go(func() error {
+		return handleQwenTTSToClient(ctx, qwenWS, newAudioOutput(w, config), sessionUpdated)
+	})
+
+	// 3. 发送文本到 Qwen-TTS
+	g.Go(func() error {
+		// 等待 session.updated 事件，确保会话参数生效
+		select {
+		case <-sessionUpdated:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return fmt.Errorf("等待 session.updated 超时")
+		}
+
+		pending := false
+	send:
+		for {
+			var text string
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case t, ok := <-textStream:
+				if !ok {
+					break send
+				}
+				text = t
+			}
+			if text == "" {
+				continue
+			}
+			if err := sendQwenEvent(qwenWS, "input_text_buffer.append", map[string]any{"text": text}); err != nil {
+				return fmt.Errorf("发送 input_text_buffer.append 失败: %w", err)
+			}
+			pending = true
+
+			// commit 模式下在句末提交，使音频按句尽早返回
+			if mode == QwenTTSModeCommit && strings.ContainsAny(lastRune(text), sentenceEndings) {
+				if err := sendQwenEvent(qwenWS, "input_text_buffer.commit", nil); err != nil {
+					return fmt.Errorf("发送 input_text_buffer.commit 失败: %w", err)
+				}
+				pending = false
+			}
+		}
+
+		if mode == QwenTTSModeCommit && pending {
+			if err := sendQwenEvent(qwenWS, "input_text_buffer.commit", nil); err != nil {
+				return fmt.Errorf("发送 input_text_buffer.commit 失败: %w", err)
+			}
+		}
+
+		// 所有文本发送完毕，结束会话
+		if err := sendQwenEvent(qwenWS, "session.finish", nil); err != nil {
+			return fmt.Errorf("发送 session.finish 失败: %w", err)
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// connectToQwenTTS 建立 Qwen-TTS Realtime WebSocket 连接
+func connectToQwenTTS(apiKey string, model string) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{
+		HandshakeTimeout: 30 * time.Second,
+		ReadBufferSize:   4096,
+		WriteBufferSize:  4096,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true},
+	}
+
+	headers := http.Header{}
+	headers.Add("Authorization", "Bearer "+apiKey)
+	headers.Add("X-DashScope-DataInspection", "enable")
+
+	endpoint := qwenTTSRealtimeURL + "?model=" + url.QueryEscape(model)
+	conn, resp, err := dialer.Dial(endpoint, headers)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("连接失败: %v, 状态码: %d", err, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("连接失败: %v", err)
+	}
+
+	return conn, nil
+}
+
+// sendQwenSessionUpdate 发送会话配置
+func sendQwenSessionUpdate(conn *websocket.Conn, config ai.TTSConfig, mode string) error {
+	languageType, ok := qwenLanguageTypes[config.Lang]
+	if !ok {
+		languageType = "Auto"
+	}
+
+	session := map[string]any{
+		"mode":            mode,
+		"voice":           config.Voice,
+		"language_type":   languageType,
+		"response_format": upstreamFormat(config.Format),
+		"sample_rate":     config.SampleRate,
+	}
+	// 语速、语调和音量未指定时使用服务端默认值
+	if config.Rate != 0 {
+		session["speech_rate"] = config.Rate
+	}
+	if config.Pitch != 0 {
+		session["pitch_rate"] = config.Pitch
+	}
+	if config.Volume != 0 {
+		session["volume"] = config.Volume
+	}
+	return sendQwenEvent(conn, "session.update", map[string]any{"session": session})
+}
+
+// sendQwenEvent 发送客户端事件，fields 为事件的附加字段
+func sendQwenEvent(conn *websocket.Conn, eventType string, fields map[string]any) error {
+	event := map[string]any{
+		"event_id": "event_" + uuid.NewString(),
+		"type":     eventType,
+	}
+	for k, v := range fields {
+		event[k] = v
+	}
+	return conn.WriteJSON(event)
+}
+
+// handleQwenTTSToClient 读取 Qwen-TTS 服务端事件，将音频增量转发给客户端
+func handleQwenTTSToClient(ctx context.Context, qwenWS *websocket.Conn, out *audioOutput, sessionUpdated chan<- struct{}) error {
+	defer close(sessionUpdated)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			msgType, msg, err := qwenWS.ReadMessage()
+			if err != nil {
+				// 连接因 ctx 取消被关闭
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure) || strings.Contains(err.Error(), "context canceled") {
+					return nil
+				}
+				return fmt.Errorf("读取 Qwen-TTS 消息失败: %w", err)
+			}
+
+			if msgType != websocket.TextMessage {
+				continue
+			}
+
+			var event struct {
+				Type  string `json:"type"`
+				Delta string `json:"delta"`
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(msg, &event); err != nil {
+				zap.L().Warn("解析 Qwen-TTS JSON 失败", zap.Error(err))
+				continue
+			}
+
+			switch event.Type {
+			case "session.updated":
+				select {
+				case sessionUpdated <- struct{}{}:
+				default:
+				}
+			case "response.audio.delta":
+				audio, err := base64.StdEncoding.DecodeString(event.Delta)
+				if err != nil {
+					zap.L().Warn("解码 Qwen-TTS 音频失败", zap.Error(err))
+					continue
+				}
+				if err := out.writeAudio(audio); err != nil {
+					return fmt.Errorf("转发音频失败: %w", err)
+				}
+			case "session.finished":
+				zap.L().Info("Qwen-TTS 会话完成")
+				return nil
+			case "error":
+				return fmt.Errorf("Qwen-TTS 任务失败: %s - %s", event.Error.Code, event.Error.Message)
+			}
+		}
+	}
+}
+
+// lastRune 返回文本的最后一个字符
+func lastRune(text string) string {
+	text = strings.TrimRight(text, " \t")
+	if text == "" {
+		return ""
+	}
+	runes := []rune(text)
+	return string(runes[len(runes)-1])
+}
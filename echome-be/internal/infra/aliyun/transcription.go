@@ -0,0 +1,334 @@
+package aliyun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/infra/audio"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	fileTranscriptionAPIURL = "https://dashscope.aliyuncs.com/api/v1/services/audio/asr/transcription"
+	taskQueryAPIURL         = "https://dashscope.aliyuncs.com/api/v1/tasks/"
+	// fileTranscriptionModel 录音文件识别模型
+	fileTranscriptionModel = "paraformer-v2"
+	// transcriptionPollInterval 查询转写任务状态的间隔
+	transcriptionPollInterval = 3 * time.Second
+)
+
+const (
+	// fileStreamChunkMs 文件分块送入实时识别时每块音频的时长
+	fileStreamChunkMs = 100
+	// fileStreamSpeedup 文件送入实时识别的倍速，过快可能被服务端拒绝
+	fileStreamSpeedup = 4
+)
+
+// FileTranscriptionRequest 录音文件识别API请求结构
+type FileTranscriptionRequest struct {
+	Model string `json:"model"`
+	Input struct {
+		FileURLs []string `json:"file_urls"`
+	} `json:"input"`
+	Parameters struct {
+		LanguageHints []string `json:"language_hints,omitempty"`
+	} `json:"parameters"`
+}
+
+// TranscriptionTaskResponse 异步任务提交及查询的响应结构
+type TranscriptionTaskResponse struct {
+	Output struct {
+		TaskID     string `json:"task_id"`
+		TaskStatus string `json:"task_status"`
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		Results    []struct {
+			FileURL          string `json:"file_url"`
+			TranscriptionURL string `json:"transcription_url"`
+			SubtaskStatus    string `json:"subtask_status"`
+			Code             string `json:"code"`
+			Message          string `json:"message"`
+		} `json:"results"`
+	} `json:"output"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// transcriptionResult transcription_url 指向的识别结果文件
+type transcriptionResult struct {
+	Transcripts []struct {
+		ChannelID int    `json:"channel_id"`
+		Text      string `json:"text"`
+		Sentences []struct {
+			BeginTime int    `json:"begin_time"`
+			EndTime   int    `json:"end_time"`
+			Text      string `json:"text"`
+		} `json:"sentences"`
+	} `json:"transcripts"`
+}
+
+// TranscribeURL 提交录音文件识别任务并轮询直到完成
+func (client *AliClient) TranscribeURL(ctx context.Context, fileURL string) (*ai.Transcript, error) {
+	if fileURL == "" {
+		return nil, fmt.Errorf("音频URL不能为空")
+	}
+
+	requestBody := FileTranscriptionRequest{Model: fileTranscriptionModel}
+	requestBody.Input.FileURLs = []string{fileURL}
+	requestBody.Parameters.LanguageHints = DefaultASRConfig().LanguageHints
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求体失败: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fileTranscriptionAPIURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-DashScope-Async", "enable")
+
+	submitted, err := client.doTranscriptionRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("提交转写任务失败: %w", err)
+	}
+	taskID := submitted.Output.TaskID
+	if taskID == "" {
+		return nil, fmt.Errorf("未返回task_id")
+	}
+	zap.L().Info("录音文件识别任务已提交", zap.String("taskID", taskID))
+
+	ticker := time.NewTicker(transcriptionPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, taskQueryAPIURL+taskID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+		}
+		task, err := client.doTranscriptionRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("查询转写任务失败: %w", err)
+		}
+
+		switch task.Output.TaskStatus {
+		case "PENDING", "RUNNING":
+			continue
+		case "SUCCEEDED":
+			if len(task.Output.Results) == 0 {
+				return nil, fmt.Errorf("转写任务未返回结果")
+			}
+			result := task.Output.Results[0]
+			if result.SubtaskStatus != "SUCCEEDED" {
+				return nil, fmt.Errorf("转写失败: %s %s", result.Code, result.Message)
+			}
+			return client.fetchTranscriptionResult(ctx, result.TranscriptionURL)
+		default:
+			return nil, fmt.Errorf("转写任务状态 %s: %s %s", task.Output.TaskStatus, task.Output.Code, task.Output.Message)
+		}
+	}
+}
+
+// doTranscriptionRequest 发送请求并解析异步任务响应
+func (client *AliClient) doTranscriptionRequest(req *http.Request) (*TranscriptionTaskResponse, error) {
+	req.Header.Set("Authorization", "Bearer "+client.apiKey)
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %w", err)
+	}
+
+	var apiResponse TranscriptionTaskResponse
+	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w，原始响应: %s", err, string(responseBody))
+	}
+	if resp.StatusCode != http.StatusOK || apiResponse.Code != "" {
+		return nil, fmt.Errorf("%s %s", apiResponse.Code, apiResponse.Message)
+	}
+	return &apiResponse, nil
+}
+
+// fetchTranscriptionResult 下载识别结果，合并所有声道的句子并按时间排序
+func (client *AliClient) fetchTranscriptionResult(ctx context.Context, url string) (*ai.Transcript, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("下载转写结果失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("下载转写结果失败: 状态码 %d", resp.StatusCode)
+	}
+
+	var result transcriptionResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析转写结果失败: %w", err)
+	}
+
+	transcript := &ai.Transcript{Sentences: []ai.TranscriptSentence{}}
+	for _, channel := range result.Transcripts {
+		for _, sentence := range channel.Sentences {
+			transcript.Sentences = append(transcript.Sentences, ai.TranscriptSentence{
+				BeginTime: sentence.BeginTime,
+				EndTime:   sentence.EndTime,
+				Text:      sentence.Text,
+			})
+		}
+	}
+	slices.SortStableFunc(transcript.Sentences, func(a, b ai.TranscriptSentence) int {
+		return a.BeginTime - b.BeginTime
+	})
+	transcript.Text = joinSentences(transcript.Sentences)
+	return transcript, nil
+}
+
+// TranscribeAudio 把音频文件解码为 PCM，按固定倍速分块送入实时识别，汇总结束的句子
+func (client *AliClient) TranscribeAudio(ctx context.Context, r io.Reader, format string) (*ai.Transcript, error) {
+	config := DefaultASRConfig()
+
+	var converter audio.Converter
+	var err error
+	switch strings.ToLower(format) {
+	case audio.FormatWAV:
+		converter, _, err = audio.NewConverter(audio.FormatWAV, 0, 0, config.SampleRate)
+	case audio.FormatMP3:
+		var sampleRate int
+		r, sampleRate, err = audio.DecodeMP3(r)
+		if err != nil {
+			return nil, err
+		}
+		converter, _, err = audio.NewConverter(audio.FormatPCM, sampleRate, audio.MP3Channels, config.SampleRate)
+	default:
+		return nil, fmt.Errorf("不支持的音频格式: %s，实时转写仅支持 wav、mp3", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	asrWS, taskID, err := connectToModelStudioASR(client.apiKey, config)
+	if err != nil {
+		return nil, fmt.Errorf("连接Model Studio ASR失败: %w", err)
+	}
+	defer asrWS.Close()
+
+	transcript := &ai.Transcript{Sentences: []ai.TranscriptSentence{}}
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer sendASRFinishTask(asrWS, taskID)
+		return streamAudioFile(ctx, asrWS, r, converter, config.SampleRate)
+	})
+
+	g.Go(func() error {
+		return handleModelStudioASRResults(ctx, asrWS, nil, func(result ai.ASRResult) error {
+			if result.SentenceEnd {
+				transcript.Sentences = append(transcript.Sentences, ai.TranscriptSentence{
+					BeginTime: result.BeginTime,
+					EndTime:   result.EndTime,
+					Text:      result.Text,
+				})
+			}
+			return nil
+		})
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	transcript.Text = joinSentences(transcript.Sentences)
+	return transcript, nil
+}
+
+// streamAudioFile 读取音频文件，转换为 PCM 后按固定倍速分块发送
+func streamAudioFile(ctx context.Context, asrWS *websocket.Conn, r io.Reader, converter audio.Converter, sampleRate int) error {
+	chunkInterval := time.Duration(fileStreamChunkMs/fileStreamSpeedup) * time.Millisecond
+	chunkSize := sampleRate * 2 * fileStreamChunkMs / 1000
+
+	var pending []byte
+	send := func(final bool) error {
+		for len(pending) >= chunkSize || (final && len(pending) > 0) {
+			n := min(chunkSize, len(pending))
+			if err := sendASRAudio(asrWS, pending[:n]); err != nil {
+				return err
+			}
+			pending = pending[n:]
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(chunkInterval):
+			}
+		}
+		return nil
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			converted, err := converter.Convert(buf[:n])
+			if err != nil {
+				return fmt.Errorf("音频转换失败: %w", err)
+			}
+			pending = append(pending, converted...)
+			if err := send(false); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("读取音频文件失败: %w", readErr)
+		}
+	}
+
+	rest, err := converter.Flush()
+	if err != nil {
+		return fmt.Errorf("音频转换失败: %w", err)
+	}
+	pending = append(pending, rest...)
+	return send(true)
+}
+
+// joinSentences 拼接句子文本，英文句子之间补空格
+func joinSentences(sentences []ai.TranscriptSentence) string {
+	var sb strings.Builder
+	for _, sentence := range sentences {
+		text := strings.TrimSpace(sentence.Text)
+		if text == "" {
+			continue
+		}
+		if sb.Len() > 0 && text[0] < utf8.RuneSelf {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
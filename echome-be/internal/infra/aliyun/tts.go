@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -14,32 +15,46 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/justin/echome-be/internal/domain/ai"
-	"github.com/justin/echome-be/internal/domain/ws"
 	"golang.org/x/sync/errgroup"
 )
 
 // HandleTTS 处理 TTS 请求，直接使用传入的文本
-func (client *AliClient) HandleTTS(ctx context.Context, clientWS ws.WebSocketConn, text string, config ai.TTSConfig) error {
+func (client *AliClient) HandleTTS(ctx context.Context, w io.Writer, text string, config ai.TTSConfig) error {
 	textCh := make(chan string, 1)
 	textCh <- text
 	close(textCh)
-	return client.HandleCosyVoiceTTS(ctx, clientWS, textCh, config)
+	return client.HandleStreamTTS(ctx, w, textCh, config)
 }
 
-func (client *AliClient) HandleCosyVoiceTTS(ctx context.Context, clientWS ws.WebSocketConn, textStream <-chan string, config ai.TTSConfig) error {
+// HandleCosyVoiceTTS 通过 CosyVoice run-task/continue-task 协议合成语音
+// 启用短句缓存且输出 pcm/wav 时，缓存命中的句子直接返回音频，未命中的短句合成后写入缓存
+// mp3 和 opus 每次合成都带有各自的容器头，分句合成的音频无法直接拼接，不使用缓存
+func (client *AliClient) HandleCosyVoiceTTS(ctx context.Context, w io.Writer, textStream <-chan string, config ai.TTSConfig) error {
+	config = client.resolveTTSConfig(config)
+	out := newAudioOutput(w, config)
+	if client.ttsCache != nil && upstreamFormat(config.Format) == ai.AudioFormatPCM {
+		return client.handleCachedCosyVoiceTTS(ctx, out, textStream, config)
+	}
+
 	aliWS, err := connectToAliyunTTS(client.apiKey)
 	if err != nil {
 		return fmt.Errorf("连接阿里云 TTS 失败: %w", err)
 	}
 	defer aliWS.Close()
 
+	return runCosyVoiceTask(ctx, aliWS, textStream, config, out.writeAudio, out.newTaskTiming().writeSentence)
+}
+
+// runCosyVoiceTask 在已建立的连接上执行一次合成任务，任务结束后连接可以复用
+// onAudio 接收音频帧，onSentence 接收字词时间戳
+func runCosyVoiceTask(ctx context.Context, aliWS *websocket.Conn, textStream <-chan string, config ai.TTSConfig, onAudio func([]byte) error, onSentence func(cosyVoiceSentence) error) error {
 	taskID := uuid.NewString()
-	taskStarted := make(chan struct{})
+	taskStarted := make(chan struct{}, 1)
 	g, ctx := errgroup.WithContext(ctx)
 
 	// 1. 从阿里云读取消息并转发
 	g.Go(func() error {
-		return handleAliyunToClient(ctx, aliWS, clientWS, taskStarted)
+		return handleAliyunToClient(ctx, aliWS, onAudio, onSentence, taskStarted)
 	})
 
 	// 2. 发送指令到阿里云
@@ -76,6 +91,26 @@ func (client *AliClient) HandleCosyVoiceTTS(ctx context.Context, clientWS ws.Web
 	return g.Wait()
 }
 
+// resolveTTSConfig 用服务配置中的默认值补全未指定的 TTS 参数
+func (client *AliClient) resolveTTSConfig(config ai.TTSConfig) ai.TTSConfig {
+	if config.Model == "" {
+		config.Model = client.ttsConfig.Model
+	}
+	if config.Voice == "" {
+		config.Voice = client.ttsConfig.Voice
+	}
+	if config.Format == "" {
+		config.Format = client.ttsConfig.Format
+	}
+	if config.SampleRate == 0 {
+		config.SampleRate = client.ttsConfig.SampleRate
+	}
+	if config.Mode == "" {
+		config.Mode = client.ttsConfig.Mode
+	}
+	return config
+}
+
 // connectToAliyunTTS 建立 WebSocket 连接
 func connectToAliyunTTS(apiKey string) (*websocket.Conn, error) {
 	url := "wss://dashscope.aliyuncs.com/api-ws/v1/inference"
@@ -104,6 +139,23 @@ func connectToAliyunTTS(apiKey string) (*websocket.Conn, error) {
 
 // sendRunTask 发送开启任务指令
 func sendRunTask(ws *websocket.Conn, taskID string, config ai.TTSConfig) error {
+	parameters := map[string]interface{}{
+		"text_type":   "PlainText",
+		"voice":       config.Voice,
+		"format":      upstreamFormat(config.Format),
+		"sample_rate": config.SampleRate,
+		// 开启字级时间戳，用于对口型
+		"word_timestamp_enabled": true,
+	}
+	if config.Rate != 0 {
+		parameters["rate"] = config.Rate
+	}
+	if config.Pitch != 0 {
+		parameters["pitch"] = config.Pitch
+	}
+	if config.Volume != 0 {
+		parameters["volume"] = config.Volume
+	}
 	cmd := map[string]interface{}{
 		"header": map[string]interface{}{
 			"action":    "run-task",
@@ -115,13 +167,8 @@ func sendRunTask(ws *websocket.Conn, taskID string, config ai.TTSConfig) error {
 			"task":       "tts",
 			"function":   "SpeechSynthesizer",
 			"model":      config.Model,
-			"parameters": map[string]interface{}{
-				"text_type":   "PlainText",
-				"voice":       config.Voice,
-				"format":      config.Format,
-				"sample_rate": 22050,
-			},
-			"input": map[string]any{},
+			"parameters": parameters,
+			"input":      map[string]any{},
 		},
 	}
 	return ws.WriteJSON(cmd)
@@ -160,7 +207,7 @@ func sendFinishTask(ws *websocket.Conn, taskID string) error {
 }
 
 // handleAliyunToClient 从阿里云读取消息并转发
-func handleAliyunToClient(ctx context.Context, aliWS *websocket.Conn, writer ws.WebSocketConn, taskStarted chan<- struct{}) error {
+func handleAliyunToClient(ctx context.Context, aliWS *websocket.Conn, onAudio func([]byte) error, onSentence func(cosyVoiceSentence) error, taskStarted chan<- struct{}) error {
 	defer close(taskStarted)
 	for {
 		select {
@@ -177,7 +224,7 @@ func handleAliyunToClient(ctx context.Context, aliWS *websocket.Conn, writer ws.
 			}
 
 			if msgType == websocket.BinaryMessage {
-				if err := writer.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+				if err := onAudio(msg); err != nil {
 					return fmt.Errorf("转发音频失败: %w", err)
 				}
 				continue
@@ -216,7 +263,16 @@ func handleAliyunToClient(ctx context.Context, aliWS *websocket.Conn, writer ws.
 				errMsg, _ := header["error_message"].(string)
 				return fmt.Errorf("阿里云 TTS 任务失败: %s", errMsg)
 			case "result-generated":
-				// 忽略
+				var result cosyVoiceResult
+				if err := json.Unmarshal(msg, &result); err != nil {
+					zap.L().Warn("解析 TTS 时间戳失败", zap.Error(err))
+					continue
+				}
+				if sentence := result.Payload.Output.Sentence; sentence != nil {
+					if err := onSentence(*sentence); err != nil {
+						return fmt.Errorf("发送时间戳失败: %w", err)
+					}
+				}
 			}
 		}
 	}
@@ -0,0 +1,201 @@
+package aliyun
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/infra/ttscache"
+)
+
+// DefaultTTSCacheMaxTextLength 可缓存句子的默认最大字数
+const DefaultTTSCacheMaxTextLength = 20
+
+// sentenceTerminators 断句使用的句末标点，英文句号需后跟空白才断句
+const sentenceTerminators = "。！？!?；;\n…"
+
+// closingMarks 句末标点之后需要并入同一句的后引号和括号
+const closingMarks = "”’」』）)\"'"
+
+// handleCachedCosyVoiceTTS 按句处理文本流
+// 可缓存的短句先查缓存，未命中时单独合成并写入缓存；其余句子连续写入同一个流式合成任务
+// 所有任务复用同一个 CosyVoice 连接，并按文本顺序输出音频
+func (client *AliClient) handleCachedCosyVoiceTTS(ctx context.Context, out *audioOutput, textStream <-chan string, config ai.TTSConfig) error {
+	var aliWS *websocket.Conn
+	defer func() {
+		if aliWS != nil {
+			aliWS.Close()
+		}
+	}()
+	connect := func() (*websocket.Conn, error) {
+		if aliWS == nil {
+			conn, err := connectToAliyunTTS(client.apiKey)
+			if err != nil {
+				return nil, fmt.Errorf("连接阿里云 TTS 失败: %w", err)
+			}
+			aliWS = conn
+		}
+		return aliWS, nil
+	}
+
+	// 正在进行的流式合成任务
+	var streamCh chan string
+	var streamDone chan error
+	finishStream := func() error {
+		if streamCh == nil {
+			return nil
+		}
+		close(streamCh)
+		err := <-streamDone
+		streamCh, streamDone = nil, nil
+		return err
+	}
+
+	for sentence := range splitSentences(ctx, textStream) {
+		if !client.isTTSCacheable(sentence) {
+			if streamCh == nil {
+				conn, err := connect()
+				if err != nil {
+					return err
+				}
+				timing := out.newTaskTiming()
+				streamCh = make(chan string, 100)
+				streamDone = make(chan error, 1)
+				go func(ch <-chan string, done chan<- error) {
+					done <- runCosyVoiceTask(ctx, conn, ch, config, out.writeAudio, timing.writeSentence)
+				}(streamCh, streamDone)
+			}
+			select {
+			case streamCh <- sentence:
+			case err := <-streamDone:
+				streamCh, streamDone = nil, nil
+				if err == nil {
+					err = fmt.Errorf("TTS 流式任务提前结束")
+				}
+				return err
+			}
+			continue
+		}
+
+		// 短句之前的流式任务必须先结束，保证音频顺序
+		if err := finishStream(); err != nil {
+			return err
+		}
+
+		key := client.ttsCacheKey(config, sentence)
+		if data, ok := client.ttsCache.Get(key); ok {
+			if err := out.writeEstimatedTiming(strings.TrimSpace(sentence), len(data)); err != nil {
+				return fmt.Errorf("发送时间戳失败: %w", err)
+			}
+			if err := out.writeAudio(data); err != nil {
+				return fmt.Errorf("转发音频失败: %w", err)
+			}
+			continue
+		}
+
+		conn, err := connect()
+		if err != nil {
+			return err
+		}
+		var captured bytes.Buffer
+		textCh := make(chan string, 1)
+		textCh <- sentence
+		close(textCh)
+		err = runCosyVoiceTask(ctx, conn, textCh, config, func(data []byte) error {
+			captured.Write(data)
+			return out.writeAudio(data)
+		}, out.newTaskTiming().writeSentence)
+		if err != nil {
+			return err
+		}
+		client.ttsCache.Put(key, captured.Bytes())
+	}
+
+	return finishStream()
+}
+
+// isTTSCacheable 判断句子是否足够短，值得缓存
+func (client *AliClient) isTTSCacheable(sentence string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(sentence)) <= client.ttsCacheMaxTextLength
+}
+
+// ttsCacheKey 构建缓存键，wav 与 pcm 共享上游的 PCM 数据
+func (client *AliClient) ttsCacheKey(config ai.TTSConfig, sentence string) ttscache.Key {
+	return ttscache.Key{
+		Model:      config.Model,
+		Voice:      config.Voice,
+		Format:     upstreamFormat(config.Format),
+		SampleRate: config.SampleRate,
+		Rate:       config.Rate,
+		Pitch:      config.Pitch,
+		Volume:     config.Volume,
+		Text:       sentence,
+	}
+}
+
+// splitSentences 将流式文本切分为完整的句子，流结束时输出剩余文本
+func splitSentences(ctx context.Context, textStream <-chan string) <-chan string {
+	sentences := make(chan string, 16)
+	go func() {
+		defer close(sentences)
+		emit := func(sentence string) bool {
+			if strings.TrimSpace(sentence) == "" {
+				return true
+			}
+			select {
+			case sentences <- sentence:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var buf string
+		for text := range textStream {
+			buf += text
+			for {
+				sentence, rest, ok := cutSentence(buf)
+				if !ok {
+					break
+				}
+				if !emit(sentence) {
+					return
+				}
+				buf = rest
+			}
+		}
+		emit(buf)
+	}()
+	return sentences
+}
+
+// cutSentence 从文本开头切出第一个完整的句子
+func cutSentence(text string) (sentence, rest string, ok bool) {
+	runes := []rune(text)
+	for i, r := range runes {
+		end := -1
+		switch {
+		case strings.ContainsRune(sentenceTerminators, r):
+			end = i + 1
+		case r == '.' && i+1 < len(runes) && unicode.IsSpace(runes[i+1]):
+			end = i + 1
+		}
+		if end < 0 {
+			continue
+		}
+		for end < len(runes) && strings.ContainsRune(closingMarks+sentenceTerminators, runes[end]) {
+			end++
+		}
+		// 句末标点是最后一个字符时，后面可能还有后引号，等待更多文本
+		if end == len(runes) && r != '\n' {
+			return "", text, false
+		}
+		return string(runes[:end]), string(runes[end:]), true
+	}
+	return "", text, false
+}
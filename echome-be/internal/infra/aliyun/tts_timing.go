@@ -0,0 +1,173 @@
+package aliyun
+
+import (
+	"unicode/utf8"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/infra/lipsync"
+)
+
+// cosyVoiceResult result-generated 事件
+type cosyVoiceResult struct {
+	Payload struct {
+		Output struct {
+			Sentence *cosyVoiceSentence `json:"sentence"`
+		} `json:"output"`
+	} `json:"payload"`
+}
+
+// cosyVoiceSentence result-generated 事件中的句子时间戳，时间相对于本次任务音频的开头
+type cosyVoiceSentence struct {
+	Index     int             `json:"index"`
+	BeginTime int             `json:"begin_time"`
+	EndTime   int             `json:"end_time"`
+	Words     []cosyVoiceWord `json:"words"`
+}
+
+// cosyVoiceWord 字词级时间戳
+type cosyVoiceWord struct {
+	Text      string `json:"text"`
+	BeginTime int    `json:"begin_time"`
+	EndTime   int    `json:"end_time"`
+}
+
+// ttsTimingEvent 发送给客户端的 tts_timing 事件
+// 时间单位为毫秒，从本次输出的第一帧音频算起；pcm/wav 格式额外给出对应的音频字节偏移
+type ttsTimingEvent struct {
+	Type          string `json:"type"`
+	SentenceIndex int    `json:"sentence_index"`
+	// Estimated 为 true 时时间戳由音频时长均分估算（如缓存命中的句子）
+	Estimated bool          `json:"estimated,omitempty"`
+	Words     []timedWord   `json:"words"`
+	Visemes   []timedViseme `json:"visemes"`
+}
+
+// timedSpan 时间区间及对应的音频字节区间
+type timedSpan struct {
+	BeginTime  int  `json:"begin_time"`
+	EndTime    int  `json:"end_time"`
+	ByteOffset *int `json:"byte_offset,omitempty"`
+	ByteEnd    *int `json:"byte_end,omitempty"`
+}
+
+type timedWord struct {
+	Text string `json:"text"`
+	timedSpan
+}
+
+type timedViseme struct {
+	Viseme lipsync.Viseme `json:"viseme"`
+	timedSpan
+}
+
+// hasByteOffsets 是否可以由时间推算音频字节偏移，只有未压缩的 PCM 可以
+func (o *audioOutput) hasByteOffsets() bool {
+	return upstreamFormat(o.format) == ai.AudioFormatPCM
+}
+
+// msToBytes 把时长换算为 PCM 字节数，按采样对齐
+func (o *audioOutput) msToBytes(ms int) int {
+	return int(int64(ms)*int64(o.sampleRate)/1000) * pcmBitsPerSample / 8 * pcmChannels
+}
+
+// bytesToMs 把 PCM 字节数换算为时长
+func (o *audioOutput) bytesToMs(n int) int {
+	return int(int64(n) / int64(pcmBitsPerSample/8*pcmChannels) * 1000 / int64(o.sampleRate))
+}
+
+// position 当前已输出音频的时长
+// 压缩格式无法由字节数推算，按此前收到的时间戳估计，缓存命中的压缩音频不计入
+func (o *audioOutput) position() int {
+	if o.hasByteOffsets() {
+		return o.bytesToMs(o.written)
+	}
+	return o.elapsedMs
+}
+
+// span 把相对于 base 的时间区间换算为输出流中的时间与字节区间
+func (o *audioOutput) span(baseMs, baseBytes, begin, end int) timedSpan {
+	s := timedSpan{BeginTime: baseMs + begin, EndTime: baseMs + end}
+	if o.hasByteOffsets() {
+		offset, byteEnd := baseBytes+o.msToBytes(begin), baseBytes+o.msToBytes(end)
+		s.ByteOffset, s.ByteEnd = &offset, &byteEnd
+	}
+	return s
+}
+
+// visemes 推算一段文本的口型，时间相对于 base
+func (o *audioOutput) visemes(baseMs, baseBytes int, text string, begin, end int) []timedViseme {
+	frames := lipsync.FromText(text, begin, end)
+	visemes := make([]timedViseme, 0, len(frames))
+	for _, f := range frames {
+		visemes = append(visemes, timedViseme{
+			Viseme:    f.Viseme,
+			timedSpan: o.span(baseMs, baseBytes, f.BeginTime, f.EndTime),
+		})
+	}
+	return visemes
+}
+
+// writeEstimatedTiming 为没有上游时间戳的整段音频（如缓存命中）估算时间戳，须在写出音频之前调用
+func (o *audioOutput) writeEstimatedTiming(text string, audioBytes int) error {
+	if !o.hasByteOffsets() || utf8.RuneCountInString(text) == 0 {
+		return nil
+	}
+	baseMs, baseBytes := o.position(), o.written
+	duration := o.bytesToMs(audioBytes)
+	event := ttsTimingEvent{
+		Type:          "tts_timing",
+		SentenceIndex: o.sentences,
+		Estimated:     true,
+		Words:         []timedWord{{Text: text, timedSpan: o.span(baseMs, baseBytes, 0, duration)}},
+		Visemes:       o.visemes(baseMs, baseBytes, text, 0, duration),
+	}
+	o.sentences++
+	return o.writeEvent(event)
+}
+
+// taskTiming 把一次 CosyVoice 任务的时间戳换算到整个输出流上
+type taskTiming struct {
+	out          *audioOutput
+	baseMs       int
+	baseBytes    int
+	baseSentence int
+	// sent 每个句子已发送的字词数，上游会重复下发累积的字词
+	sent map[int]int
+}
+
+// newTaskTiming 在任务开始前调用，记录任务音频在输出流中的起点
+func (o *audioOutput) newTaskTiming() *taskTiming {
+	return &taskTiming{
+		out:          o,
+		baseMs:       o.position(),
+		baseBytes:    o.written,
+		baseSentence: o.sentences,
+		sent:         make(map[int]int),
+	}
+}
+
+// writeSentence 把句子中新增的字词时间戳和口型发送给客户端
+func (t *taskTiming) writeSentence(sentence cosyVoiceSentence) error {
+	words := sentence.Words[min(t.sent[sentence.Index], len(sentence.Words)):]
+	if len(words) == 0 {
+		return nil
+	}
+	t.sent[sentence.Index] += len(words)
+
+	o := t.out
+	event := ttsTimingEvent{
+		Type:          "tts_timing",
+		SentenceIndex: t.baseSentence + sentence.Index,
+		Words:         make([]timedWord, 0, len(words)),
+	}
+	for _, w := range words {
+		event.Words = append(event.Words, timedWord{Text: w.Text, timedSpan: o.span(t.baseMs, t.baseBytes, w.BeginTime, w.EndTime)})
+		event.Visemes = append(event.Visemes, o.visemes(t.baseMs, t.baseBytes, w.Text, w.BeginTime, w.EndTime)...)
+		o.elapsedMs = max(o.elapsedMs, t.baseMs+w.EndTime)
+	}
+	o.sentences = max(o.sentences, event.SentenceIndex+1)
+	if event.Visemes == nil {
+		event.Visemes = []timedViseme{}
+	}
+	return o.writeEvent(event)
+}
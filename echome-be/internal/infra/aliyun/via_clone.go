@@ -7,40 +7,70 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"github.com/justin/echome-be/internal/domain/ai"
 )
 
 // VoiceCloneRequest 阿里云声音复刻API请求结构
 type VoiceCloneRequest struct {
 	Model string `json:"model"`
 	Input struct {
-		Action      string `json:"action"`       // 固定为create_voice
-		TargetModel string `json:"target_model"` // 声音复刻使用的模型
-		Prefix      string `json:"prefix"`       // 音色自定义前缀
-		URL         string `json:"url"`          // 音频文件URL
-		VoiceID     string `json:"voice_id"`     // 查询时使用的音色ID
+		Action      string `json:"action"`                 // create_voice / query_voice / list_voice / update_voice / delete_voice
+		TargetModel string `json:"target_model,omitempty"` // 声音复刻使用的模型
+		Prefix      string `json:"prefix,omitempty"`       // 音色自定义前缀
+		URL         string `json:"url,omitempty"`          // 音频文件URL
+		VoiceID     string `json:"voice_id,omitempty"`     // 查询、更新、删除时使用的音色ID
+		PageIndex   int    `json:"page_index,omitempty"`   // 列表页码，从0开始
+		PageSize    int    `json:"page_size,omitempty"`    // 列表每页数量
 	} `json:"input"`
 }
 
 // VoiceCloneAPIResponse 阿里云声音复刻API响应结构
 type VoiceCloneAPIResponse struct {
 	Output struct {
-		VoiceID string `json:"voice_id"`
+		VoiceID     string          `json:"voice_id"`
+		Status      string          `json:"status"`
+		GmtCreate   string          `json:"gmt_create"`
+		GmtModified string          `json:"gmt_modified"`
+		VoiceList   []voiceListItem `json:"voice_list"`
 	} `json:"output"`
 	Usage struct {
 		Count int `json:"count"`
 	} `json:"usage"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
 	RequestID string `json:"request_id"`
 }
 
+// voiceListItem list_voice 返回的音色
+type voiceListItem struct {
+	VoiceID     string `json:"voice_id"`
+	Status      string `json:"status"`
+	GmtCreate   string `json:"gmt_create"`
+	GmtModified string `json:"gmt_modified"`
+}
+
 const (
 	apiURL = "https://dashscope.aliyuncs.com/api/v1/services/audio/tts/customization"
+	// VoicePrefix 本服务复刻音色的自定义前缀，用于区分账号下其他来源的音色
+	VoicePrefix = "echome"
+	// voiceTargetModel 复刻音色绑定的合成模型
+	voiceTargetModel = "cosyvoice-v2"
+	// voiceListPageSize 列出音色时每页数量
+	voiceListPageSize = 100
+	// voiceTimeLayout gmt_create/gmt_modified 的时间格式，时区为北京时间
+	voiceTimeLayout = "2006-01-02 15:04:05"
 )
 
+// voiceTimeZone 音色接口返回时间的时区
+var voiceTimeZone = time.FixedZone("CST", 8*60*60)
+
 // GetVoiceStatus 根据音色ID查询音色状态
-func (client *AliClient) GetVoiceStatus(ctx context.Context, voiceID string) (bool, error) {
+func (client *AliClient) GetVoiceStatus(ctx context.Context, voiceID string) (*ai.VoiceInfo, error) {
 	// 参数验证
 	if voiceID == "" {
-		return false, fmt.Errorf("音色ID不能为空")
+		return nil, fmt.Errorf("音色ID不能为空")
 	}
 
 	// 构建请求体
@@ -50,77 +80,120 @@ func (client *AliClient) GetVoiceStatus(ctx context.Context, voiceID string) (bo
 	requestBody.Input.Action = "query_voice"
 	requestBody.Input.VoiceID = voiceID
 
-	// 序列化请求体
-	jsonData, err := json.Marshal(requestBody)
+	apiResponse, err := client.callVoiceAPI(ctx, requestBody)
 	if err != nil {
-		return false, fmt.Errorf("序列化请求体失败: %w", err)
-	}
+		return nil, err
+	}
+	output := apiResponse.Output
+	if output.Status == "" {
+		return nil, fmt.Errorf("未返回音色状态")
+	}
+	return &ai.VoiceInfo{
+		VoiceID:   voiceID,
+		Status:    output.Status,
+		CreatedAt: parseVoiceTime(output.GmtCreate),
+		UpdatedAt: parseVoiceTime(output.GmtModified),
+	}, nil
+}
 
-	// 创建HTTP请求
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return false, fmt.Errorf("创建HTTP请求失败: %w", err)
+// 克隆声音接口
+func (client *AliClient) VoiceClone(ctx context.Context, url string) (*string, error) {
+	// 参数验证
+	if url == "" {
+		return nil, fmt.Errorf("音频URL不能为空")
 	}
 
-	// 设置请求头
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+client.apiKey)
-
-	// 发送请求
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return false, fmt.Errorf("发送请求失败: %w", err)
+	// 构建请求体
+	requestBody := VoiceCloneRequest{
+		Model: "voice-enrollment",
 	}
-	defer resp.Body.Close()
+	requestBody.Input.Action = "create_voice"
+	// 固定模型和音色前缀
+	requestBody.Input.TargetModel = voiceTargetModel
+	requestBody.Input.Prefix = VoicePrefix
+	requestBody.Input.URL = url
 
-	// 读取响应体
-	responseBody, err := io.ReadAll(resp.Body)
+	apiResponse, err := client.callVoiceAPI(ctx, requestBody)
 	if err != nil {
-		return false, fmt.Errorf("读取响应体失败: %w", err)
+		return nil, err
 	}
 
-	// 解析响应
-	var listResponse map[string]interface{}
-	if err := json.Unmarshal(responseBody, &listResponse); err != nil {
-		return false, fmt.Errorf("解析响应失败: %w，原始响应: %s", err, string(responseBody))
+	// 检查是否返回了voice_id
+	if apiResponse.Output.VoiceID == "" {
+		return nil, fmt.Errorf("未返回voice_id")
 	}
 
-	// 提取voices数组
-	output, ok := listResponse["output"].(map[string]interface{})
-	if !ok {
-		return false, nil
-	}
+	return &apiResponse.Output.VoiceID, nil
+}
 
-	voices, ok := output["status"]
-	if !ok {
-		return false, nil
+// ListVoices 分页列出本服务创建的全部音色
+func (client *AliClient) ListVoices(ctx context.Context) ([]ai.VoiceInfo, error) {
+	var voices []ai.VoiceInfo
+	for page := 0; ; page++ {
+		requestBody := VoiceCloneRequest{
+			Model: "voice-enrollment",
+		}
+		requestBody.Input.Action = "list_voice"
+		requestBody.Input.Prefix = VoicePrefix
+		requestBody.Input.PageIndex = page
+		requestBody.Input.PageSize = voiceListPageSize
+
+		apiResponse, err := client.callVoiceAPI(ctx, requestBody)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range apiResponse.Output.VoiceList {
+			voices = append(voices, ai.VoiceInfo{
+				VoiceID:   item.VoiceID,
+				Status:    item.Status,
+				CreatedAt: parseVoiceTime(item.GmtCreate),
+				UpdatedAt: parseVoiceTime(item.GmtModified),
+			})
+		}
+		if len(apiResponse.Output.VoiceList) < voiceListPageSize {
+			return voices, nil
+		}
 	}
-	if voices == "OK" {
-		return true, nil
-	}
-	return false, nil
 }
 
-// 克隆声音接口
-func (client *AliClient) VoiceClone(ctx context.Context, url string) (*string, error) {
-	// 参数验证
+// UpdateVoice 用新的音频样本重新复刻音色，音色ID不变，音色状态回到审核中
+func (client *AliClient) UpdateVoice(ctx context.Context, voiceID, url string) error {
+	if voiceID == "" {
+		return fmt.Errorf("音色ID不能为空")
+	}
 	if url == "" {
-		return nil, fmt.Errorf("音频URL不能为空")
+		return fmt.Errorf("音频URL不能为空")
 	}
 
-	// 固定音色前缀
-	prefix := "echome"
-
-	// 构建请求体
 	requestBody := VoiceCloneRequest{
 		Model: "voice-enrollment",
 	}
-	requestBody.Input.Action = "create_voice"
-	// 固定模型
-	requestBody.Input.TargetModel = "cosyvoice-v2"
-	requestBody.Input.Prefix = prefix
+	requestBody.Input.Action = "update_voice"
+	requestBody.Input.VoiceID = voiceID
 	requestBody.Input.URL = url
 
+	_, err := client.callVoiceAPI(ctx, requestBody)
+	return err
+}
+
+// DeleteVoice 删除音色，释放账号的音色配额
+func (client *AliClient) DeleteVoice(ctx context.Context, voiceID string) error {
+	if voiceID == "" {
+		return fmt.Errorf("音色ID不能为空")
+	}
+
+	requestBody := VoiceCloneRequest{
+		Model: "voice-enrollment",
+	}
+	requestBody.Input.Action = "delete_voice"
+	requestBody.Input.VoiceID = voiceID
+
+	_, err := client.callVoiceAPI(ctx, requestBody)
+	return err
+}
+
+// callVoiceAPI 调用声音复刻API
+func (client *AliClient) callVoiceAPI(ctx context.Context, requestBody VoiceCloneRequest) (*VoiceCloneAPIResponse, error) {
 	// 序列化请求体
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
@@ -128,7 +201,7 @@ func (client *AliClient) VoiceClone(ctx context.Context, url string) (*string, e
 	}
 
 	// 创建HTTP请求
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
 	}
@@ -155,11 +228,17 @@ func (client *AliClient) VoiceClone(ctx context.Context, url string) (*string, e
 	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w，原始响应: %s", err, string(responseBody))
 	}
-
-	// 检查是否返回了voice_id
-	if apiResponse.Output.VoiceID == "" {
-		return nil, fmt.Errorf("未返回voice_id")
+	if resp.StatusCode != http.StatusOK || apiResponse.Code != "" {
+		return nil, fmt.Errorf("声音复刻接口 %s 调用失败: %s %s", requestBody.Input.Action, apiResponse.Code, apiResponse.Message)
 	}
+	return &apiResponse, nil
+}
 
-	return &apiResponse.Output.VoiceID, nil
+// parseVoiceTime 解析音色接口返回的时间，格式不符时返回零值
+func parseVoiceTime(value string) time.Time {
+	t, err := time.ParseInLocation(voiceTimeLayout, value, voiceTimeZone)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
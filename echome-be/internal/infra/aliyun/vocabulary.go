@@ -0,0 +1,126 @@
+package aliyun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/justin/echome-be/internal/domain/ai"
+)
+
+const (
+	vocabularyAPIURL = "https://dashscope.aliyuncs.com/api/v1/services/audio/asr/customization"
+	// vocabularyPrefix 热词表自定义前缀
+	vocabularyPrefix = "echome"
+)
+
+// VocabularyRequest 阿里云热词定制API请求结构
+type VocabularyRequest struct {
+	Model string `json:"model"`
+	Input struct {
+		Action       string       `json:"action"`
+		TargetModel  string       `json:"target_model,omitempty"`
+		Prefix       string       `json:"prefix,omitempty"`
+		VocabularyID string       `json:"vocabulary_id,omitempty"`
+		Vocabulary   []ai.Hotword `json:"vocabulary,omitempty"`
+	} `json:"input"`
+}
+
+// VocabularyAPIResponse 阿里云热词定制API响应结构
+type VocabularyAPIResponse struct {
+	Output struct {
+		VocabularyID string `json:"vocabulary_id"`
+	} `json:"output"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// CreateVocabulary 创建热词表，热词表绑定默认的实时识别模型
+func (client *AliClient) CreateVocabulary(ctx context.Context, hotwords []ai.Hotword) (string, error) {
+	if len(hotwords) == 0 {
+		return "", fmt.Errorf("热词列表不能为空")
+	}
+
+	requestBody := VocabularyRequest{Model: "speech-biasing"}
+	requestBody.Input.Action = "create_vocabulary"
+	requestBody.Input.TargetModel = DefaultASRConfig().Model
+	requestBody.Input.Prefix = vocabularyPrefix
+	requestBody.Input.Vocabulary = hotwords
+
+	apiResponse, err := client.callVocabularyAPI(ctx, requestBody)
+	if err != nil {
+		return "", err
+	}
+	if apiResponse.Output.VocabularyID == "" {
+		return "", fmt.Errorf("未返回vocabulary_id")
+	}
+	return apiResponse.Output.VocabularyID, nil
+}
+
+// UpdateVocabulary 用新的热词列表覆盖热词表
+func (client *AliClient) UpdateVocabulary(ctx context.Context, vocabularyID string, hotwords []ai.Hotword) error {
+	if vocabularyID == "" {
+		return fmt.Errorf("热词表ID不能为空")
+	}
+
+	requestBody := VocabularyRequest{Model: "speech-biasing"}
+	requestBody.Input.Action = "update_vocabulary"
+	requestBody.Input.VocabularyID = vocabularyID
+	requestBody.Input.Vocabulary = hotwords
+
+	_, err := client.callVocabularyAPI(ctx, requestBody)
+	return err
+}
+
+// DeleteVocabulary 删除热词表
+func (client *AliClient) DeleteVocabulary(ctx context.Context, vocabularyID string) error {
+	if vocabularyID == "" {
+		return fmt.Errorf("热词表ID不能为空")
+	}
+
+	requestBody := VocabularyRequest{Model: "speech-biasing"}
+	requestBody.Input.Action = "delete_vocabulary"
+	requestBody.Input.VocabularyID = vocabularyID
+
+	_, err := client.callVocabularyAPI(ctx, requestBody)
+	return err
+}
+
+// callVocabularyAPI 调用热词定制API
+func (client *AliClient) callVocabularyAPI(ctx context.Context, requestBody VocabularyRequest) (*VocabularyAPIResponse, error) {
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求体失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, vocabularyAPIURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+client.apiKey)
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %w", err)
+	}
+
+	var apiResponse VocabularyAPIResponse
+	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w，原始响应: %s", err, string(responseBody))
+	}
+	if resp.StatusCode != http.StatusOK || apiResponse.Code != "" {
+		return nil, fmt.Errorf("热词接口 %s 调用失败: %s %s", requestBody.Input.Action, apiResponse.Code, apiResponse.Message)
+	}
+	return &apiResponse, nil
+}
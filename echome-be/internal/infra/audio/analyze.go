@@ -0,0 +1,139 @@
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	// analysisFrameMs 计算静音比例和响度时每帧的时长
+	analysisFrameMs = 20
+	// silenceThresholdDBFS 低于该电平的帧视为静音
+	silenceThresholdDBFS = -45.0
+	// clippingThreshold 达到该幅度的采样视为削波
+	clippingThreshold = 0.999
+	// minDBFS 电平下限，全静音时返回该值
+	minDBFS = -120.0
+)
+
+// SampleStats 音频文件的统计信息
+type SampleStats struct {
+	Format     string
+	SampleRate int
+	Channels   int
+	Duration   time.Duration
+	// PeakDBFS 峰值电平
+	PeakDBFS float64
+	// LoudnessDBFS 非静音部分的平均电平（RMS）
+	LoudnessDBFS float64
+	// ClippingRatio 削波采样所占比例
+	ClippingRatio float64
+	// SilenceRatio 静音帧所占比例
+	SilenceRatio float64
+}
+
+// DetectFormat 根据文件头判断音频格式，无法识别时返回空字符串
+func DetectFormat(data []byte) string {
+	switch {
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return FormatWAV
+	case len(data) >= 3 && string(data[0:3]) == "ID3",
+		len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		return FormatMP3
+	default:
+		return ""
+	}
+}
+
+// AnalyzeSample 解码完整的 wav（16 位 PCM）或 mp3 文件并统计电平、削波和静音
+func AnalyzeSample(data []byte) (*SampleStats, error) {
+	stats := &SampleStats{Format: DetectFormat(data)}
+
+	var pcm []byte
+	switch stats.Format {
+	case FormatWAV:
+		info, err := ParseWAVHeader(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse wav: %w", err)
+		}
+		if info.AudioFormat != 1 || info.BitsPerSample != 16 {
+			return nil, fmt.Errorf("unsupported wav encoding: format %d, %d bits, expected 16-bit PCM", info.AudioFormat, info.BitsPerSample)
+		}
+		pcm = data[info.DataOffset:]
+		if int(info.DataSize) < len(pcm) {
+			pcm = pcm[:info.DataSize]
+		}
+		stats.SampleRate, stats.Channels = info.SampleRate, info.Channels
+	case FormatMP3:
+		r, sampleRate, err := DecodeMP3(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if pcm, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("decode mp3: %w", err)
+		}
+		stats.SampleRate, stats.Channels = sampleRate, MP3Channels
+	default:
+		return nil, fmt.Errorf("unrecognized audio format")
+	}
+	if stats.Channels < 1 || stats.SampleRate <= 0 {
+		return nil, fmt.Errorf("invalid audio header: %d channels, %d Hz", stats.Channels, stats.SampleRate)
+	}
+
+	samples := DecodePCM16(pcm)
+	frames := len(samples) / stats.Channels
+	stats.Duration = time.Duration(frames) * time.Second / time.Duration(stats.SampleRate)
+	if frames == 0 {
+		stats.PeakDBFS, stats.LoudnessDBFS, stats.SilenceRatio = minDBFS, minDBFS, 1
+		return stats, nil
+	}
+
+	var peak float64
+	clipped := 0
+	for _, s := range samples {
+		v := math.Abs(float64(s))
+		peak = max(peak, v)
+		if v >= clippingThreshold {
+			clipped++
+		}
+	}
+	stats.PeakDBFS = toDBFS(peak)
+	stats.ClippingRatio = float64(clipped) / float64(len(samples))
+
+	mono := Downmix(samples, stats.Channels)
+	frameSize := max(1, stats.SampleRate*analysisFrameMs/1000)
+	silent, total := 0, 0
+	var activeSum float64
+	activeCount := 0
+	for start := 0; start < len(mono); start += frameSize {
+		frame := mono[start:min(start+frameSize, len(mono))]
+		var sum float64
+		for _, s := range frame {
+			sum += float64(s) * float64(s)
+		}
+		total++
+		if toDBFS(math.Sqrt(sum/float64(len(frame)))) < silenceThresholdDBFS {
+			silent++
+			continue
+		}
+		activeSum += sum
+		activeCount += len(frame)
+	}
+	stats.SilenceRatio = float64(silent) / float64(total)
+	stats.LoudnessDBFS = minDBFS
+	if activeCount > 0 {
+		stats.LoudnessDBFS = toDBFS(math.Sqrt(activeSum / float64(activeCount)))
+	}
+	return stats, nil
+}
+
+// toDBFS 把 [0, 1] 范围的幅度换算为 dBFS，不低于 minDBFS
+func toDBFS(amplitude float64) float64 {
+	if amplitude <= 0 {
+		return minDBFS
+	}
+	return max(minDBFS, 20*math.Log10(amplitude))
+}
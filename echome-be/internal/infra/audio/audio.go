@@ -0,0 +1,101 @@
+// Package audio 提供纯 Go 实现的音频接入处理：
+// PCM 解码、声道下混、多相重采样，以及 Ogg/WebM 封装的 Opus 转封装。
+// Opus 不在服务端解码，由 ASR 服务按 48kHz 解码，因此只接受单声道 Opus。
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 输入音频格式
+const (
+	FormatPCM  = "pcm"
+	FormatWAV  = "wav"
+	FormatOgg  = "ogg"
+	FormatOpus = "opus"
+	FormatWebM = "webm"
+)
+
+// MaxChannels PCM 输入允许的最大声道数
+const MaxChannels = 8
+
+// SupportedSampleRates PCM 输入允许的采样率
+// 重采样滤波器的长度随约分后的插值、抽取因子增长，任意采样率（如大素数）会分配巨大的滤波器，因此只接受常见采样率
+var SupportedSampleRates = []int{8000, 11025, 12000, 16000, 22050, 24000, 32000, 44100, 48000}
+
+// OpusSampleRate Opus 解码输出的采样率，与编码前的输入采样率无关（RFC 7845）
+const OpusSampleRate = 48000
+
+// ErrInvalidParams 客户端声明或文件头中的音频参数不受支持
+var ErrInvalidParams = errors.New("invalid audio parameters")
+
+// Converter 把客户端音频流转换为 ASR 可直接接收的数据
+type Converter interface {
+	// Convert 处理一段输入数据，返回可以发送给 ASR 的数据，可能为空
+	Convert(data []byte) ([]byte, error)
+	// Flush 输入结束时返回缓冲中的剩余数据
+	Flush() ([]byte, error)
+}
+
+// Output 描述转换后发送给 ASR 的音频格式
+type Output struct {
+	Format     string
+	SampleRate int
+}
+
+// NewConverter 根据客户端声明的输入格式创建转换器
+// PCM/WAV 会被下混为单声道并重采样到 targetRate；Ogg/Opus 校验声道数后原样透传，WebM/Opus 转封装为 Ogg/Opus
+// Opus 不解码也就无法下混，只接受单声道，输出采样率为 OpusSampleRate
+func NewConverter(format string, sampleRate, channels, targetRate int) (Converter, Output, error) {
+	if channels <= 0 {
+		channels = 1
+	}
+	if sampleRate <= 0 {
+		sampleRate = targetRate
+	}
+
+	switch strings.ToLower(format) {
+	case "", FormatPCM:
+		converter, err := newPCMConverter(sampleRate, channels, targetRate)
+		if err != nil {
+			return nil, Output{}, err
+		}
+		return converter, Output{Format: FormatPCM, SampleRate: targetRate}, nil
+	case FormatWAV:
+		return newWAVConverter(targetRate), Output{Format: FormatPCM, SampleRate: targetRate}, nil
+	case FormatOgg, FormatOpus:
+		if err := validateOpusChannels(channels); err != nil {
+			return nil, Output{}, err
+		}
+		return &oggOpusConverter{}, Output{Format: FormatOpus, SampleRate: OpusSampleRate}, nil
+	case FormatWebM:
+		if err := validateOpusChannels(channels); err != nil {
+			return nil, Output{}, err
+		}
+		return newWebMConverter(), Output{Format: FormatOpus, SampleRate: OpusSampleRate}, nil
+	default:
+		return nil, Output{}, fmt.Errorf("unsupported audio format: %s, supported: pcm, wav, ogg, opus, webm", format)
+	}
+}
+
+// validatePCMParams 校验 PCM 输入的采样率和声道数，不支持时返回包装 ErrInvalidParams 的错误
+func validatePCMParams(sampleRate, channels int) error {
+	if channels < 1 || channels > MaxChannels {
+		return fmt.Errorf("%w: unsupported channel count %d, expected 1-%d", ErrInvalidParams, channels, MaxChannels)
+	}
+	if !slices.Contains(SupportedSampleRates, sampleRate) {
+		return fmt.Errorf("%w: unsupported sample rate %d, supported: %v", ErrInvalidParams, sampleRate, SupportedSampleRates)
+	}
+	return nil
+}
+
+// validateOpusChannels 校验 Opus 的声道数，Opus 不在服务端解码，无法下混多声道音频
+func validateOpusChannels(channels int) error {
+	if channels != 1 {
+		return fmt.Errorf("%w: opus audio must be mono, got %d channels", ErrInvalidParams, channels)
+	}
+	return nil
+}
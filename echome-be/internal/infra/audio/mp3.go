@@ -0,0 +1,23 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// FormatMP3 MP3 音频文件
+const FormatMP3 = "mp3"
+
+// MP3Channels MP3 解码输出的声道数，单声道文件也会被解码为双声道
+const MP3Channels = 2
+
+// DecodeMP3 把 MP3 解码为 16 位小端 PCM，返回 PCM 数据流和采样率
+func DecodeMP3(r io.Reader) (io.Reader, int, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decode mp3: %w", err)
+	}
+	return decoder, decoder.SampleRate(), nil
+}
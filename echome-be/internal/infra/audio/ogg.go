@@ -0,0 +1,189 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// maxOggHeadPageSize 等待第一个 Ogg 页时最多缓冲的字节数，第一页只包含 OpusHead，通常不足 100 字节
+const maxOggHeadPageSize = 64 * 1024
+
+// Ogg 页头标志位
+const (
+	oggFlagBOS = 0x02 // 流开始
+	oggFlagEOS = 0x04 // 流结束
+)
+
+// oggCRCTable Ogg 使用的 CRC-32 表，多项式 0x04C11DB7，不反转
+var oggCRCTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = (r << 1) ^ 0x04C11DB7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return table
+}()
+
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = (crc << 8) ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// oggWriter 把数据包封装为 Ogg 页，每页一个数据包
+type oggWriter struct {
+	serial   uint32
+	sequence uint32
+}
+
+// page 构建一个 Ogg 页
+func (w *oggWriter) page(packet []byte, granule uint64, flags byte) []byte {
+	// 分段表：每 255 字节一个段，以小于 255 的段结束
+	segments := make([]byte, 0, len(packet)/255+1)
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			segments = append(segments, byte(n))
+			break
+		}
+		segments = append(segments, 255)
+	}
+
+	page := make([]byte, 27+len(segments)+len(packet))
+	copy(page[0:4], "OggS")
+	page[4] = 0 // 版本
+	page[5] = flags
+	binary.LittleEndian.PutUint64(page[6:14], granule)
+	binary.LittleEndian.PutUint32(page[14:18], w.serial)
+	binary.LittleEndian.PutUint32(page[18:22], w.sequence)
+	page[26] = byte(len(segments))
+	copy(page[27:], segments)
+	copy(page[27+len(segments):], packet)
+	binary.LittleEndian.PutUint32(page[22:26], oggCRC(page))
+
+	w.sequence++
+	return page
+}
+
+// opusHead 构建 OpusHead 标识头
+func opusHead(channels int, preSkip uint16, inputRate uint32) []byte {
+	head := make([]byte, 19)
+	copy(head[0:8], "OpusHead")
+	head[8] = 1 // 版本
+	head[9] = byte(channels)
+	binary.LittleEndian.PutUint16(head[10:12], preSkip)
+	binary.LittleEndian.PutUint32(head[12:16], inputRate)
+	// 输出增益为 0，声道映射族 0
+	return head
+}
+
+// opusTags 构建最小的 OpusTags 注释头
+func opusTags() []byte {
+	vendor := "echome"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags[0:8], "OpusTags")
+	binary.LittleEndian.PutUint32(tags[8:12], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	return tags
+}
+
+// opusPacketSamples 根据 TOC 字节计算 Opus 数据包在 48kHz 下的采样数
+func opusPacketSamples(packet []byte) uint64 {
+	if len(packet) == 0 {
+		return 0
+	}
+	toc := packet[0]
+	config := toc >> 3
+
+	// 单帧时长，以 48kHz 采样数计
+	var frameSamples uint64
+	switch {
+	case config < 12: // SILK: 10/20/40/60ms
+		frameSamples = []uint64{480, 960, 1920, 2880}[config%4]
+	case config < 16: // Hybrid: 10/20ms
+		frameSamples = []uint64{480, 960}[config%2]
+	default: // CELT: 2.5/5/10/20ms
+		frameSamples = []uint64{120, 240, 480, 960}[config%4]
+	}
+
+	var frames uint64
+	switch toc & 0x03 {
+	case 0:
+		frames = 1
+	case 1, 2:
+		frames = 2
+	case 3:
+		if len(packet) < 2 {
+			return 0
+		}
+		frames = uint64(packet[1] & 0x3F)
+	}
+	return frames * frameSamples
+}
+
+// oggOpusConverter 检查 Ogg/Opus 流第一页的 OpusHead，确认是单声道后原样透传
+type oggOpusConverter struct {
+	head    []byte
+	checked bool
+}
+
+// Convert 实现 Converter 接口
+func (c *oggOpusConverter) Convert(data []byte) ([]byte, error) {
+	if c.checked {
+		return data, nil
+	}
+
+	c.head = append(c.head, data...)
+	channels, ok, err := parseOggOpusChannels(c.head)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if len(c.head) > maxOggHeadPageSize {
+			return nil, fmt.Errorf("ogg head page exceeds %d bytes", maxOggHeadPageSize)
+		}
+		return nil, nil
+	}
+	if err := validateOpusChannels(channels); err != nil {
+		return nil, err
+	}
+	c.checked = true
+	out := c.head
+	c.head = nil
+	return out, nil
+}
+
+// Flush 实现 Converter 接口
+func (c *oggOpusConverter) Flush() ([]byte, error) {
+	if !c.checked && len(c.head) > 0 {
+		return nil, fmt.Errorf("ogg stream ended before OpusHead")
+	}
+	return nil, nil
+}
+
+// parseOggOpusChannels 从 Ogg 流的第一页读取 OpusHead 中的声道数，数据不足时 ok 为 false
+func parseOggOpusChannels(data []byte) (channels int, ok bool, err error) {
+	if len(data) < 27 {
+		return 0, false, nil
+	}
+	if string(data[0:4]) != "OggS" || data[5]&oggFlagBOS == 0 {
+		return 0, false, fmt.Errorf("not an ogg stream")
+	}
+	bodyStart := 27 + int(data[26])
+	if len(data) < bodyStart+19 {
+		return 0, false, nil
+	}
+	body := data[bodyStart:]
+	if string(body[0:8]) != "OpusHead" {
+		return 0, false, fmt.Errorf("ogg stream is not opus")
+	}
+	return int(body[9]), true, nil
+}
@@ -0,0 +1,95 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// pcmConverter 将 16 位小端交织 PCM 下混为单声道并重采样
+type pcmConverter struct {
+	channels  int
+	carry     []byte
+	resampler *Resampler
+}
+
+// newPCMConverter 创建 PCM 转换器，采样率或声道数不受支持时返回包装 ErrInvalidParams 的错误
+func newPCMConverter(sampleRate, channels, targetRate int) (*pcmConverter, error) {
+	if err := validatePCMParams(sampleRate, channels); err != nil {
+		return nil, err
+	}
+	c := &pcmConverter{channels: channels}
+	if sampleRate != targetRate {
+		c.resampler = NewResampler(sampleRate, targetRate)
+	}
+	return c, nil
+}
+
+// Convert 实现 Converter 接口
+func (c *pcmConverter) Convert(data []byte) ([]byte, error) {
+	// 单声道且无需重采样时直接透传
+	if c.channels == 1 && c.resampler == nil && len(c.carry) == 0 && len(data)%2 == 0 {
+		return data, nil
+	}
+
+	frameSize := 2 * c.channels
+	if len(c.carry) > 0 {
+		data = append(c.carry, data...)
+		c.carry = nil
+	}
+	if rest := len(data) % frameSize; rest != 0 {
+		// 不完整的采样帧留到下一次处理
+		c.carry = append([]byte(nil), data[len(data)-rest:]...)
+		data = data[:len(data)-rest]
+	}
+
+	samples := Downmix(DecodePCM16(data), c.channels)
+	if c.resampler != nil {
+		samples = c.resampler.Process(samples)
+	}
+	return EncodePCM16(samples), nil
+}
+
+// Flush 实现 Converter 接口
+func (c *pcmConverter) Flush() ([]byte, error) {
+	c.carry = nil
+	if c.resampler == nil {
+		return nil, nil
+	}
+	return EncodePCM16(c.resampler.Flush()), nil
+}
+
+// DecodePCM16 将 16 位小端 PCM 字节解码为 [-1, 1] 范围的浮点采样
+func DecodePCM16(data []byte) []float32 {
+	samples := make([]float32, len(data)/2)
+	for i := range samples {
+		samples[i] = float32(int16(binary.LittleEndian.Uint16(data[2*i:]))) / 32768
+	}
+	return samples
+}
+
+// EncodePCM16 将浮点采样编码为 16 位小端 PCM，超出范围的采样会被截断
+func EncodePCM16(samples []float32) []byte {
+	data := make([]byte, 2*len(samples))
+	for i, s := range samples {
+		v := math.Round(float64(s) * 32768)
+		v = math.Max(math.MinInt16, math.Min(math.MaxInt16, v))
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(int16(v)))
+	}
+	return data
+}
+
+// Downmix 将交织的多声道采样平均为单声道
+func Downmix(samples []float32, channels int) []float32 {
+	if channels <= 1 {
+		return samples
+	}
+	mono := make([]float32, len(samples)/channels)
+	for i := range mono {
+		var sum float32
+		for ch := 0; ch < channels; ch++ {
+			sum += samples[i*channels+ch]
+		}
+		mono[i] = sum / float32(channels)
+	}
+	return mono
+}
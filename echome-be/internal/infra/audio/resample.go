@@ -0,0 +1,112 @@
+package audio
+
+import "math"
+
+const (
+	// resampleZeroCrossings 原型低通滤波器在较低采样率一侧的零点个数，决定滤波器长度
+	resampleZeroCrossings = 16
+	// resampleRolloff 截止频率相对奈奎斯特频率的比例，留出过渡带
+	resampleRolloff = 0.92
+)
+
+// Resampler 流式多相重采样器
+// 以 L/M 的有理数比例进行插值和抽取，只计算实际需要输出的相位
+type Resampler struct {
+	up      int       // 插值因子 L
+	down    int       // 抽取因子 M
+	taps    int       // 每个相位的滤波器长度
+	phases  []float32 // 按相位重排的滤波器系数，phases[p*taps+j] = h[p+j*L]
+	history []float32 // 输入历史，前 taps-1 个为上一批的尾部
+	pos     int       // 下一个输出采样在插值域中相对 history 起点的位置
+}
+
+// NewResampler 创建从 inRate 到 outRate 的重采样器
+// 滤波器系数个数约为 32*max(L, M)，调用方需保证采样率来自 SupportedSampleRates 等有限集合
+func NewResampler(inRate, outRate int) *Resampler {
+	g := gcd(inRate, outRate)
+	up, down := outRate/g, inRate/g
+
+	// 插值域中的截止频率取输入、输出奈奎斯特频率中较低的一个
+	factor := max(up, down)
+	cutoff := resampleRolloff * 0.5 / float64(factor)
+	taps := (2*resampleZeroCrossings*factor + up - 1) / up
+	length := taps * up
+	center := float64(length-1) / 2
+
+	proto := make([]float64, length)
+	for n := range proto {
+		x := float64(n) - center
+		proto[n] = 2 * cutoff * sinc(2*cutoff*x) * blackman(n, length) * float64(up)
+	}
+
+	phases := make([]float32, length)
+	for p := 0; p < up; p++ {
+		for j := 0; j < taps; j++ {
+			phases[p*taps+j] = float32(proto[p+j*up])
+		}
+	}
+
+	return &Resampler{
+		up:      up,
+		down:    down,
+		taps:    taps,
+		phases:  phases,
+		history: make([]float32, taps-1),
+		pos:     (taps - 1) * up,
+	}
+}
+
+// Process 处理一批输入采样，返回当前可以确定的输出采样
+func (r *Resampler) Process(input []float32) []float32 {
+	r.history = append(r.history, input...)
+
+	var output []float32
+	for {
+		base := r.pos / r.up
+		if base >= len(r.history) {
+			break
+		}
+		phase := r.phases[(r.pos%r.up)*r.taps:][:r.taps]
+		var acc float32
+		for j, h := range phase {
+			acc += h * r.history[base-j]
+		}
+		output = append(output, acc)
+		r.pos += r.down
+	}
+
+	// 丢弃后续计算不再需要的历史采样
+	keepFrom := r.pos/r.up - (r.taps - 1)
+	if keepFrom > 0 {
+		r.history = append(r.history[:0], r.history[keepFrom:]...)
+		r.pos -= keepFrom * r.up
+	}
+	return output
+}
+
+// Flush 用静音填充滤波器延迟，输出剩余的采样
+func (r *Resampler) Flush() []float32 {
+	return r.Process(make([]float32, r.taps/2))
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+func blackman(n, length int) float64 {
+	if length == 1 {
+		return 1
+	}
+	a := 2 * math.Pi * float64(n) / float64(length-1)
+	return 0.42 - 0.5*math.Cos(a) + 0.08*math.Cos(2*a)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
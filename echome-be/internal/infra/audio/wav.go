@@ -0,0 +1,117 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// maxWAVHeaderSize 等待 data 块之前最多缓冲的字节数
+const maxWAVHeaderSize = 64 * 1024
+
+// WAVInfo WAV 文件的 fmt 块信息
+type WAVInfo struct {
+	AudioFormat   uint16
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+	// DataOffset data 块数据在文件中的起始位置
+	DataOffset int
+	// DataSize data 块长度，流式 WAV 可能为占位值
+	DataSize uint32
+}
+
+// errWAVIncomplete 文件头还不完整，需要更多数据
+var errWAVIncomplete = errors.New("incomplete wav header")
+
+// ParseWAVHeader 解析 WAV 文件头，定位 data 块
+func ParseWAVHeader(data []byte) (*WAVInfo, error) {
+	if len(data) < 12 {
+		return nil, errWAVIncomplete
+	}
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF/WAVE stream")
+	}
+
+	info := &WAVInfo{}
+	offset := 12
+	for {
+		if len(data) < offset+8 {
+			return nil, errWAVIncomplete
+		}
+		chunkID := string(data[offset : offset+4])
+		chunkSize := binary.LittleEndian.Uint32(data[offset+4 : offset+8])
+		body := offset + 8
+
+		switch chunkID {
+		case "fmt ":
+			if len(data) < body+16 {
+				return nil, errWAVIncomplete
+			}
+			info.AudioFormat = binary.LittleEndian.Uint16(data[body:])
+			info.Channels = int(binary.LittleEndian.Uint16(data[body+2:]))
+			info.SampleRate = int(binary.LittleEndian.Uint32(data[body+4:]))
+			info.BitsPerSample = int(binary.LittleEndian.Uint16(data[body+14:]))
+		case "data":
+			if info.SampleRate == 0 {
+				return nil, fmt.Errorf("wav data chunk before fmt chunk")
+			}
+			info.DataOffset = body
+			info.DataSize = chunkSize
+			return info, nil
+		}
+
+		// RIFF 块按偶数字节对齐
+		offset = body + int(chunkSize) + int(chunkSize&1)
+	}
+}
+
+// wavConverter 解析流开头的 WAV 文件头，之后按 PCM 处理
+type wavConverter struct {
+	targetRate int
+	header     []byte
+	pcm        *pcmConverter
+}
+
+func newWAVConverter(targetRate int) *wavConverter {
+	return &wavConverter{targetRate: targetRate}
+}
+
+// Convert 实现 Converter 接口
+func (c *wavConverter) Convert(data []byte) ([]byte, error) {
+	if c.pcm != nil {
+		return c.pcm.Convert(data)
+	}
+
+	c.header = append(c.header, data...)
+	info, err := ParseWAVHeader(c.header)
+	if errors.Is(err, errWAVIncomplete) {
+		if len(c.header) > maxWAVHeaderSize {
+			return nil, fmt.Errorf("wav header exceeds %d bytes", maxWAVHeaderSize)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.AudioFormat != 1 || info.BitsPerSample != 16 {
+		return nil, fmt.Errorf("%w: unsupported wav encoding: format %d, %d bits, only 16-bit PCM is supported", ErrInvalidParams, info.AudioFormat, info.BitsPerSample)
+	}
+
+	// 文件头中的声道数和采样率来自客户端，建立转换器前先校验，声道数为 0 会导致按帧切分时除零
+	c.pcm, err = newPCMConverter(info.SampleRate, info.Channels, c.targetRate)
+	if err != nil {
+		return nil, err
+	}
+	rest := c.header[info.DataOffset:]
+	c.header = nil
+	return c.pcm.Convert(rest)
+}
+
+// Flush 实现 Converter 接口
+func (c *wavConverter) Flush() ([]byte, error) {
+	if c.pcm == nil {
+		return nil, fmt.Errorf("wav stream ended before data chunk")
+	}
+	return c.pcm.Flush()
+}
@@ -0,0 +1,406 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// WebM/Matroska 中用到的 EBML 元素 ID
+const (
+	ebmlIDSegment      = 0x18538067
+	ebmlIDTracks       = 0x1654AE6B
+	ebmlIDTrackEntry   = 0xAE
+	ebmlIDTrackNumber  = 0xD7
+	ebmlIDTrackType    = 0x83
+	ebmlIDCodecID      = 0x86
+	ebmlIDCodecPrivate = 0x63A2
+	ebmlIDAudio        = 0xE1
+	ebmlIDSampling     = 0xB5
+	ebmlIDChannels     = 0x9F
+	ebmlIDCluster      = 0x1F43B675
+	ebmlIDBlockGroup   = 0xA0
+	ebmlIDBlock        = 0xA1
+	ebmlIDSimpleBlock  = 0xA3
+)
+
+// maxEBMLElementSize 单个叶子元素允许的最大长度，防止恶意数据耗尽内存
+const maxEBMLElementSize = 16 * 1024 * 1024
+
+// webmTrack 音轨信息
+type webmTrack struct {
+	number       uint64
+	trackType    uint64
+	codecID      string
+	codecPrivate []byte
+	sampleRate   float64
+	channels     uint64
+}
+
+// webmConverter 从 WebM 流中提取 Opus 音轨，并重新封装为 Ogg/Opus
+// 解析器把所有主元素展开为线性的元素序列，只在叶子元素上等待完整数据
+type webmConverter struct {
+	buf     []byte
+	skip    uint64
+	track   *webmTrack
+	current *webmTrack
+	ogg     oggWriter
+	granule uint64
+	// pending 暂存最后一个数据包，以便在流结束时打上 EOS 标志
+	pending []byte
+	started bool
+}
+
+func newWebMConverter() *webmConverter {
+	return &webmConverter{ogg: oggWriter{serial: 0x4563686F}}
+}
+
+// Convert 实现 Converter 接口
+func (c *webmConverter) Convert(data []byte) ([]byte, error) {
+	if c.skip > 0 {
+		n := min(c.skip, uint64(len(data)))
+		data = data[n:]
+		c.skip -= n
+	}
+	c.buf = append(c.buf, data...)
+
+	var out []byte
+	for {
+		id, idLen := readEBMLID(c.buf)
+		if idLen == 0 {
+			if len(c.buf) >= 4 {
+				return nil, fmt.Errorf("invalid webm element id")
+			}
+			break
+		}
+		size, sizeLen, unknown := readEBMLSize(c.buf[idLen:])
+		if sizeLen == 0 {
+			if len(c.buf)-idLen >= 8 {
+				return nil, fmt.Errorf("invalid webm element size")
+			}
+			break
+		}
+		headerLen := idLen + sizeLen
+
+		// 主元素：直接进入其子元素
+		if isEBMLMaster(id) || unknown {
+			if id == ebmlIDTrackEntry {
+				c.current = &webmTrack{}
+			}
+			c.buf = c.buf[headerLen:]
+			continue
+		}
+
+		// 不关心的大元素（如 Cues、附件）直接跳过，无需缓冲
+		if !isEBMLInteresting(id) {
+			available := uint64(len(c.buf) - headerLen)
+			if size > available {
+				c.skip = size - available
+				c.buf = c.buf[:0]
+				break
+			}
+			c.buf = c.buf[headerLen+int(size):]
+			continue
+		}
+
+		if size > maxEBMLElementSize {
+			return nil, fmt.Errorf("webm element 0x%X too large: %d bytes", id, size)
+		}
+		if uint64(len(c.buf)-headerLen) < size {
+			break
+		}
+		body := c.buf[headerLen : headerLen+int(size)]
+		packets, err := c.handleElement(id, body)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, packets...)
+		c.buf = c.buf[headerLen+int(size):]
+	}
+
+	// 释放已消费的底层数组
+	c.buf = append([]byte(nil), c.buf...)
+	return out, nil
+}
+
+// Flush 实现 Converter 接口
+func (c *webmConverter) Flush() ([]byte, error) {
+	if c.pending == nil {
+		return nil, nil
+	}
+	c.granule += opusPacketSamples(c.pending)
+	page := c.ogg.page(c.pending, c.granule, oggFlagEOS)
+	c.pending = nil
+	return page, nil
+}
+
+// handleElement 处理叶子元素，返回生成的 Ogg 页
+func (c *webmConverter) handleElement(id uint32, body []byte) ([]byte, error) {
+	track := c.current
+	switch id {
+	case ebmlIDTrackNumber:
+		if track != nil {
+			track.number = readEBMLUint(body)
+		}
+	case ebmlIDTrackType:
+		if track != nil {
+			track.trackType = readEBMLUint(body)
+			c.selectTrack(track)
+		}
+	case ebmlIDCodecID:
+		if track != nil {
+			track.codecID = string(body)
+			c.selectTrack(track)
+		}
+	case ebmlIDCodecPrivate:
+		if track != nil {
+			track.codecPrivate = append([]byte(nil), body...)
+		}
+	case ebmlIDSampling:
+		if track != nil {
+			track.sampleRate = readEBMLFloat(body)
+		}
+	case ebmlIDChannels:
+		if track != nil {
+			track.channels = readEBMLUint(body)
+		}
+	case ebmlIDSimpleBlock, ebmlIDBlock:
+		return c.handleBlock(body)
+	}
+	return nil, nil
+}
+
+// selectTrack 选择第一个 Opus 音轨
+func (c *webmConverter) selectTrack(track *webmTrack) {
+	if c.track == nil && track.trackType == 2 && track.codecID == "A_OPUS" {
+		c.track = track
+	}
+}
+
+// handleBlock 解析 Block/SimpleBlock，把 Opus 帧写成 Ogg 页
+func (c *webmConverter) handleBlock(body []byte) ([]byte, error) {
+	if c.track == nil {
+		return nil, fmt.Errorf("webm stream has no opus audio track")
+	}
+	trackNumber, n := readEBMLVint(body)
+	if n == 0 || len(body) < n+3 {
+		return nil, fmt.Errorf("invalid webm block")
+	}
+	if trackNumber != c.track.number {
+		return nil, nil
+	}
+	flags := body[n+2]
+	frames, err := splitLacedFrames(body[n+3:], (flags>>1)&0x03)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if !c.started {
+		if err := validateOpusChannels(c.track.opusChannels()); err != nil {
+			return nil, err
+		}
+		c.started = true
+		out = append(out, c.headerPages()...)
+	}
+	for _, frame := range frames {
+		if c.pending != nil {
+			c.granule += opusPacketSamples(c.pending)
+			out = append(out, c.ogg.page(c.pending, c.granule, 0)...)
+		}
+		c.pending = append([]byte(nil), frame...)
+	}
+	return out, nil
+}
+
+// opusChannels 返回音轨的声道数，优先使用 CodecPrivate 中的 OpusHead，未声明时视为单声道
+func (t *webmTrack) opusChannels() int {
+	if t.hasOpusHead() {
+		return int(t.codecPrivate[9])
+	}
+	return max(int(t.channels), 1)
+}
+
+// hasOpusHead CodecPrivate 是否为完整的 OpusHead
+func (t *webmTrack) hasOpusHead() bool {
+	return len(t.codecPrivate) >= 19 && string(t.codecPrivate[:8]) == "OpusHead"
+}
+
+// headerPages 构建 OpusHead 与 OpusTags 两个头页
+func (c *webmConverter) headerPages() []byte {
+	head := c.track.codecPrivate
+	if !c.track.hasOpusHead() {
+		channels := c.track.opusChannels()
+		rate := uint32(c.track.sampleRate)
+		if rate == 0 {
+			rate = OpusSampleRate
+		}
+		head = opusHead(channels, 312, rate)
+	}
+	out := c.ogg.page(head, 0, oggFlagBOS)
+	return append(out, c.ogg.page(opusTags(), 0, 0)...)
+}
+
+// splitLacedFrames 按 Matroska 的 lacing 方式拆分帧
+func splitLacedFrames(data []byte, lacing byte) ([][]byte, error) {
+	if lacing == 0 {
+		return [][]byte{data}, nil
+	}
+	if len(data) < 1 {
+		return nil, fmt.Errorf("invalid laced block")
+	}
+	count := int(data[0]) + 1
+	data = data[1:]
+	sizes := make([]int, count)
+
+	switch lacing {
+	case 1: // Xiph lacing
+		for i := 0; i < count-1; i++ {
+			for {
+				if len(data) == 0 {
+					return nil, fmt.Errorf("invalid xiph lacing")
+				}
+				b := data[0]
+				data = data[1:]
+				sizes[i] += int(b)
+				if b != 255 {
+					break
+				}
+			}
+		}
+	case 2: // 固定长度
+		if len(data)%count != 0 {
+			return nil, fmt.Errorf("invalid fixed-size lacing")
+		}
+		for i := range sizes {
+			sizes[i] = len(data) / count
+		}
+		return splitFrames(data, sizes)
+	case 3: // EBML lacing
+		first, n := readEBMLVint(data)
+		if n == 0 {
+			return nil, fmt.Errorf("invalid ebml lacing")
+		}
+		data = data[n:]
+		sizes[0] = int(first)
+		for i := 1; i < count-1; i++ {
+			raw, n := readEBMLVint(data)
+			if n == 0 {
+				return nil, fmt.Errorf("invalid ebml lacing")
+			}
+			data = data[n:]
+			// 有符号差值：减去偏置 2^(7n-1)-1
+			diff := int64(raw) - (int64(1)<<(7*n-1) - 1)
+			sizes[i] = sizes[i-1] + int(diff)
+		}
+	}
+
+	total := 0
+	for _, size := range sizes[:count-1] {
+		if size < 0 {
+			return nil, fmt.Errorf("invalid lacing size")
+		}
+		total += size
+	}
+	if total > len(data) {
+		return nil, fmt.Errorf("laced frames exceed block size")
+	}
+	sizes[count-1] = len(data) - total
+	return splitFrames(data, sizes)
+}
+
+func splitFrames(data []byte, sizes []int) ([][]byte, error) {
+	frames := make([][]byte, 0, len(sizes))
+	for _, size := range sizes {
+		if size > len(data) {
+			return nil, fmt.Errorf("laced frames exceed block size")
+		}
+		frames = append(frames, data[:size])
+		data = data[size:]
+	}
+	return frames, nil
+}
+
+func isEBMLMaster(id uint32) bool {
+	switch id {
+	case ebmlIDSegment, ebmlIDTracks, ebmlIDTrackEntry, ebmlIDAudio, ebmlIDCluster, ebmlIDBlockGroup:
+		return true
+	}
+	return false
+}
+
+func isEBMLInteresting(id uint32) bool {
+	switch id {
+	case ebmlIDTrackNumber, ebmlIDTrackType, ebmlIDCodecID, ebmlIDCodecPrivate,
+		ebmlIDSampling, ebmlIDChannels, ebmlIDSimpleBlock, ebmlIDBlock:
+		return true
+	}
+	return false
+}
+
+// readEBMLID 读取元素 ID（保留长度标记位），数据不足时返回长度 0
+func readEBMLID(data []byte) (uint32, int) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 4 || len(data) < length {
+		return 0, 0
+	}
+	var id uint32
+	for _, b := range data[:length] {
+		id = id<<8 | uint32(b)
+	}
+	return id, length
+}
+
+// readEBMLSize 读取元素长度，unknown 表示长度未知（全 1）
+func readEBMLSize(data []byte) (size uint64, length int, unknown bool) {
+	value, length := readEBMLVint(data)
+	if length == 0 {
+		return 0, 0, false
+	}
+	return value, length, value == (uint64(1)<<(7*length))-1
+}
+
+// readEBMLVint 读取去掉长度标记位的变长整数
+func readEBMLVint(data []byte) (uint64, int) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0
+	}
+	length := 1
+	mask := byte(0x80)
+	for data[0]&mask == 0 {
+		length++
+		mask >>= 1
+	}
+	if len(data) < length {
+		return 0, 0
+	}
+	value := uint64(data[0] & (mask - 1))
+	for _, b := range data[1:length] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length
+}
+
+func readEBMLUint(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func readEBMLFloat(data []byte) float64 {
+	switch len(data) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	}
+	return 0
+}
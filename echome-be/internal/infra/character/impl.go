@@ -2,11 +2,17 @@ package character
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/justin/echome-be/gen/gen/model"
 	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/ai"
 	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/samber/lo"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
 )
 
 // CharacterRepository 实现domain.CharacterRepository接口
@@ -24,164 +30,443 @@ func NewCharacterRepository(query *query.Query) *CharacterRepository {
 	}
 }
 
-// GetAll 获取所有角色
-func (r *CharacterRepository) GetAll(ctx context.Context) ([]*character.Character, error) {
-	charModels, err := r.query.Character.WithContext(ctx).Find()
+// List 按查询条件获取角色，按创建时间和ID倒序，按热度排序时先按对话次数倒序，游标之后的角色
+func (r *CharacterRepository) List(ctx context.Context, q character.ListQuery) ([]*character.Character, error) {
+	c := r.query.Character
+	do := r.filter(ctx, q)
+	popular := q.Sort == character.SortPopular
+	if q.Cursor != nil {
+		if popular {
+			do = do.Where(field.NewUnsafeFieldRaw("(characters.session_count, characters.created_at, characters.id) < (?, ?, ?)",
+				q.Cursor.SessionCount, q.Cursor.CreatedAt, q.Cursor.ID.String()))
+		} else {
+			do = do.Where(field.NewUnsafeFieldRaw("(characters.created_at, characters.id) < (?, ?)", q.Cursor.CreatedAt, q.Cursor.ID.String()))
+		}
+	}
+	if popular {
+		do = do.Order(c.SessionCount.Desc())
+	}
+
+	charModels, err := do.Order(c.CreatedAt.Desc(), c.ID.Desc()).Limit(q.Limit).Find()
 	if err != nil {
 		return nil, err
 	}
 
-	// 转换为domain.Character切片
-	characters := make([]*character.Character, 0, len(charModels))
-	for _, charModel := range charModels {
-		id, err := uuid.Parse(charModel.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		character := &character.Character{
-			ID:           id,
-			Name:         charModel.Name,
-			Prompt:       charModel.Prompt,
-			Description:  charModel.Description,
-			Status:       charModel.Status,
-			Avatar:       charModel.Avatar,
-			Voice:        charModel.Voice,
-			Flag:         charModel.Flag,
-			AudioExample: charModel.AudioExample,
-			CreatedAt:    charModel.CreatedAt,
-			UpdatedAt:    charModel.UpdatedAt,
-		}
-
-		characters = append(characters, character)
+	characters, err := toCharacters(charModels)
+	if err != nil {
+		return nil, err
 	}
+	return characters, r.attachTags(ctx, characters...)
+}
 
-	return characters, nil
+// filter 按查询条件中除游标以外的条件过滤角色
+func (r *CharacterRepository) filter(ctx context.Context, q character.ListQuery) query.ICharacterDo {
+	c := r.query.Character
+	do := c.WithContext(ctx)
+	switch {
+	case q.Viewer == nil:
+		do = do.Where(c.Visibility.Eq(character.VisibilityPublic))
+	case !q.Viewer.Admin:
+		do = do.Where(c.WithContext(ctx).Where(c.Visibility.Eq(character.VisibilityPublic)).Or(c.OwnerID.Eq(q.Viewer.ID)))
+	}
+	if q.OwnerID != nil {
+		do = do.Where(c.OwnerID.Eq(*q.OwnerID))
+	}
+	if q.Status != nil {
+		do = do.Where(c.Status.Eq(*q.Status))
+	}
+	if q.FavoritedBy != nil {
+		f := r.query.CharacterFavorite
+		do = do.Where(c.Columns(c.ID).In(f.WithContext(ctx).Select(f.CharacterID).Where(f.UserID.Eq(*q.FavoritedBy))))
+	}
+	if q.ReviewStatus != "" {
+		do = do.Where(c.ReviewStatus.Eq(q.ReviewStatus))
+	}
+	if q.Cloned != nil {
+		do = do.Where(c.Flag.Is(*q.Cloned))
+	}
+	if q.Tag != "" {
+		ct, t := r.query.CharacterTag, r.query.Tag
+		do = do.Where(c.Columns(c.ID).In(
+			ct.WithContext(ctx).Select(ct.CharacterID).Join(t, t.ID.EqCol(ct.TagID)).Where(t.Name.Eq(q.Tag)),
+		))
+	}
+	if tsquery := character.SearchQuery(q.Search); tsquery != "" {
+		do = do.Where(field.NewUnsafeFieldRaw("characters.search_vector @@ ?::tsquery", tsquery))
+	}
+	return do
 }
 
 // GetByID 根据ID获取角色
 func (r *CharacterRepository) GetByID(ctx context.Context, id uuid.UUID) (*character.Character, error) {
 	charModel, err := r.query.Character.WithContext(ctx).Where(r.query.Character.ID.Eq(id.String())).First()
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, character.ErrCharacterNotFound
+		}
 		return nil, err
 	}
 
-	// 转换为domain.Character
-	character := &character.Character{
-		ID:           id,
-		Name:         charModel.Name,
-		Prompt:       charModel.Prompt,
-		Description:  charModel.Description,
-		Status:       charModel.Status,
-		Avatar:       charModel.Avatar,
-		Voice:        charModel.Voice,
-		Flag:         charModel.Flag,
-		AudioExample: charModel.AudioExample,
-		CreatedAt:    charModel.CreatedAt,
-		UpdatedAt:    charModel.UpdatedAt,
+	found, err := toCharacter(charModel)
+	if err != nil {
+		return nil, err
+	}
+	return found, r.attachTags(ctx, found)
+}
+
+// GetDeletedByID 获取已软删除的角色
+func (r *CharacterRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*character.Character, error) {
+	charModel, err := r.query.Character.WithContext(ctx).Unscoped().
+		Where(r.query.Character.ID.Eq(id.String()), r.query.Character.DeletedAt.IsNotNull()).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, character.ErrCharacterNotFound
+		}
+		return nil, err
 	}
 
-	return character, nil
+	found, err := toCharacter(charModel)
+	if err != nil {
+		return nil, err
+	}
+	return found, r.attachTags(ctx, found)
 }
 
 // Save 保存角色
 func (r *CharacterRepository) Save(ctx context.Context, character *character.Character) error {
-	modelChar := &model.Character{
-		Name:         character.Name,
-		Prompt:       character.Prompt,
-		Description:  character.Description,
-		Status:       character.Status,
-		Avatar:       character.Avatar,
-		Voice:        character.Voice,
-		Flag:         character.Flag,
-		AudioExample: character.AudioExample,
-		CreatedAt:    character.CreatedAt,
-		UpdatedAt:    character.UpdatedAt,
-	}
-	err := r.query.Character.WithContext(ctx).Save(modelChar)
+	hotwords, err := marshalList(character.Hotwords)
+	if err != nil {
+		return err
+	}
+	dialogue, err := marshalDialogue(character.AlternateGreetings, character.ExampleDialogues)
 	if err != nil {
 		return err
 	}
 
+	modelChar := &model.Character{
+		Name:               character.Name,
+		Prompt:             character.Prompt,
+		Greeting:           character.Greeting,
+		AlternateGreetings: dialogue.alternateGreetings,
+		ExampleDialogues:   dialogue.exampleDialogues,
+		Description:        character.Description,
+		OwnerID:            character.OwnerID,
+		Visibility:         character.Visibility,
+		ForkedFrom:         uuidString(character.ForkedFrom),
+		AllowFork:          character.AllowFork,
+		License:            character.License,
+		TTSModel:           character.Speech.TTSModel,
+		SpeechRate:         character.Speech.Rate,
+		SpeechPitch:        character.Speech.Pitch,
+		SpeechVolume:       int32(character.Speech.Volume),
+		Status:             character.Status,
+		StatusReason:       character.StatusReason,
+		VoiceStatus:        character.VoiceStatus,
+		ReviewStatus:       character.ReviewStatus,
+		ReviewReason:       character.ReviewReason,
+		Avatar:             character.Avatar,
+		Voice:              character.Voice,
+		Flag:               character.Flag,
+		AudioExample:       character.AudioExample,
+		Hotwords:           hotwords,
+		VocabularyID:       character.VocabularyID,
+		VoiceID:            uuidString(character.VoiceID),
+		SearchText:         searchText(character),
+		CreatedAt:          character.CreatedAt,
+		UpdatedAt:          character.UpdatedAt,
+	}
+	err = r.query.Character.WithContext(ctx).Save(modelChar)
+	if err != nil {
+		return translateError(err)
+	}
+
+	// 回填数据库生成的ID
+	character.ID, err = uuid.Parse(modelChar.ID)
+	return err
+}
+
+// Delete 软删除角色
+func (r *CharacterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.query.Character.WithContext(ctx).Where(r.query.Character.ID.Eq(id.String())).Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return character.ErrCharacterNotFound
+	}
 	return nil
 }
 
-// Update 更新角色
+// Update 更新角色的可编辑字段和状态
 func (r *CharacterRepository) Update(ctx context.Context, character *character.Character) error {
-	// 创建更新字段的map
-	updateFields := make(map[string]any)
+	dialogue, err := marshalDialogue(character.AlternateGreetings, character.ExampleDialogues)
+	if err != nil {
+		return err
+	}
 
-	// 添加需要更新的字段
-	updateFields["name"] = character.Name
-	updateFields["prompt"] = character.Prompt
-	updateFields["flag"] = character.Flag
-	updateFields["status"] = character.Status
+	_, err = r.query.Character.WithContext(ctx).
+		Where(r.query.Character.ID.Eq(character.ID.String())).
+		Updates(map[string]any{
+			"name":                character.Name,
+			"prompt":              character.Prompt,
+			"greeting":            character.Greeting,
+			"alternate_greetings": dialogue.alternateGreetings,
+			"example_dialogues":   dialogue.exampleDialogues,
+			"description":         character.Description,
+			"avatar":              character.Avatar,
+			"audio_example":       character.AudioExample,
+			"visibility":          character.Visibility,
+			"allow_fork":          character.AllowFork,
+			"license":             character.License,
+			"tts_model":           character.Speech.TTSModel,
+			"speech_rate":         character.Speech.Rate,
+			"speech_pitch":        character.Speech.Pitch,
+			"speech_volume":       character.Speech.Volume,
+			"voice":               character.Voice,
+			"voice_id":            uuidString(character.VoiceID),
+			"flag":                character.Flag,
+			"status":              character.Status,
+			"status_reason":       character.StatusReason,
+			"voice_status":        character.VoiceStatus,
+			"review_status":       character.ReviewStatus,
+			"review_reason":       character.ReviewReason,
+			"search_text":         searchText(character),
+		})
+	return translateError(err)
+}
 
-	// 处理可空字段
-	if character.Avatar != nil {
-		updateFields["avatar"] = character.Avatar
+// Restore 恢复已软删除的角色
+func (r *CharacterRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := r.query.Character.WithContext(ctx).Unscoped().
+		Where(r.query.Character.ID.Eq(id.String()), r.query.Character.DeletedAt.IsNotNull()).
+		Update(r.query.Character.DeletedAt, nil)
+	if err != nil {
+		return translateError(err)
 	}
-	if character.AudioExample != nil {
-		updateFields["audio_example"] = character.AudioExample
+	if result.RowsAffected == 0 {
+		return character.ErrCharacterNotFound
 	}
+	return nil
+}
 
-	// 如果有Description字段，也添加到更新map中
-	if character.Description != nil {
-		updateFields["description"] = character.Description
-	}
+// Purge 在事务中彻底删除已软删除的角色及其关联记录，对话记录保留
+func (r *CharacterRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.query.Transaction(func(tx *query.Query) error {
+		c := tx.Character
+		result, err := c.WithContext(ctx).Unscoped().
+			Where(c.ID.Eq(id.String()), c.DeletedAt.IsNotNull()).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return character.ErrCharacterNotFound
+		}
 
-	// 使用map更新字段
-	_, err := r.query.Character.WithContext(ctx).
-		Where(r.query.Character.ID.Eq(character.ID.String())).
-		Updates(updateFields)
+		characterID := id.String()
+		if _, err := tx.CharacterTag.WithContext(ctx).Where(tx.CharacterTag.CharacterID.Eq(characterID)).Delete(); err != nil {
+			return err
+		}
+		if _, err := tx.CharacterVersion.WithContext(ctx).Where(tx.CharacterVersion.CharacterID.Eq(characterID)).Delete(); err != nil {
+			return err
+		}
+		if _, err := tx.CharacterFavorite.WithContext(ctx).Where(tx.CharacterFavorite.CharacterID.Eq(characterID)).Delete(); err != nil {
+			return err
+		}
+		_, err = tx.CharacterReview.WithContext(ctx).Where(tx.CharacterReview.CharacterID.Eq(characterID)).Delete()
+		return err
+	})
+}
+
+// UpdateHotwords 更新角色热词及热词表ID
+func (r *CharacterRepository) UpdateHotwords(ctx context.Context, id uuid.UUID, hotwords []ai.Hotword, vocabularyID *string) error {
+	data, err := marshalList(hotwords)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = r.query.Character.WithContext(ctx).
+		Where(r.query.Character.ID.Eq(id.String())).
+		Updates(map[string]any{
+			"hotwords":      data,
+			"vocabulary_id": vocabularyID,
+		})
+	return err
 }
 
-// GetCharactersByStatus 根据状态获取角色
-func (r *CharacterRepository) GetCharactersByStatus(ctx context.Context, status int32) ([]*character.Character, error) {
-	charModels, err := r.query.Character.WithContext(ctx).Where(r.query.Character.Status.Eq(status)).Find()
+// UpdateVoicePreview 更新音色试听音频URL
+func (r *CharacterRepository) UpdateVoicePreview(ctx context.Context, id uuid.UUID, url *string) error {
+	_, err := r.query.Character.WithContext(ctx).
+		Where(r.query.Character.ID.Eq(id.String())).
+		Update(r.query.Character.VoicePreviewURL, url)
+	return err
+}
+
+// GetByVoiceID 获取使用指定音色的角色
+func (r *CharacterRepository) GetByVoiceID(ctx context.Context, voiceID uuid.UUID) ([]*character.Character, error) {
+	charModels, err := r.query.Character.WithContext(ctx).Where(r.query.Character.VoiceID.Eq(voiceID.String())).Find()
 	if err != nil {
 		return nil, err
 	}
 
-	// 转换为domain.Character切片
+	return toCharacters(charModels)
+}
+
+// toCharacters 将数据库模型列表转换为domain.Character切片
+func toCharacters(charModels []*model.Character) ([]*character.Character, error) {
 	characters := make([]*character.Character, 0, len(charModels))
 	for _, charModel := range charModels {
-		id, err := uuid.Parse(charModel.ID)
+		character, err := toCharacter(charModel)
 		if err != nil {
 			return nil, err
 		}
+		characters = append(characters, character)
+	}
+	return characters, nil
+}
 
-		character := &character.Character{
-			ID:        id,
-			Name:      charModel.Name,
-			Prompt:    charModel.Prompt,
-			CreatedAt: charModel.CreatedAt,
-			UpdatedAt: charModel.UpdatedAt,
-			Flag:      charModel.Flag,
-			Status:    charModel.Status,
-		}
+// toCharacter 将数据库模型转换为domain.Character
+func toCharacter(charModel *model.Character) (*character.Character, error) {
+	id, err := uuid.Parse(charModel.ID)
+	if err != nil {
+		return nil, err
+	}
 
-		// 处理可空字段
-		if charModel.Avatar != nil {
-			character.Avatar = charModel.Avatar
-		}
-		if charModel.AudioExample != nil {
-			character.AudioExample = charModel.AudioExample
-		}
-		if charModel.Description != nil {
-			character.Description = charModel.Description
-		}
-		if charModel.Voice != nil {
-			character.Voice = charModel.Voice
-		}
+	hotwords, err := unmarshalList[ai.Hotword](charModel.Hotwords)
+	if err != nil {
+		return nil, err
+	}
+	alternateGreetings, err := unmarshalList[string](charModel.AlternateGreetings)
+	if err != nil {
+		return nil, err
+	}
+	exampleDialogues, err := unmarshalList[character.ExampleDialogue](charModel.ExampleDialogues)
+	if err != nil {
+		return nil, err
+	}
 
-		characters = append(characters, character)
+	voiceID, err := parseUUID(charModel.VoiceID)
+	if err != nil {
+		return nil, err
+	}
+	forkedFrom, err := parseUUID(charModel.ForkedFrom)
+	if err != nil {
+		return nil, err
 	}
 
-	return characters, nil
+	return &character.Character{
+		ID:                 id,
+		Name:               charModel.Name,
+		Prompt:             charModel.Prompt,
+		Greeting:           charModel.Greeting,
+		AlternateGreetings: alternateGreetings,
+		ExampleDialogues:   exampleDialogues,
+		Description:        charModel.Description,
+		OwnerID:            charModel.OwnerID,
+		Visibility:         charModel.Visibility,
+		ForkedFrom:         forkedFrom,
+		AllowFork:          charModel.AllowFork,
+		License:            charModel.License,
+		Speech: character.SpeechSettings{
+			TTSModel: charModel.TTSModel,
+			Rate:     charModel.SpeechRate,
+			Pitch:    charModel.SpeechPitch,
+			Volume:   int(charModel.SpeechVolume),
+		},
+		Version:         charModel.Version,
+		Status:          charModel.Status,
+		Avatar:          charModel.Avatar,
+		Voice:           charModel.Voice,
+		VoiceID:         voiceID,
+		Flag:            charModel.Flag,
+		AudioExample:    charModel.AudioExample,
+		StatusReason:    charModel.StatusReason,
+		VoiceStatus:     charModel.VoiceStatus,
+		ReviewStatus:    charModel.ReviewStatus,
+		ReviewReason:    charModel.ReviewReason,
+		SessionCount:    charModel.SessionCount,
+		FavoriteCount:   charModel.FavoriteCount,
+		VoicePreviewURL: charModel.VoicePreviewURL,
+		Hotwords:        hotwords,
+		VocabularyID:    charModel.VocabularyID,
+		CreatedAt:       charModel.CreatedAt,
+		UpdatedAt:       charModel.UpdatedAt,
+	}, nil
+}
+
+// translateError 将角色名唯一索引冲突转换为 character.ErrNameTaken
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return character.ErrNameTaken
+	}
+	return err
+}
+
+// searchText 计算角色的全文检索词
+func searchText(c *character.Character) *string {
+	return lo.ToPtr(character.SearchText(c))
+}
+
+// uuidString 将可空的UUID转换为数据库字段值
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// parseUUID 解析可为空的 uuid 列
+func parseUUID(s *string) (*uuid.UUID, error) {
+	if s == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// marshalList 将列表序列化为 jsonb，空列表存为 NULL
+func marshalList[T any](items []T) (*string, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+// unmarshalList 将 jsonb 反序列化为列表，NULL 返回 nil
+func unmarshalList[T any](data *string) ([]T, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var items []T
+	if err := json.Unmarshal([]byte(*data), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// dialogueColumns 开场白列表和对话示例序列化后的 jsonb 字段
+type dialogueColumns struct {
+	alternateGreetings *string
+	exampleDialogues   *string
+}
+
+// marshalDialogue 序列化备选开场白和对话示例
+func marshalDialogue(alternateGreetings []string, exampleDialogues []character.ExampleDialogue) (dialogueColumns, error) {
+	var columns dialogueColumns
+	var err error
+	if columns.alternateGreetings, err = marshalList(alternateGreetings); err != nil {
+		return columns, err
+	}
+	columns.exampleDialogues, err = marshalList(exampleDialogues)
+	return columns, err
 }
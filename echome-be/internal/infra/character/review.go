@@ -0,0 +1,90 @@
+package character
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/character"
+)
+
+// SaveReview 在事务中保存审核记录并更新角色的审核状态和角色状态
+func (r *CharacterRepository) SaveReview(ctx context.Context, review *character.Review, char *character.Character) error {
+	reviewModel := &model.CharacterReview{
+		CharacterID:      review.CharacterID.String(),
+		CharacterVersion: review.CharacterVersion,
+		Decision:         review.Decision,
+		Reason:           review.Reason,
+		ReviewerID:       review.ReviewerID,
+	}
+	err := r.query.Transaction(func(tx *query.Query) error {
+		if err := tx.CharacterReview.WithContext(ctx).Create(reviewModel); err != nil {
+			return err
+		}
+		result, err := tx.Character.WithContext(ctx).
+			Where(tx.Character.ID.Eq(char.ID.String())).
+			Updates(map[string]any{
+				"review_status": char.ReviewStatus,
+				"review_reason": char.ReviewReason,
+				"status":        char.Status,
+				"status_reason": char.StatusReason,
+				"updated_at":    char.UpdatedAt,
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return character.ErrCharacterNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	review.ID, err = uuid.Parse(reviewModel.ID)
+	review.CreatedAt = reviewModel.CreatedAt
+	return err
+}
+
+// ListReviews 获取角色的审核记录，按时间倒序
+func (r *CharacterRepository) ListReviews(ctx context.Context, characterID uuid.UUID) ([]*character.Review, error) {
+	rv := r.query.CharacterReview
+	reviewModels, err := rv.WithContext(ctx).Where(rv.CharacterID.Eq(characterID.String())).Order(rv.CreatedAt.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*character.Review, 0, len(reviewModels))
+	for _, reviewModel := range reviewModels {
+		review, err := toReview(reviewModel)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// toReview 将数据库模型转换为 character.Review
+func toReview(reviewModel *model.CharacterReview) (*character.Review, error) {
+	id, err := uuid.Parse(reviewModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	characterID, err := uuid.Parse(reviewModel.CharacterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &character.Review{
+		ID:               id,
+		CharacterID:      characterID,
+		CharacterVersion: reviewModel.CharacterVersion,
+		Decision:         reviewModel.Decision,
+		Reason:           reviewModel.Reason,
+		ReviewerID:       reviewModel.ReviewerID,
+		CreatedAt:        reviewModel.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package character
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/character"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+// 对话统计的汇总列，未结束的对话不计入时长
+var (
+	turnsSum     = field.NewUnsafeFieldRaw("COALESCE(SUM(conversations.turns), 0)").As("turns")
+	voiceMinutes = field.NewUnsafeFieldRaw("COALESCE(SUM(EXTRACT(EPOCH FROM conversations.ended_at - conversations.started_at)), 0) / 60").As("voice_minutes")
+	uniqueUsers  = field.NewUnsafeFieldRaw("COUNT(DISTINCT conversations.user_id)").As("unique_users")
+	day          = field.NewUnsafeFieldRaw("date_trunc('day', conversations.started_at AT TIME ZONE 'UTC')")
+)
+
+// statsRow 对话统计的查询结果，汇总全部对话时 Day 为空
+type statsRow struct {
+	Day          time.Time
+	Sessions     int64
+	Turns        int64
+	VoiceMinutes float64
+	UniqueUsers  int64
+}
+
+// Stats 汇总角色所有语音对话的统计，及 since 之后每天的统计
+func (r *CharacterRepository) Stats(ctx context.Context, characterID uuid.UUID, since time.Time) (*character.Stats, error) {
+	c := r.query.Conversation
+	var total statsRow
+	err := c.WithContext(ctx).
+		Select(c.ID.Count().As("sessions"), turnsSum, voiceMinutes, uniqueUsers).
+		Where(c.CharacterID.Eq(characterID.String())).
+		Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []statsRow
+	err = c.WithContext(ctx).
+		Select(day.As("day"), c.ID.Count().As("sessions"), turnsSum, voiceMinutes, uniqueUsers).
+		Where(c.CharacterID.Eq(characterID.String()), c.StartedAt.Gte(since)).
+		Group(day).
+		Order(day).
+		Scan(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &character.Stats{
+		Sessions:     total.Sessions,
+		Turns:        total.Turns,
+		VoiceMinutes: total.VoiceMinutes,
+		UniqueUsers:  total.UniqueUsers,
+		Daily:        make([]character.DailyStats, 0, len(rows)),
+	}
+	for _, row := range rows {
+		stats.Daily = append(stats.Daily, character.DailyStats{
+			Day:          row.Day,
+			Sessions:     row.Sessions,
+			Turns:        row.Turns,
+			VoiceMinutes: row.VoiceMinutes,
+			UniqueUsers:  row.UniqueUsers,
+		})
+	}
+	return stats, nil
+}
+
+// AddFavorite 收藏角色并累加收藏数，已收藏时不做修改
+func (r *CharacterRepository) AddFavorite(ctx context.Context, userID string, characterID uuid.UUID) error {
+	return r.query.Transaction(func(tx *query.Query) error {
+		// gen 的 Create 不返回影响行数，用底层的 gorm.DB 判断是否真的插入了收藏
+		result := tx.CharacterFavorite.WithContext(ctx).UnderlyingDB().Clauses(clause.OnConflict{DoNothing: true}).Create(&model.CharacterFavorite{
+			UserID:      userID,
+			CharacterID: characterID.String(),
+		})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return addFavoriteCount(ctx, tx, characterID, 1)
+	})
+}
+
+// RemoveFavorite 取消收藏并减少收藏数，未收藏时不做修改
+func (r *CharacterRepository) RemoveFavorite(ctx context.Context, userID string, characterID uuid.UUID) error {
+	return r.query.Transaction(func(tx *query.Query) error {
+		f := tx.CharacterFavorite
+		result, err := f.WithContext(ctx).Where(f.UserID.Eq(userID), f.CharacterID.Eq(characterID.String())).Delete()
+		if err != nil || result.RowsAffected == 0 {
+			return err
+		}
+		return addFavoriteCount(ctx, tx, characterID, -1)
+	})
+}
+
+// addFavoriteCount 在数据库中原子地增减收藏数
+// 只有真正插入或删除了收藏时才调用，不在 READ COMMITTED 下用子查询重新统计，避免并发收藏互相看不到对方的插入
+func addFavoriteCount(ctx context.Context, tx *query.Query, characterID uuid.UUID, delta int64) error {
+	c := tx.Character
+	_, err := c.WithContext(ctx).Unscoped().
+		Where(c.ID.Eq(characterID.String())).
+		UpdateColumn(c.FavoriteCount, c.FavoriteCount.Add(delta))
+	return err
+}
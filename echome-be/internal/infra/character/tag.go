@@ -0,0 +1,199 @@
+package character
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/samber/lo"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagFacets 统计符合查询条件的角色中最常见的标签，按角色数倒序
+func (r *CharacterRepository) TagFacets(ctx context.Context, q character.ListQuery, limit int) ([]character.TagCount, error) {
+	c, ct, t := r.query.Character, r.query.CharacterTag, r.query.Tag
+	facets := []character.TagCount{}
+	err := ct.WithContext(ctx).
+		Select(t.Name.As("tag"), ct.CharacterID.Count().As("count")).
+		Join(t, t.ID.EqCol(ct.TagID)).
+		Where(ct.Columns(ct.CharacterID).In(r.filter(ctx, q).Select(c.ID))).
+		Group(t.Name).
+		Order(field.NewInt64("", "count").Desc(), t.Name).
+		Limit(limit).
+		Scan(&facets)
+	return facets, err
+}
+
+// ListTags 获取标签，精选分类按展示顺序排在前面，其余按名称排序
+func (r *CharacterRepository) ListTags(ctx context.Context, categoriesOnly bool) ([]*character.Tag, error) {
+	t := r.query.Tag
+	do := t.WithContext(ctx)
+	if categoriesOnly {
+		do = do.Where(t.Category.Is(true))
+	}
+	tagModels, err := do.Order(t.Category.Desc(), t.SortOrder, t.Name).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*character.Tag, 0, len(tagModels))
+	for _, tagModel := range tagModels {
+		tag, err := toTag(tagModel)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// GetTag 获取标签
+func (r *CharacterRepository) GetTag(ctx context.Context, id uuid.UUID) (*character.Tag, error) {
+	tagModel, err := r.query.Tag.WithContext(ctx).Where(r.query.Tag.ID.Eq(id.String())).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, character.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return toTag(tagModel)
+}
+
+// SaveTag 保存新标签
+func (r *CharacterRepository) SaveTag(ctx context.Context, tag *character.Tag) error {
+	tagModel := &model.Tag{
+		Name:      tag.Name,
+		Category:  tag.Category,
+		SortOrder: tag.SortOrder,
+	}
+	if err := r.query.Tag.WithContext(ctx).Create(tagModel); err != nil {
+		return translateTagError(err)
+	}
+
+	saved, err := toTag(tagModel)
+	if err != nil {
+		return err
+	}
+	*tag = *saved
+	return nil
+}
+
+// UpdateTag 更新标签
+func (r *CharacterRepository) UpdateTag(ctx context.Context, tag *character.Tag) error {
+	t := r.query.Tag
+	result, err := t.WithContext(ctx).Where(t.ID.Eq(tag.ID.String())).Updates(map[string]any{
+		"name":       tag.Name,
+		"category":   tag.Category,
+		"sort_order": tag.SortOrder,
+	})
+	if err != nil {
+		return translateTagError(err)
+	}
+	if result.RowsAffected == 0 {
+		return character.ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag 在事务中删除标签及其与角色的关联
+func (r *CharacterRepository) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	return r.query.Transaction(func(tx *query.Query) error {
+		if _, err := tx.CharacterTag.WithContext(ctx).Where(tx.CharacterTag.TagID.Eq(id.String())).Delete(); err != nil {
+			return err
+		}
+		result, err := tx.Tag.WithContext(ctx).Where(tx.Tag.ID.Eq(id.String())).Delete()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return character.ErrTagNotFound
+		}
+		return nil
+	})
+}
+
+// SetCharacterTags 在事务中创建缺少的标签，并用新的标签替换角色原有的标签
+func (r *CharacterRepository) SetCharacterTags(ctx context.Context, characterID uuid.UUID, tags []string) error {
+	return r.query.Transaction(func(tx *query.Query) error {
+		ct, t := tx.CharacterTag, tx.Tag
+		if _, err := ct.WithContext(ctx).Where(ct.CharacterID.Eq(characterID.String())).Delete(); err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+
+		tagModels := lo.Map(tags, func(name string, _ int) *model.Tag { return &model.Tag{Name: name} })
+		if err := t.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(tagModels...); err != nil {
+			return err
+		}
+		saved, err := t.WithContext(ctx).Where(t.Name.In(tags...)).Find()
+		if err != nil {
+			return err
+		}
+		links := lo.Map(saved, func(tag *model.Tag, _ int) *model.CharacterTag {
+			return &model.CharacterTag{CharacterID: characterID.String(), TagID: tag.ID}
+		})
+		return ct.WithContext(ctx).Create(links...)
+	})
+}
+
+// attachTags 查询并填充角色的标签，标签按名称排序
+func (r *CharacterRepository) attachTags(ctx context.Context, characters ...*character.Character) error {
+	if len(characters) == 0 {
+		return nil
+	}
+	ct, t := r.query.CharacterTag, r.query.Tag
+	ids := lo.Map(characters, func(c *character.Character, _ int) string { return c.ID.String() })
+
+	var rows []struct {
+		CharacterID string
+		Name        string
+	}
+	err := ct.WithContext(ctx).
+		Select(ct.CharacterID, t.Name).
+		Join(t, t.ID.EqCol(ct.TagID)).
+		Where(ct.CharacterID.In(ids...)).
+		Order(t.Name).
+		Scan(&rows)
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string][]string, len(characters))
+	for _, row := range rows {
+		tags[row.CharacterID] = append(tags[row.CharacterID], row.Name)
+	}
+	for _, c := range characters {
+		c.Tags = lo.CoalesceSliceOrEmpty(tags[c.ID.String()], []string{})
+	}
+	return nil
+}
+
+// toTag 将数据库模型转换为 character.Tag
+func toTag(tagModel *model.Tag) (*character.Tag, error) {
+	id, err := uuid.Parse(tagModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &character.Tag{
+		ID:        id,
+		Name:      tagModel.Name,
+		Category:  tagModel.Category,
+		SortOrder: tagModel.SortOrder,
+		CreatedAt: tagModel.CreatedAt,
+	}, nil
+}
+
+// translateTagError 将标签名唯一索引冲突转换为 character.ErrTagExists
+func translateTagError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return character.ErrTagExists
+	}
+	return err
+}
@@ -0,0 +1,185 @@
+package character
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/ai"
+	"github.com/justin/echome-be/internal/domain/character"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// SaveVersion 在事务中分配下一个版本号，保存版本并更新角色的当前版本号
+// 并发保存同一角色的版本时，版本号唯一索引冲突的一方失败
+func (r *CharacterRepository) SaveVersion(ctx context.Context, version *character.Version) error {
+	hotwords, err := marshalList(version.Hotwords)
+	if err != nil {
+		return err
+	}
+	dialogue, err := marshalDialogue(version.AlternateGreetings, version.ExampleDialogues)
+	if err != nil {
+		return err
+	}
+	// 标签总是保存为列表，NULL 只表示记录标签之前的版本
+	tags, err := json.Marshal(lo.Ternary(version.Tags != nil, version.Tags, []string{}))
+	if err != nil {
+		return err
+	}
+
+	versionModel := &model.CharacterVersion{
+		CharacterID:        version.CharacterID.String(),
+		Change:             version.Change,
+		RolledBackFrom:     version.RolledBackFrom,
+		AuthorID:           version.AuthorID,
+		Name:               version.Name,
+		Prompt:             version.Prompt,
+		Greeting:           version.Greeting,
+		AlternateGreetings: dialogue.alternateGreetings,
+		ExampleDialogues:   dialogue.exampleDialogues,
+		Description:        version.Description,
+		Avatar:             version.Avatar,
+		AudioExample:       version.AudioExample,
+		VoiceID:            uuidString(version.VoiceID),
+		Visibility:         version.Visibility,
+		Hotwords:           hotwords,
+		Tags:               lo.ToPtr(string(tags)),
+		AllowFork:          version.AllowFork,
+		License:            version.License,
+		TTSModel:           version.Speech.TTSModel,
+		SpeechRate:         version.Speech.Rate,
+		SpeechPitch:        version.Speech.Pitch,
+		SpeechVolume:       int32(version.Speech.Volume),
+	}
+	err = r.query.Transaction(func(tx *query.Query) error {
+		v := tx.CharacterVersion
+		latest, err := v.WithContext(ctx).Where(v.CharacterID.Eq(versionModel.CharacterID)).Order(v.Version.Desc()).First()
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			versionModel.Version = 1
+		case err != nil:
+			return err
+		default:
+			versionModel.Version = latest.Version + 1
+		}
+
+		if err := v.WithContext(ctx).Create(versionModel); err != nil {
+			return err
+		}
+		_, err = tx.Character.WithContext(ctx).
+			Where(tx.Character.ID.Eq(versionModel.CharacterID)).
+			Update(tx.Character.Version, versionModel.Version)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	version.ID, err = uuid.Parse(versionModel.ID)
+	version.Version = versionModel.Version
+	version.CreatedAt = versionModel.CreatedAt
+	return err
+}
+
+// ListVersions 获取角色的所有版本，按版本号倒序
+func (r *CharacterRepository) ListVersions(ctx context.Context, characterID uuid.UUID) ([]*character.Version, error) {
+	v := r.query.CharacterVersion
+	versionModels, err := v.WithContext(ctx).Where(v.CharacterID.Eq(characterID.String())).Order(v.Version.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*character.Version, 0, len(versionModels))
+	for _, versionModel := range versionModels {
+		version, err := toVersion(versionModel)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// GetVersion 获取角色的指定版本
+func (r *CharacterRepository) GetVersion(ctx context.Context, characterID uuid.UUID, version int32) (*character.Version, error) {
+	v := r.query.CharacterVersion
+	versionModel, err := v.WithContext(ctx).Where(v.CharacterID.Eq(characterID.String()), v.Version.Eq(version)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, character.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	return toVersion(versionModel)
+}
+
+// toVersion 将数据库模型转换为 character.Version
+func toVersion(versionModel *model.CharacterVersion) (*character.Version, error) {
+	id, err := uuid.Parse(versionModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	characterID, err := uuid.Parse(versionModel.CharacterID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotwords, err := unmarshalList[ai.Hotword](versionModel.Hotwords)
+	if err != nil {
+		return nil, err
+	}
+	alternateGreetings, err := unmarshalList[string](versionModel.AlternateGreetings)
+	if err != nil {
+		return nil, err
+	}
+	exampleDialogues, err := unmarshalList[character.ExampleDialogue](versionModel.ExampleDialogues)
+	if err != nil {
+		return nil, err
+	}
+
+	voiceID, err := parseUUID(versionModel.VoiceID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := unmarshalList[string](versionModel.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &character.Version{
+		ID:             id,
+		CharacterID:    characterID,
+		Version:        versionModel.Version,
+		Change:         versionModel.Change,
+		RolledBackFrom: versionModel.RolledBackFrom,
+		AuthorID:       versionModel.AuthorID,
+		Snapshot: character.Snapshot{
+			Name:               versionModel.Name,
+			Prompt:             versionModel.Prompt,
+			Greeting:           versionModel.Greeting,
+			AlternateGreetings: alternateGreetings,
+			ExampleDialogues:   exampleDialogues,
+			Description:        versionModel.Description,
+			Avatar:             versionModel.Avatar,
+			AudioExample:       versionModel.AudioExample,
+			VoiceID:            voiceID,
+			Visibility:         versionModel.Visibility,
+			Hotwords:           hotwords,
+			Tags:               tags,
+			AllowFork:          versionModel.AllowFork,
+			License:            versionModel.License,
+			Speech: character.SpeechSettings{
+				TTSModel: versionModel.TTSModel,
+				Rate:     versionModel.SpeechRate,
+				Pitch:    versionModel.SpeechPitch,
+				Volume:   int(versionModel.SpeechVolume),
+			},
+		},
+		CreatedAt: versionModel.CreatedAt,
+	}, nil
+}
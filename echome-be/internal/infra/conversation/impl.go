@@ -0,0 +1,64 @@
+package conversation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/conversation"
+)
+
+// ConversationRepository 对话记录仓库
+type ConversationRepository struct {
+	query *query.Query
+}
+
+var _ conversation.Repo = (*ConversationRepository)(nil)
+
+// NewConversationRepository 创建对话记录仓库
+func NewConversationRepository(query *query.Query) *ConversationRepository {
+	return &ConversationRepository{
+		query: query,
+	}
+}
+
+// Start 在事务中保存新开始的对话并回填ID，有角色时累加角色的对话次数
+func (r *ConversationRepository) Start(ctx context.Context, record *conversation.Record) error {
+	conversationModel := &model.Conversation{
+		CharacterVersion: record.CharacterVersion,
+		UserID:           record.UserID,
+		StartedAt:        record.StartedAt,
+	}
+	if record.CharacterID != nil {
+		characterID := record.CharacterID.String()
+		conversationModel.CharacterID = &characterID
+	}
+	err := r.query.Transaction(func(tx *query.Query) error {
+		if err := tx.Conversation.WithContext(ctx).Create(conversationModel); err != nil {
+			return err
+		}
+		if conversationModel.CharacterID == nil {
+			return nil
+		}
+		c := tx.Character
+		_, err := c.WithContext(ctx).Where(c.ID.Eq(*conversationModel.CharacterID)).UpdateColumn(c.SessionCount, c.SessionCount.Add(1))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	record.ID, err = uuid.Parse(conversationModel.ID)
+	return err
+}
+
+// Finish 记录对话的结束时间和对话轮数
+func (r *ConversationRepository) Finish(ctx context.Context, record *conversation.Record) error {
+	c := r.query.Conversation
+	_, err := c.WithContext(ctx).Where(c.ID.Eq(record.ID.String())).Updates(map[string]any{
+		"ended_at": record.EndedAt,
+		"turns":    record.Turns,
+	})
+	return err
+}
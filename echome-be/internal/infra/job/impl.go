@@ -0,0 +1,134 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/job"
+	"gorm.io/gorm"
+)
+
+// JobRepository 实现 job.Repo 接口
+type JobRepository struct {
+	query *query.Query
+}
+
+var _ job.Repo = (*JobRepository)(nil)
+
+// NewJobRepository 创建新的JobRepository实例
+func NewJobRepository(query *query.Query) *JobRepository {
+	return &JobRepository{
+		query: query,
+	}
+}
+
+// Create 创建任务并回填ID
+func (r *JobRepository) Create(ctx context.Context, j *job.Job) error {
+	jobModel := toModel(j)
+	if err := r.query.Job.WithContext(ctx).Create(jobModel); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(jobModel.ID)
+	if err != nil {
+		return err
+	}
+	j.ID = id
+	return nil
+}
+
+// Update 保存任务的全部字段
+func (r *JobRepository) Update(ctx context.Context, j *job.Job) error {
+	return r.query.Job.WithContext(ctx).Save(toModel(j))
+}
+
+// FindPending 查找对象上未结束的任务
+func (r *JobRepository) FindPending(ctx context.Context, jobType, refID string) (*job.Job, error) {
+	q := r.query.Job
+	jobModel, err := q.WithContext(ctx).
+		Where(q.Type.Eq(jobType), q.RefID.Eq(refID), q.Status.Eq(string(job.StatusPending))).
+		Order(q.CreatedAt.Desc()).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, job.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return toJob(jobModel)
+}
+
+// Due 返回到期的待执行任务
+func (r *JobRepository) Due(ctx context.Context, now time.Time, limit int) ([]*job.Job, error) {
+	q := r.query.Job
+	jobModels, err := q.WithContext(ctx).
+		Where(q.Status.Eq(string(job.StatusPending)), q.NextRunAt.Lte(now)).
+		Order(q.NextRunAt).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*job.Job, 0, len(jobModels))
+	for _, jobModel := range jobModels {
+		j, err := toJob(jobModel)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// Claim 以下次执行时间作为乐观锁领取任务
+func (r *JobRepository) Claim(ctx context.Context, id uuid.UUID, nextRunAt, until time.Time) (bool, error) {
+	q := r.query.Job
+	result, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id.String()), q.Status.Eq(string(job.StatusPending)), q.NextRunAt.Eq(nextRunAt)).
+		Update(q.NextRunAt, until)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// toModel 将 job.Job 转换为数据库模型
+func toModel(j *job.Job) *model.Job {
+	jobModel := &model.Job{
+		Type:      j.Type,
+		RefID:     j.RefID,
+		Status:    string(j.Status),
+		Attempts:  int32(j.Attempts),
+		NextRunAt: j.NextRunAt,
+		LastError: j.LastError,
+		CreatedAt: j.CreatedAt,
+	}
+	if j.ID != uuid.Nil {
+		jobModel.ID = j.ID.String()
+	}
+	return jobModel
+}
+
+// toJob 将数据库模型转换为 job.Job
+func toJob(jobModel *model.Job) (*job.Job, error) {
+	id, err := uuid.Parse(jobModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &job.Job{
+		ID:        id,
+		Type:      jobModel.Type,
+		RefID:     jobModel.RefID,
+		Status:    job.Status(jobModel.Status),
+		Attempts:  int(jobModel.Attempts),
+		NextRunAt: jobModel.NextRunAt,
+		LastError: jobModel.LastError,
+		CreatedAt: jobModel.CreatedAt,
+		UpdatedAt: jobModel.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,182 @@
+// Package lipsync 根据文本和拼音推算口型（viseme）序列，供前端动画和虚拟形象对口型。
+package lipsync
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// Viseme 口型类别
+type Viseme string
+
+// 口型集合按嘴部形状划分，时间上的空隙表示闭口静止
+const (
+	Rest Viseme = "rest" // 静止
+	MBP  Viseme = "MBP"  // 双唇闭合：b p m
+	FV   Viseme = "FV"   // 上齿触下唇：f v
+	A    Viseme = "A"    // 大开口：a
+	E    Viseme = "E"    // 半开口：e
+	I    Viseme = "I"    // 扁唇：i
+	O    Viseme = "O"    // 圆唇：o
+	U    Viseme = "U"    // 撮唇：u ü
+)
+
+// consonantRatio 音节中辅音口型占用的时长比例
+const consonantRatio = 0.3
+
+// Frame 一段时间内的口型，时间单位为毫秒
+type Frame struct {
+	Viseme    Viseme `json:"viseme"`
+	BeginTime int    `json:"begin_time"`
+	EndTime   int    `json:"end_time"`
+}
+
+// syllable 一个音节的口型：可选的辅音口型加元音口型
+type syllable struct {
+	consonant Viseme
+	vowel     Viseme
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
+// FromText 把文本的口型均匀分布到 [begin, end) 时间区间内
+// 汉字按拼音取声母和主元音，拉丁字母按元音组估算，标点和数字不产生口型
+func FromText(text string, begin, end int) []Frame {
+	syllables := textSyllables(text)
+	if len(syllables) == 0 || end <= begin {
+		return nil
+	}
+
+	frames := make([]Frame, 0, len(syllables)*2)
+	span := float64(end-begin) / float64(len(syllables))
+	for i, s := range syllables {
+		start := begin + int(float64(i)*span)
+		stop := begin + int(float64(i+1)*span)
+		if s.consonant != "" {
+			mid := start + int(float64(stop-start)*consonantRatio)
+			frames = appendFrame(frames, s.consonant, start, mid)
+			start = mid
+		}
+		frames = appendFrame(frames, s.vowel, start, stop)
+	}
+	return frames
+}
+
+// appendFrame 追加口型，与前一帧相同时合并
+func appendFrame(frames []Frame, v Viseme, begin, end int) []Frame {
+	if end <= begin {
+		return frames
+	}
+	if n := len(frames); n > 0 && frames[n-1].Viseme == v && frames[n-1].EndTime == begin {
+		frames[n-1].EndTime = end
+		return frames
+	}
+	return append(frames, Frame{Viseme: v, BeginTime: begin, EndTime: end})
+}
+
+// textSyllables 把文本拆成音节序列
+func textSyllables(text string) []syllable {
+	var syllables []syllable
+	var latin strings.Builder
+	flushLatin := func() {
+		if latin.Len() > 0 {
+			syllables = append(syllables, latinSyllables(latin.String())...)
+			latin.Reset()
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushLatin()
+			if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 {
+				syllables = append(syllables, pinyinSyllable(py[0]))
+			}
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			latin.WriteRune(unicode.ToLower(r))
+		default:
+			flushLatin()
+		}
+	}
+	flushLatin()
+	return syllables
+}
+
+// pinyinSyllable 根据无声调拼音推算口型
+func pinyinSyllable(py string) syllable {
+	var s syllable
+	switch {
+	case strings.HasPrefix(py, "b"), strings.HasPrefix(py, "p"), strings.HasPrefix(py, "m"):
+		s.consonant = MBP
+	case strings.HasPrefix(py, "f"):
+		s.consonant = FV
+	}
+	s.vowel = mainVowel(py)
+	return s
+}
+
+// mainVowel 取韵母中开口度最大的元音
+func mainVowel(py string) Viseme {
+	switch {
+	case strings.Contains(py, "a"):
+		return A
+	case strings.Contains(py, "o"):
+		return O
+	case strings.Contains(py, "e"):
+		return E
+	case strings.Contains(py, "i"):
+		return I
+	case strings.ContainsAny(py, "uvü"):
+		return U
+	}
+	// 如 m、n、ng 等鼻音叹词
+	return MBP
+}
+
+// latinSyllables 按元音组拆分拉丁字母单词，元音组前的唇音作为辅音口型
+func latinSyllables(word string) []syllable {
+	var syllables []syllable
+	var consonant Viseme
+	inVowel := false
+	for _, r := range word {
+		v, isVowel := latinVowel(r)
+		if isVowel {
+			if !inVowel {
+				syllables = append(syllables, syllable{consonant: consonant, vowel: v})
+				consonant = ""
+			}
+			inVowel = true
+			continue
+		}
+		inVowel = false
+		switch r {
+		case 'b', 'p', 'm':
+			consonant = MBP
+		case 'f', 'v':
+			consonant = FV
+		}
+	}
+	// 没有元音的单词（如缩写）按一个音节处理
+	if len(syllables) == 0 && word != "" {
+		syllables = append(syllables, syllable{consonant: consonant, vowel: E})
+	}
+	return syllables
+}
+
+func latinVowel(r rune) (Viseme, bool) {
+	switch r {
+	case 'a':
+		return A, true
+	case 'e':
+		return E, true
+	case 'i', 'y':
+		return I, true
+	case 'o':
+		return O, true
+	case 'u':
+		return U, true
+	}
+	return "", false
+}
@@ -4,9 +4,19 @@ import (
 	"github.com/google/wire"
 	"github.com/justin/echome-be/internal/domain/ai"
 	dc "github.com/justin/echome-be/internal/domain/character"
+	dcv "github.com/justin/echome-be/internal/domain/conversation"
+	dj "github.com/justin/echome-be/internal/domain/job"
+	ds "github.com/justin/echome-be/internal/domain/storage"
+	dt "github.com/justin/echome-be/internal/domain/transcription"
+	dv "github.com/justin/echome-be/internal/domain/voice"
 	"github.com/justin/echome-be/internal/infra/aliyun"
 	"github.com/justin/echome-be/internal/infra/character"
+	"github.com/justin/echome-be/internal/infra/conversation"
 	"github.com/justin/echome-be/internal/infra/db"
+	"github.com/justin/echome-be/internal/infra/job"
+	"github.com/justin/echome-be/internal/infra/storage"
+	"github.com/justin/echome-be/internal/infra/transcription"
+	"github.com/justin/echome-be/internal/infra/voice"
 )
 
 // RepositoryProviderSet 包含所有仓库提供者
@@ -15,6 +25,18 @@ var RepositoryProviderSet = wire.NewSet(
 	db.NewQuery,
 	character.NewCharacterRepository,
 	wire.Bind(new(dc.Repo), new(*character.CharacterRepository)),
+	conversation.NewConversationRepository,
+	wire.Bind(new(dcv.Repo), new(*conversation.ConversationRepository)),
 	aliyun.ProvideAliClient,
 	wire.Bind(new(ai.Repo), new(*aliyun.AliClient)),
+	storage.NewLocalStorage,
+	wire.Bind(new(ds.Repo), new(*storage.LocalStorage)),
+	transcription.NewMemoryJobRepository,
+	wire.Bind(new(dt.Repo), new(*transcription.MemoryJobRepository)),
+	voice.NewVoiceRepository,
+	wire.Bind(new(dv.Repo), new(*voice.VoiceRepository)),
+	voice.NewSampleAnalyzer,
+	wire.Bind(new(dv.SampleAnalyzer), new(*voice.SampleAnalyzer)),
+	job.NewJobRepository,
+	wire.Bind(new(dj.Repo), new(*job.JobRepository)),
 )
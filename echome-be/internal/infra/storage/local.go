@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain/storage"
+)
+
+// URLPrefix 本地文件对外提供访问的路由前缀
+const URLPrefix = "/files"
+
+// DefaultDir 未配置时使用的存储目录
+const DefaultDir = "./data/files"
+
+var _ storage.Repo = (*LocalStorage)(nil)
+
+// LocalStorage 把文件保存在本地目录，并通过 URLPrefix 静态路由对外提供
+type LocalStorage struct {
+	dir           string
+	publicBaseURL string
+}
+
+// NewLocalStorage 创建本地文件存储
+func NewLocalStorage(cfg *config.StorageConfig) *LocalStorage {
+	dir := cfg.Dir
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return &LocalStorage{
+		dir:           dir,
+		publicBaseURL: strings.TrimRight(cfg.PublicBaseURL, "/"),
+	}
+}
+
+// Dir 返回存储目录
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+// Save 保存文件，先写临时文件再重命名，避免读到不完整的文件
+func (s *LocalStorage) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return "", fmt.Errorf("创建文件失败: %w", err)
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("保存文件失败: %w", err)
+	}
+
+	return s.publicBaseURL + URLPrefix + "/" + key, nil
+}
+
+// Delete 删除文件，文件不存在时不报错
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Open 打开 Save 返回的URL对应的文件
+func (s *LocalStorage) Open(ctx context.Context, url string) (io.ReadCloser, error) {
+	rest, ok := strings.CutPrefix(url, s.publicBaseURL+URLPrefix+"/")
+	if !ok {
+		// 配置 public_base_url 之前保存的文件URL是相对路径
+		if rest, ok = strings.CutPrefix(url, URLPrefix+"/"); !ok {
+			return nil, storage.ErrNotFound
+		}
+	}
+	path, err := s.path(rest)
+	if err != nil {
+		return nil, storage.ErrNotFound
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, storage.ErrNotFound
+	}
+	return f, err
+}
+
+// Public 配置了对外地址时，文件URL可以被外部服务访问
+func (s *LocalStorage) Public() bool {
+	return s.publicBaseURL != ""
+}
+
+// path 返回 key 对应的本地路径，拒绝越出存储目录的 key
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("非法的文件key: %q", key)
+	}
+	return filepath.Join(s.dir, clean), nil
+}
@@ -0,0 +1,56 @@
+package transcription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/internal/domain/transcription"
+)
+
+// jobRetention 已结束任务的保留时长
+const jobRetention = 24 * time.Hour
+
+var _ transcription.Repo = (*MemoryJobRepository)(nil)
+
+// MemoryJobRepository 基于内存的转写任务仓库，服务重启后任务丢失
+type MemoryJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]transcription.Job
+}
+
+// NewMemoryJobRepository 创建内存转写任务仓库
+func NewMemoryJobRepository() *MemoryJobRepository {
+	return &MemoryJobRepository{
+		jobs: make(map[uuid.UUID]transcription.Job),
+	}
+}
+
+// Save 保存任务快照，并清理过期的已结束任务
+func (r *MemoryJobRepository) Save(ctx context.Context, job *transcription.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = *job
+
+	expiry := time.Now().Add(-jobRetention)
+	for id, j := range r.jobs {
+		if j.FinishedAt != nil && j.FinishedAt.Before(expiry) {
+			delete(r.jobs, id)
+		}
+	}
+	return nil
+}
+
+// GetByID 根据ID获取任务
+func (r *MemoryJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*transcription.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, transcription.ErrJobNotFound
+	}
+	return &job, nil
+}
@@ -0,0 +1,210 @@
+// Package ttscache 提供按内容寻址的 TTS 短句音频缓存，内存 LRU 为一级缓存，磁盘为可选的二级缓存。
+package ttscache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// metrics 缓存命中统计，不发布到全局 expvar，由 Metrics 返回给管理员接口
+var metrics = new(expvar.Map).Init()
+
+// Metrics 返回缓存命中统计，序列化为 JSON 对象
+func Metrics() expvar.Var {
+	return metrics
+}
+
+// Key 缓存键，同一组合的合成结果可以复用
+type Key struct {
+	Model      string
+	Voice      string
+	Format     string
+	SampleRate int
+	// Rate 语速，0 表示默认语速
+	Rate float64
+	// Pitch 语调，0 表示默认语调
+	Pitch float64
+	// Volume 音量，0 表示默认音量
+	Volume int
+	Text   string
+}
+
+// keyVersion 缓存键的版本，文本归一化规则变化时递增，使旧的缓存条目失效
+const keyVersion = "2"
+
+// Hash 返回键的内容哈希，文本会先归一化
+func (k Key) Hash() string {
+	h := sha256.New()
+	parts := []string{keyVersion, k.Model, k.Voice, k.Format, strconv.Itoa(k.SampleRate), NormalizeText(k.Text)}
+	// 默认语速、语调和音量不参与哈希，保持已有缓存可用
+	if k.Rate != 0 && k.Rate != 1 {
+		parts = append(parts, strconv.FormatFloat(k.Rate, 'f', -1, 64))
+	}
+	if k.Pitch != 0 && k.Pitch != 1 {
+		parts = append(parts, "pitch="+strconv.FormatFloat(k.Pitch, 'f', -1, 64))
+	}
+	if k.Volume != 0 && k.Volume != 50 {
+		parts = append(parts, "volume="+strconv.Itoa(k.Volume))
+	}
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NormalizeText 归一化文本：去掉首尾空白并合并连续空白，不改变大小写，大小写不同的文本读音可能不同（如 US 和 us）
+func NormalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Cache TTS 短句缓存
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	items    map[string]*list.Element
+	lru      *list.List
+	dir      string
+	// maxDiskBytes 磁盘缓存上限，diskSize 为当前估计的占用，pruning 表示正在后台清理
+	maxDiskBytes int64
+	diskSize     int64
+	pruning      bool
+}
+
+type entry struct {
+	hash string
+	data []byte
+}
+
+// New 创建缓存，maxBytes 为内存上限，maxDiskBytes 为磁盘上限，dir 为空时不启用磁盘缓存
+func New(maxBytes int, dir string, maxDiskBytes int64) *Cache {
+	c := &Cache{
+		maxBytes:     maxBytes,
+		items:        make(map[string]*list.Element),
+		lru:          list.New(),
+		maxDiskBytes: maxDiskBytes,
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			zap.L().Warn("创建TTS缓存目录失败，禁用磁盘缓存", zap.String("dir", dir), zap.Error(err))
+			return c
+		}
+		c.dir = dir
+		c.diskSize = diskUsage(dir)
+		c.pruneDiskIfFull()
+	}
+	return c
+}
+
+// Get 查询缓存，内存未命中时尝试从磁盘读取并提升到内存
+func (c *Cache) Get(key Key) ([]byte, bool) {
+	hash := key.Hash()
+
+	c.mu.Lock()
+	if elem, ok := c.items[hash]; ok {
+		c.lru.MoveToFront(elem)
+		data := elem.Value.(*entry).data
+		c.mu.Unlock()
+		metrics.Add("hits_memory", 1)
+		return data, true
+	}
+	c.mu.Unlock()
+
+	if c.dir != "" {
+		if data, err := os.ReadFile(c.path(hash)); err == nil {
+			c.touch(hash)
+			c.store(hash, data)
+			metrics.Add("hits_disk", 1)
+			return data, true
+		}
+	}
+
+	metrics.Add("misses", 1)
+	return nil, false
+}
+
+// Put 写入缓存，同时写入磁盘
+func (c *Cache) Put(key Key, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	hash := key.Hash()
+	c.store(hash, data)
+	metrics.Add("stores", 1)
+
+	if c.dir != "" {
+		if err := c.writeFile(hash, data); err != nil {
+			zap.L().Warn("写入TTS磁盘缓存失败", zap.Error(err))
+			return
+		}
+		c.mu.Lock()
+		c.diskSize += int64(len(data))
+		c.mu.Unlock()
+		c.pruneDiskIfFull()
+	}
+}
+
+// store 写入内存并按 LRU 淘汰超出上限的条目
+func (c *Cache) store(hash string, data []byte) {
+	if len(data) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[hash]; ok {
+		c.size += len(data) - len(elem.Value.(*entry).data)
+		elem.Value.(*entry).data = data
+		c.lru.MoveToFront(elem)
+	} else {
+		c.items[hash] = c.lru.PushFront(&entry{hash: hash, data: data})
+		c.size += len(data)
+	}
+
+	for c.size > c.maxBytes {
+		oldest := c.lru.Back()
+		e := oldest.Value.(*entry)
+		c.lru.Remove(oldest)
+		delete(c.items, e.hash)
+		c.size -= len(e.data)
+		metrics.Add("evictions", 1)
+	}
+}
+
+// path 返回缓存文件路径，按哈希前两位分目录
+func (c *Cache) path(hash string) string {
+	return filepath.Join(c.dir, hash[:2], hash)
+}
+
+// writeFile 先写临时文件再重命名，避免读到不完整的文件
+func (c *Cache) writeFile(hash string, data []byte) error {
+	path := c.path(hash)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,100 @@
+package ttscache
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// diskPruneRatio 磁盘缓存超出上限后清理到上限的该比例，避免每次写入都触发清理
+const diskPruneRatio = 0.9
+
+// diskFile 磁盘缓存中的一个文件
+type diskFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// touch 更新磁盘缓存文件的修改时间，磁盘缓存按修改时间淘汰最久未使用的文件
+func (c *Cache) touch(hash string) {
+	now := time.Now()
+	_ = os.Chtimes(c.path(hash), now, now)
+}
+
+// pruneDiskIfFull 磁盘缓存超出上限时在后台清理，同一时间只有一个清理任务
+func (c *Cache) pruneDiskIfFull() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxDiskBytes <= 0 || c.diskSize <= c.maxDiskBytes || c.pruning {
+		return
+	}
+	c.pruning = true
+	go c.pruneDisk()
+}
+
+// pruneDisk 按修改时间从旧到新删除磁盘缓存文件，直到占用降到上限以下，并用实际占用校正估计值
+func (c *Cache) pruneDisk() {
+	files := listDiskFiles(c.dir)
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	slices.SortFunc(files, func(a, b diskFile) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	target := int64(float64(c.maxDiskBytes) * diskPruneRatio)
+	removed := 0
+	for _, f := range files {
+		if total <= target {
+			break
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			zap.L().Warn("删除TTS磁盘缓存失败", zap.String("path", f.path), zap.Error(err))
+			continue
+		}
+		total -= f.size
+		removed++
+	}
+	metrics.Add("disk_evictions", int64(removed))
+
+	c.mu.Lock()
+	c.diskSize = total
+	c.pruning = false
+	c.mu.Unlock()
+}
+
+// diskUsage 统计磁盘缓存目录的占用
+func diskUsage(dir string) int64 {
+	var total int64
+	for _, f := range listDiskFiles(dir) {
+		total += f.size
+	}
+	return total
+}
+
+// listDiskFiles 列出磁盘缓存文件，跳过写入中的临时文件
+func listDiskFiles(dir string) []diskFile {
+	var files []diskFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.Contains(d.Name(), ".tmp") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, diskFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		zap.L().Warn("遍历TTS磁盘缓存失败", zap.String("dir", dir), zap.Error(err))
+	}
+	return files
+}
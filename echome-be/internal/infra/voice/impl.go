@@ -0,0 +1,145 @@
+package voice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/gen/gen/query"
+	"github.com/justin/echome-be/internal/domain/voice"
+	"gorm.io/gorm"
+)
+
+// VoiceRepository 实现 voice.Repo 接口
+type VoiceRepository struct {
+	query *query.Query
+}
+
+var _ voice.Repo = (*VoiceRepository)(nil)
+
+// NewVoiceRepository 创建新的VoiceRepository实例
+func NewVoiceRepository(query *query.Query) *VoiceRepository {
+	return &VoiceRepository{
+		query: query,
+	}
+}
+
+// GetByID 根据ID获取音色
+func (r *VoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*voice.Voice, error) {
+	voiceModel, err := r.query.Voice.WithContext(ctx).Where(r.query.Voice.ID.Eq(id.String())).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, voice.ErrVoiceNotFound
+		}
+		return nil, err
+	}
+	return toVoice(voiceModel)
+}
+
+// List 获取所有音色，按创建时间倒序
+func (r *VoiceRepository) List(ctx context.Context) ([]*voice.Voice, error) {
+	voiceModels, err := r.query.Voice.WithContext(ctx).Order(r.query.Voice.CreatedAt.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	return toVoices(voiceModels)
+}
+
+// GetByStatus 根据状态获取音色列表
+func (r *VoiceRepository) GetByStatus(ctx context.Context, status string) ([]*voice.Voice, error) {
+	voiceModels, err := r.query.Voice.WithContext(ctx).Where(r.query.Voice.Status.Eq(status)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return toVoices(voiceModels)
+}
+
+// Save 保存音色，已存在时覆盖，新建时回填数据库生成的ID
+func (r *VoiceRepository) Save(ctx context.Context, v *voice.Voice) error {
+	voiceModel := &model.Voice{
+		Name:           v.Name,
+		OwnerID:        v.OwnerID,
+		Provider:       v.Provider,
+		Model:          v.Model,
+		Voice:          v.Voice,
+		Status:         v.Status,
+		StatusReason:   v.StatusReason,
+		SampleURL:      v.SampleURL,
+		PreviewURL:     v.PreviewURL,
+		CharacterOwned: v.CharacterOwned,
+		CreatedAt:      v.CreatedAt,
+	}
+	if v.ID != uuid.Nil {
+		voiceModel.ID = v.ID.String()
+	}
+	if err := r.query.Voice.WithContext(ctx).Save(voiceModel); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(voiceModel.ID)
+	if err != nil {
+		return err
+	}
+	v.ID = id
+	v.CreatedAt = voiceModel.CreatedAt
+	v.UpdatedAt = voiceModel.UpdatedAt
+	return nil
+}
+
+// Delete 删除音色记录
+func (r *VoiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.query.Voice.WithContext(ctx).Where(r.query.Voice.ID.Eq(id.String())).Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return voice.ErrVoiceNotFound
+	}
+	return nil
+}
+
+// CountCharacters 统计使用该音色的角色数量
+func (r *VoiceRepository) CountCharacters(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.query.Character.WithContext(ctx).Where(r.query.Character.VoiceID.Eq(id.String())).Count()
+}
+
+// CountCharactersWithDeleted 统计引用该音色的角色数量，包括软删除的角色
+func (r *VoiceRepository) CountCharactersWithDeleted(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.query.Character.WithContext(ctx).Unscoped().Where(r.query.Character.VoiceID.Eq(id.String())).Count()
+}
+
+func toVoices(voiceModels []*model.Voice) ([]*voice.Voice, error) {
+	voices := make([]*voice.Voice, 0, len(voiceModels))
+	for _, voiceModel := range voiceModels {
+		v, err := toVoice(voiceModel)
+		if err != nil {
+			return nil, err
+		}
+		voices = append(voices, v)
+	}
+	return voices, nil
+}
+
+// toVoice 将数据库模型转换为 voice.Voice
+func toVoice(voiceModel *model.Voice) (*voice.Voice, error) {
+	id, err := uuid.Parse(voiceModel.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &voice.Voice{
+		ID:             id,
+		Name:           voiceModel.Name,
+		OwnerID:        voiceModel.OwnerID,
+		Provider:       voiceModel.Provider,
+		Model:          voiceModel.Model,
+		Voice:          voiceModel.Voice,
+		Status:         voiceModel.Status,
+		StatusReason:   voiceModel.StatusReason,
+		SampleURL:      voiceModel.SampleURL,
+		PreviewURL:     voiceModel.PreviewURL,
+		CharacterOwned: voiceModel.CharacterOwned,
+		CreatedAt:      voiceModel.CreatedAt,
+		UpdatedAt:      voiceModel.UpdatedAt,
+	}, nil
+}
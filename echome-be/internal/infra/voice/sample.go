@@ -0,0 +1,80 @@
+package voice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/justin/echome-be/internal/domain/voice"
+	"github.com/justin/echome-be/internal/infra/audio"
+)
+
+// sampleDownloadTimeout 下载复刻样本的超时时间
+const sampleDownloadTimeout = 30 * time.Second
+
+// SampleAnalyzer 下载复刻样本并用纯 Go 解码分析，实现 voice.SampleAnalyzer 接口
+type SampleAnalyzer struct {
+	httpClient *http.Client
+}
+
+var _ voice.SampleAnalyzer = (*SampleAnalyzer)(nil)
+
+// NewSampleAnalyzer 创建样本分析器
+func NewSampleAnalyzer() *SampleAnalyzer {
+	return &SampleAnalyzer{
+		httpClient: &http.Client{Timeout: sampleDownloadTimeout},
+	}
+}
+
+// Analyze 下载并分析样本
+func (a *SampleAnalyzer) Analyze(ctx context.Context, url string) (*voice.SampleStats, error) {
+	data, err := a.download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := audio.AnalyzeSample(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voice.ErrSampleFormat, err)
+	}
+	return &voice.SampleStats{
+		Format:          stats.Format,
+		SampleRate:      stats.SampleRate,
+		Channels:        stats.Channels,
+		DurationSeconds: stats.Duration.Seconds(),
+		PeakDBFS:        stats.PeakDBFS,
+		LoudnessDBFS:    stats.LoudnessDBFS,
+		ClippingRatio:   stats.ClippingRatio,
+		SilenceRatio:    stats.SilenceRatio,
+	}, nil
+}
+
+// download 下载样本，超过 voice.MaxSampleBytes 时返回 voice.ErrSampleTooLarge
+func (a *SampleAnalyzer) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voice.ErrSampleUnreachable, err)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voice.ErrSampleUnreachable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: 状态码 %d", voice.ErrSampleUnreachable, resp.StatusCode)
+	}
+	if resp.ContentLength > voice.MaxSampleBytes {
+		return nil, voice.ErrSampleTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, voice.MaxSampleBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voice.ErrSampleUnreachable, err)
+	}
+	if len(data) > voice.MaxSampleBytes {
+		return nil, voice.ErrSampleTooLarge
+	}
+	return data, nil
+}
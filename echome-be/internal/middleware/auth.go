@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/justin/echome-be/config"
+	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/internal/domain/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+// tokenClaims 访问令牌中用到的字段
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// AuthMiddleware 校验 HS256 访问令牌并把当前用户写入请求的 context
+// 令牌从 Authorization: Bearer 头读取，浏览器的 WebSocket 无法设置请求头，也可以通过 token 查询参数传递；
+// 没有令牌的请求按匿名用户处理，由各接口决定是否允许；未配置密钥时无法校验令牌，所有请求都按匿名用户处理
+// 只有显式配置 auth.disabled 时才关闭鉴权，所有请求都以本地管理员身份处理
+func AuthMiddleware(cfg *config.AuthConfig) echo.MiddlewareFunc {
+	switch {
+	case cfg.Disabled:
+		zap.L().Warn("auth.disabled 已开启，鉴权已关闭，所有请求都以本地管理员身份处理")
+	case cfg.JWTSecret == "":
+		zap.L().Warn("未配置 auth.jwt_secret，无法校验访问令牌，所有请求都按匿名用户处理")
+	}
+	secret := []byte(cfg.JWTSecret)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var user *auth.User
+			if cfg.Disabled {
+				user = auth.LocalUser
+			} else if token := requestToken(c); token != "" && len(secret) > 0 {
+				var err error
+				if user, err = verifyToken(token, secret, time.Now()); err != nil {
+					return domain.Unauthorized(c, "Invalid access token", err.Error())
+				}
+			}
+
+			if user != nil {
+				req := c.Request()
+				c.SetRequest(req.WithContext(auth.WithUser(req.Context(), user)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// requestToken 从请求头或查询参数中读取访问令牌
+func requestToken(c echo.Context) string {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return c.QueryParam("token")
+}
+
+// verifyToken 校验令牌签名和有效期，返回令牌对应的用户
+func verifyToken(token string, secret []byte, now time.Time) (*auth.User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims.Subject == "" {
+		return nil, errInvalidToken
+	}
+	if claims.ExpiresAt != nil && now.Unix() >= *claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return nil, errors.New("token not valid yet")
+	}
+
+	return &auth.User{ID: claims.Subject, Admin: claims.Role == "admin"}, nil
+}
+
+// decodeSegment 解码令牌中 base64url 编码的 JSON 段
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
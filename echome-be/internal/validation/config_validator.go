@@ -135,12 +135,22 @@ func (v *ConfigValidator) validateTTSConfig(tts *config.TTSServiceConfig) error
 		return fmt.Errorf("TTS sample rate must be positive: %d", tts.SampleRate)
 	}
 
-	supportedFormats := []string{"pcm", "wav", "mp3"}
+	supportedFormats := []string{"pcm", "wav", "mp3", "opus"}
 	if tts.ResponseFormat != "" && !v.contains(supportedFormats, tts.ResponseFormat) {
 		return fmt.Errorf("unsupported TTS response format: %s, supported: %s",
 			tts.ResponseFormat, strings.Join(supportedFormats, ", "))
 	}
 
+	supportedModes := []string{"server_commit", "commit"}
+	if tts.Mode != "" && !v.contains(supportedModes, tts.Mode) {
+		return fmt.Errorf("unsupported TTS mode: %s, supported: %s",
+			tts.Mode, strings.Join(supportedModes, ", "))
+	}
+
+	if tts.Cache.MaxMemoryMB < 0 || tts.Cache.MaxTextLength < 0 {
+		return fmt.Errorf("TTS cache limits must not be negative")
+	}
+
 	return nil
 }
 
@@ -5,10 +5,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"strings"
 
-	"github.com/google/uuid"
 	"github.com/justin/echome-be/config"
-	"github.com/justin/echome-be/internal/domain"
+	"github.com/justin/echome-be/gen/gen/model"
+	"github.com/justin/echome-be/internal/domain/character"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
@@ -46,52 +47,260 @@ func main() {
 	if err != nil {
 		zap.L().Fatal("Failed to connect to database", zap.Error(err))
 	}
-	if err != nil {
-		zap.L().Fatal("Failed to connect to database", zap.Error(err))
-	}
 
 	// 执行迁移
 	zap.L().Info("Running database migrations...")
 
+	// 引入可见性之前的角色所有人都能看到，保持公开；之后新建的角色默认私有
+	if db.Migrator().HasTable(&model.Character{}) && !db.Migrator().HasColumn(&model.Character{}, "visibility") {
+		err = db.Exec("ALTER TABLE characters ADD COLUMN visibility text NOT NULL DEFAULT 'public'").Error
+		if err != nil {
+			zap.L().Fatal("Failed to add characters.visibility", zap.Error(err))
+		}
+	}
+
+	// 拆分音色状态和内容审核状态：已可用的角色视为审核通过，音色状态在音色表迁移后回填
+	splitStatus := db.Migrator().HasTable(&model.Character{}) && !db.Migrator().HasColumn(&model.Character{}, "review_status")
+	if splitStatus {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, sql := range []string{
+				"ALTER TABLE characters ADD COLUMN review_status text NOT NULL DEFAULT 'pending'",
+				"UPDATE characters SET review_status = 'approved' WHERE status = 2",
+				"ALTER TABLE characters ADD COLUMN voice_status text NOT NULL DEFAULT 'ready'",
+			} {
+				if err := tx.Exec(sql).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			zap.L().Fatal("Failed to split characters.status", zap.Error(err))
+		}
+	}
+
+	// 对话次数在对话记录表上线之后才开始统计，需要从已有的对话记录回填
+	backfillSessions := db.Migrator().HasTable(&model.Character{}) && !db.Migrator().HasColumn(&model.Character{}, "session_count")
+
 	// 创建角色表
-	err = db.AutoMigrate(&domain.Character{})
+	err = db.AutoMigrate(&model.Character{})
 	if err != nil {
 		zap.L().Fatal("Failed to migrate characters table", zap.Error(err))
 	}
 
-	// 创建索引
-	err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_characters_name ON characters (name)").Error
+	// 创建索引，角色名只在同一所有者未删除的角色中唯一，已删除角色的名字可以重新使用
+	// 旧版索引只包含 name，定义不一致时才重建，避免每次启动都重建索引
+	var nameIndexDef string
+	err = db.Raw("SELECT indexdef FROM pg_indexes WHERE tablename = 'characters' AND indexname = 'idx_characters_name'").Scan(&nameIndexDef).Error
+	if err != nil {
+		zap.L().Fatal("Failed to inspect index on characters.name", zap.Error(err))
+	}
+	if nameIndexDef != "" && !strings.Contains(nameIndexDef, "owner_id") {
+		err = db.Exec("DROP INDEX idx_characters_name").Error
+		if err != nil {
+			zap.L().Fatal("Failed to drop index on characters.name", zap.Error(err))
+		}
+	}
+	// 内置角色没有所有者，owner_id 为空时按空字符串参与唯一约束
+	err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_characters_name ON characters (COALESCE(owner_id, ''), name) WHERE deleted_at IS NULL").Error
 	if err != nil {
 		zap.L().Fatal("Failed to create index on characters.name", zap.Error(err))
 	}
 
+	// 全文检索：search_text 由服务端切词写入，search_vector 由数据库生成，不经过分词解析器
+	err = db.Exec(`ALTER TABLE characters ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (array_to_tsvector(string_to_array(coalesce(search_text, ''), ' '))) STORED`).Error
+	if err != nil {
+		zap.L().Fatal("Failed to add characters.search_vector", zap.Error(err))
+	}
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_characters_search_vector ON characters USING gin (search_vector)").Error
+	if err != nil {
+		zap.L().Fatal("Failed to create index on characters.search_vector", zap.Error(err))
+	}
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_characters_created_at_id ON characters (created_at DESC, id DESC)").Error
+	if err != nil {
+		zap.L().Fatal("Failed to create index on characters.created_at", zap.Error(err))
+	}
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_characters_popular ON characters (session_count DESC, created_at DESC, id DESC)").Error
+	if err != nil {
+		zap.L().Fatal("Failed to create index on characters.session_count", zap.Error(err))
+	}
+	// 音色表改为以音色库ID为主键，原 voice_id 列保存上游音色
+	if db.Migrator().HasColumn(&model.Voice{}, "voice_id") {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, sql := range []string{
+				"ALTER TABLE voices ADD COLUMN IF NOT EXISTS id uuid NOT NULL DEFAULT gen_random_uuid()",
+				"ALTER TABLE voices DROP CONSTRAINT IF EXISTS voices_pkey",
+				"ALTER TABLE voices ADD PRIMARY KEY (id)",
+				"ALTER TABLE voices RENAME COLUMN voice_id TO voice",
+				"ALTER TABLE voices RENAME COLUMN source_url TO sample_url",
+			} {
+				if err := tx.Exec(sql).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			zap.L().Fatal("Failed to convert voices table", zap.Error(err))
+		}
+	}
+
+	// 创建音色表
+	err = db.AutoMigrate(&model.Voice{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate voices table", zap.Error(err))
+	}
+
+	// 把角色的复刻音色登记到音色库，音色名和状态取自角色，这些音色随角色复刻，随角色释放
+	const voiceStatus = `CASE c.status WHEN 2 THEN 'ready' WHEN 4 THEN 'failed' WHEN 5 THEN 'rejected' ELSE 'pending' END`
+	err = db.Exec(`UPDATE voices v SET name = c.name, provider = 'clone', model = 'cosyvoice-v2',
+		status = ` + voiceStatus + `, status_reason = c.status_reason, character_owned = true
+		FROM characters c WHERE c.flag AND c.voice = v.voice AND v.name = ''`).Error
+	if err != nil {
+		zap.L().Fatal("Failed to backfill voices", zap.Error(err))
+	}
+	err = db.Exec(`INSERT INTO voices (name, provider, model, voice, status, status_reason, sample_url, character_owned)
+		SELECT c.name, 'clone', 'cosyvoice-v2', c.voice, ` + voiceStatus + `, c.status_reason, c.audio_example, true
+		FROM characters c WHERE c.flag AND c.voice IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM voices v WHERE v.voice = c.voice)`).Error
+	if err != nil {
+		zap.L().Fatal("Failed to backfill voices", zap.Error(err))
+	}
+	err = db.Exec(`UPDATE characters c SET voice_id = v.id FROM voices v
+		WHERE c.flag AND c.voice = v.voice AND c.voice_id IS NULL`).Error
+	if err != nil {
+		zap.L().Fatal("Failed to link characters to voices", zap.Error(err))
+	}
+	if splitStatus {
+		err = db.Exec("UPDATE characters c SET voice_status = v.status FROM voices v WHERE c.voice_id = v.id").Error
+		if err != nil {
+			zap.L().Fatal("Failed to backfill characters.voice_status", zap.Error(err))
+		}
+	}
+	err = db.Exec("ALTER TABLE voices DROP COLUMN IF EXISTS character_id").Error
+	if err != nil {
+		zap.L().Fatal("Failed to drop voices.character_id", zap.Error(err))
+	}
+
+	// 创建任务表
+	err = db.AutoMigrate(&model.Job{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate jobs table", zap.Error(err))
+	}
+
+	// 为清除任务上线前已删除的角色补建清除任务，在删除时间加保留期后执行
+	err = db.Exec(`INSERT INTO jobs (type, ref_id, status, next_run_at)
+		SELECT ?, c.id::text, ?, c.deleted_at + make_interval(secs => ?)
+		FROM characters c
+		WHERE c.deleted_at IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM jobs j WHERE j.type = ? AND j.ref_id = c.id::text AND j.status = ?
+		)`,
+		character.JobTypeCharacterPurge, "pending", character.DeletedRetention.Seconds(),
+		character.JobTypeCharacterPurge, "pending").Error
+	if err != nil {
+		zap.L().Fatal("Failed to schedule purge jobs for deleted characters", zap.Error(err))
+	}
+
+	// 创建角色版本表和对话记录表
+	err = db.AutoMigrate(&model.CharacterVersion{}, &model.Conversation{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate character_versions and conversations tables", zap.Error(err))
+	}
+
+	// 创建标签表和角色标签关联表
+	err = db.AutoMigrate(&model.Tag{}, &model.CharacterTag{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate tags and character_tags tables", zap.Error(err))
+	}
+
+	// 创建角色审核记录表
+	err = db.AutoMigrate(&model.CharacterReview{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate character_reviews table", zap.Error(err))
+	}
+
+	// 创建角色收藏表
+	err = db.AutoMigrate(&model.CharacterFavorite{})
+	if err != nil {
+		zap.L().Fatal("Failed to migrate character_favorites table", zap.Error(err))
+	}
+	if backfillSessions {
+		err = db.Exec(`UPDATE characters c SET session_count = s.count
+			FROM (SELECT character_id, count(*) AS count FROM conversations GROUP BY character_id) s
+			WHERE c.id = s.character_id`).Error
+		if err != nil {
+			zap.L().Fatal("Failed to backfill characters.session_count", zap.Error(err))
+		}
+	}
+
 	// 检查是否需要插入默认数据
 	var count int64
-	db.Model(&domain.Character{}).Count(&count)
+	db.Model(&model.Character{}).Count(&count)
 	if count == 0 {
 		zap.L().Info("No existing characters found, inserting default characters...")
 		insertDefaultCharacters(db)
 	}
+	backfillSearchText(db)
+	backfillVersions(db)
 
 	zap.L().Info("Database migration completed successfully")
 }
 
+// backfillSearchText 为检索功能上线前创建的角色计算检索词
+func backfillSearchText(db *gorm.DB) {
+	var characters []*model.Character
+	if err := db.Unscoped().Where("search_text IS NULL").Find(&characters).Error; err != nil {
+		zap.L().Fatal("Failed to load characters for search backfill", zap.Error(err))
+	}
+	for _, c := range characters {
+		searchText := character.SearchText(&character.Character{Name: c.Name, Description: c.Description})
+		err := db.Model(&model.Character{}).Unscoped().Where("id = ?", c.ID).Update("search_text", searchText).Error
+		if err != nil {
+			zap.L().Fatal("Failed to backfill search text", zap.String("id", c.ID), zap.Error(err))
+		}
+	}
+}
+
+// backfillVersions 为版本历史上线前创建的角色保存当前内容作为第1版
+func backfillVersions(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO character_versions (character_id, version, change, author_id, name, prompt,
+			description, avatar, audio_example, voice_id, visibility, hotwords, created_at)
+			SELECT id, 1, 'create', owner_id, name, prompt, description, avatar, audio_example, voice_id,
+			visibility, hotwords, updated_at FROM characters WHERE version = 0`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE characters SET version = 1 WHERE version = 0").Error
+	})
+	if err != nil {
+		zap.L().Fatal("Failed to backfill character versions", zap.Error(err))
+	}
+}
+
 // insertDefaultCharacters 插入默认角色数据
 func insertDefaultCharacters(db *gorm.DB) {
-	defaultCharacters := []*domain.Character{
+	defaultCharacters := []*model.Character{
 		{
-			ID:          uuid.New(),
-			Name:        "小助手",
-			Prompt:      "你是一个友善、耐心的AI助手，总是乐于帮助用户解决问题。你说话温和，回答详细且有用。",
-			Avatar:      nil,
-			Voice:       lo.ToPtr("xiaoyun"), // 阿里云小云语音
+			Name:         "小助手",
+			Prompt:       "你是一个友善、耐心的AI助手，总是乐于帮助用户解决问题。你说话温和，回答详细且有用。",
+			Greeting:     lo.ToPtr("你好，我是小助手，有什么可以帮你的吗？"),
+			Avatar:       nil,
+			Voice:        lo.ToPtr("xiaoyun"), // 阿里云小云语音
+			Status:       2,
+			ReviewStatus: "approved",
+			Visibility:   "public",
 		},
 		{
-			ID:          uuid.New(),
-			Name:        "专业顾问",
-			Prompt:   "你是一个专业的技术顾问，具有丰富的技术知识和经验。你的回答准确、专业，善于用简单的语言解释复杂的技术概念。",
-			Avatar:   nil,
-			Voice: lo.ToPtr("zhiwei"), // 阿里云志伟语音（男声）
+			Name:         "专业顾问",
+			Prompt:       "你是一个专业的技术顾问，具有丰富的技术知识和经验。你的回答准确、专业，善于用简单的语言解释复杂的技术概念。",
+			Greeting:     lo.ToPtr("你好，我是你的技术顾问。今天想聊聊哪方面的技术问题？"),
+			Avatar:       nil,
+			Voice:        lo.ToPtr("zhiwei"), // 阿里云志伟语音（男声）
+			Status:       2,
+			ReviewStatus: "approved",
+			Visibility:   "public",
 		},
 	}
 